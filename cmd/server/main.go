@@ -23,7 +23,22 @@ import (
 )
 
 func main() {
-	if err := cmd.RunServer(); err != nil {
+	run := cmd.RunServer
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "gc-blobs":
+			run = cmd.RunBlobGC
+		case "rotate-keys":
+			run = cmd.RunKeyRotation
+		case "reconcile-storage":
+			run = cmd.RunStorageReconciliation
+		default:
+			fmt.Fprintf(os.Stderr, "unknown command: %s\n", os.Args[1])
+			os.Exit(2)
+		}
+	}
+
+	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
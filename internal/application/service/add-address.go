@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/google/uuid"
+)
+
+func NewAddAddressApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *AddAddressApplicationService {
+	return &AddAddressApplicationService{repository, publisher}
+}
+
+type AddAddressApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *AddAddressApplicationService) Do(req *v1.AddAddressRequest) (*v1.AddAddressResponse, error) {
+	address, err := model.NewAddress(req.Type, req.Lines, req.Locality, req.Region, req.PostalCode, req.Country)
+	if err != nil {
+		return &v1.AddAddressResponse{}, err
+	}
+	address.ID = uuid.NewString()
+
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.AddAddressResponse{}, err
+	}
+
+	user.AddAddress(address)
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return &v1.AddAddressResponse{}, err
+	}
+
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return &v1.AddAddressResponse{Address: address.ToDTO()}, nil
+}
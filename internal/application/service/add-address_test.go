@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddAddressApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	req := &v1.AddAddressRequest{
+		UserID:     userID,
+		Type:       "shipping",
+		Lines:      []string{"1600 Amphitheatre Parkway"},
+		Locality:   "Mountain View",
+		Region:     "CA",
+		PostalCode: "94043",
+		Country:    "US",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewAddAddressApplicationService(mockRepo, mockEventPublisher)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+		mockRepo.On("Update", userID, mock.MatchedBy(func(u *model.User) bool { return len(u.GetAddresses()) == 1 })).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.Address.ID)
+		assert.Equal(t, "94043", res.Address.PostalCode)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Postal Code", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewAddAddressApplicationService(mockRepo, nil)
+
+		invalid := *req
+		invalid.PostalCode = "SW1A 2AA"
+
+		res, err := service.Do(&invalid)
+
+		assert.ErrorIs(t, err, model.ErrInvalidPostalCode)
+		assert.Equal(t, &v1.AddAddressResponse{}, res)
+		mockRepo.AssertNotCalled(t, "Get", mock.Anything)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewAddAddressApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
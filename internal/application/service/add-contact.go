@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/google/uuid"
+)
+
+func NewAddContactPointApplicationService(
+	repository domain.UserRepository,
+	publisher domain.EventPublisher,
+	verifier *EmailVerifier) *AddContactPointApplicationService {
+	return &AddContactPointApplicationService{repository, publisher, verifier}
+}
+
+type AddContactPointApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+	verifier   *EmailVerifier
+}
+
+func (s *AddContactPointApplicationService) Do(req *v1.AddContactPointRequest) (*v1.AddContactPointResponse, error) {
+	contact, err := model.NewContactPoint(req.Type, req.Value, req.Label)
+	if err != nil {
+		return &v1.AddContactPointResponse{}, err
+	}
+	contact.ID = uuid.NewString()
+
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.AddContactPointResponse{}, err
+	}
+
+	// an email address identifies a single user
+	if contact.Type == model.ContactEmail {
+		owner, err := s.repository.GetByEmail(contact.Value)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return &v1.AddContactPointResponse{}, err
+		}
+		if owner != nil && owner.ID != user.ID {
+			return &v1.AddContactPointResponse{}, model.ErrContactPointAlreadyExists
+		}
+	}
+
+	err = user.AddContactPoint(contact)
+	if err != nil {
+		return &v1.AddContactPointResponse{}, err
+	}
+
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return &v1.AddContactPointResponse{}, err
+	}
+
+	if contact.Type == model.ContactEmail {
+		// the contact point exists even if the token couldn't be sent, a new one can be requested
+		err = s.verifier.SendTo(user, contact.Value)
+		if err != nil {
+			log.Printf("error sending verification of contact point %s: %s", contact.ID, err)
+		}
+	}
+
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return &v1.AddContactPointResponse{Contact: contact.ToDTO()}, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddContactPointApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		return user
+	}
+
+	t.Run("Phone number", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewAddContactPointApplicationService(mockRepo, mockEventPublisher, verifier)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("Update", userID, mock.MatchedBy(func(u *model.User) bool { return len(u.GetContactPoints()) == 1 })).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(&v1.AddContactPointRequest{UserID: userID, Type: "phone", Value: "+44 7911 123456"})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.Contact.ID)
+		assert.Equal(t, "+447911123456", res.Contact.Value)
+		assert.True(t, res.Contact.Primary)
+		assert.False(t, res.Contact.Verified)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Email address is sent a verification token", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		verifier, mockTokenRepo, mockMailer := newTestEmailVerifier()
+		service := NewAddContactPointApplicationService(mockRepo, mockEventPublisher, verifier)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetByEmail", "work@example.com").Return(nil, domain.ErrUserNotFound).Once()
+		mockRepo.On("Update", userID, mock.Anything).Return(nil).Once()
+		mockTokenRepo.On("DeleteByUser", userID).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.MatchedBy(func(token *model.VerificationToken) bool {
+			return token.Email == "work@example.com"
+		})).Return(nil).Once()
+		mockMailer.On("Send", "work@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(&v1.AddContactPointRequest{UserID: userID, Type: "email", Value: "work@example.com"})
+
+		assert.NoError(t, err)
+		assert.False(t, res.Contact.Primary)
+		mockTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Email address of another user", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewAddContactPointApplicationService(mockRepo, nil, verifier)
+
+		other, _ := model.NewUser("Other User", "work@example.com", "1990-01-01")
+		other.ID = "user-456"
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetByEmail", "work@example.com").Return(other, nil).Once()
+
+		res, err := service.Do(&v1.AddContactPointRequest{UserID: userID, Type: "email", Value: "work@example.com"})
+
+		assert.ErrorIs(t, err, model.ErrContactPointAlreadyExists)
+		assert.Equal(t, &v1.AddContactPointResponse{}, res)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid phone number", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewAddContactPointApplicationService(mockRepo, nil, verifier)
+
+		_, err := service.Do(&v1.AddContactPointRequest{UserID: userID, Type: "phone", Value: "07911 123456"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidPhoneNumber)
+		mockRepo.AssertNotCalled(t, "Get", mock.Anything)
+	})
+}
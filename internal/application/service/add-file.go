@@ -1,25 +1,39 @@
 package service
 
 import (
+	"fmt"
+	"io"
 	"log"
+	"mime"
+	"path/filepath"
 
 	"github.com/bizio/abc-user-service/internal/domain"
 	"github.com/bizio/abc-user-service/internal/domain/model"
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gabriel-vasile/mimetype"
 	"github.com/google/uuid"
 )
 
+// genericContentType tells nothing about a file, clients send it when they don't know its type
+const genericContentType = "application/octet-stream"
+
 func NewAddFileApplicationService(
 	repository domain.UserRepository,
 	storage domain.FileRepository,
-	maxFileSize int64) *AddFileApplicationService {
-	return &AddFileApplicationService{repository, storage, maxFileSize}
+	policy *model.UploadPolicy,
+	quota *model.StorageQuota,
+	files *FileScanner,
+	versions *FileVersions) *AddFileApplicationService {
+	return &AddFileApplicationService{repository, storage, policy, quota, files, versions}
 }
 
 type AddFileApplicationService struct {
-	repository  domain.UserRepository
-	storage     domain.FileRepository
-	maxFileSize int64
+	repository domain.UserRepository
+	storage    domain.FileRepository
+	policy     *model.UploadPolicy
+	quota      *model.StorageQuota
+	files      *FileScanner
+	versions   *FileVersions
 }
 
 func (s *AddFileApplicationService) Do(req *v1.UploadFileRequest) (*v1.UploadFileResponse, error) {
@@ -28,30 +42,184 @@ func (s *AddFileApplicationService) Do(req *v1.UploadFileRequest) (*v1.UploadFil
 		return nil, err
 	}
 
-	if req.File.Size > s.maxFileSize {
+	if !user.CanModifyFiles() {
+		return nil, model.ErrFilesReadOnly
+	}
+
+	// no type is allowed files this large, don't bother reading them
+	if req.File.Size > s.policy.LargestMaxSize() {
 		return nil, model.ErrFileTooLarge
 	}
 
-	filepath, err := s.storage.Upload(req.UserID, req.File)
+	if err := checkFolder(s.repository, user.ID, req.FolderID); err != nil {
+		return nil, err
+	}
+
+	// a file with the same name in the folder gets the content as its next version
+	existing, err := user.GetFileByName(req.FolderID, req.File.Filename)
+	if err != nil {
+		existing = nil
+	}
+
+	// fail early, the repository checks the quota again when it accounts for the file
+	if existing == nil {
+		if err := checkStorageQuota(s.repository, req.UserID, req.File.Size, s.quota); err != nil {
+			return nil, err
+		}
+	}
+
+	var expectedDigest string
+	if req.Digest != "" {
+		if expectedDigest, err = model.ParseDigest(req.Digest); err != nil {
+			return nil, err
+		}
+	}
+
+	declaredType := declaredContentType(req.File.Header.Get("Content-Type"), req.File.Filename)
+	f, err := req.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := checkContentType(s.policy, f, declaredType, req.File.Size)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// infected content is quarantined before it reaches the user's files
+	scanStatus, err := s.files.Check(req.UserID, req.File.Filename, req.File.Size, func() (io.ReadCloser, error) {
+		return req.File.Open()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the content is stored under a new generated key, the name sent by the client is only displayed. An
+	// existing file is switched to the new content, its current content stays where it is as a version.
+	fileID := uuid.NewString()
+	storageName := fileID
+	undo := func(reason string) {
+		if err := s.storage.Delete(req.UserID, storageName); err != nil {
+			log.Printf("error deleting file %s: %s", reason, err)
+		}
+	}
+
+	filepath, digest, err := s.storage.Upload(req.UserID, storageName, req.File)
 	if err != nil {
 		log.Printf("error uploading file: %s", err)
 		return nil, err
 	}
+	if expectedDigest != "" && digest != expectedDigest {
+		undo("with mismatching digest")
+		return nil, model.ErrDigestMismatch
+	}
 
 	newFile := &model.File{
-		ID:     uuid.NewString(),
-		UserID: req.UserID,
-		Name:   req.File.Filename,
-		Path:   filepath,
-		Size:   req.File.Size,
+		ID:           fileID,
+		UserID:       req.UserID,
+		Name:         req.File.Filename,
+		StorageKey:   fileID,
+		Path:         filepath,
+		Size:         req.File.Size,
+		ContentType:  contentType,
+		DeclaredType: declaredType,
+		Digest:       digest,
+		ScanStatus:   scanStatus,
+		Version:      1,
+		FolderID:     req.FolderID,
+	}
+	if existing != nil {
+		replaced := *existing
+		replaced.Replace(newFile)
+		if err := s.versions.replace(&replaced, s.versions.archive(existing), nil, s.quota); err != nil {
+			undo("that couldn't be replaced")
+			return nil, err
+		}
+		*existing = replaced
+		newFile = existing
+	} else {
+		if err := s.repository.AddFile(newFile, s.quota); err != nil {
+			undo("that couldn't be added")
+			return nil, err
+		}
+		user.AddFile(newFile)
+	}
+
+	res := &v1.UploadFileResponse{File: newFile.ToDTO()}
+	for _, duplicate := range user.FindDuplicateFiles(newFile) {
+		res.Duplicates = append(res.Duplicates, duplicate.ID)
 	}
-	user.AddFile(newFile)
+	return res, nil
 
-	err = s.repository.Update(user.ID, user)
+}
+
+// checkFolder tells whether the folder of the user exists, the empty ID of the root does
+func checkFolder(repository domain.UserRepository, userID, folderID string) error {
+	if folderID == "" {
+		return nil
+	}
+	folders, err := repository.GetFolders(userID)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return model.NewFolderTree(folders).Check(folderID)
+}
 
-	return &v1.UploadFileResponse{File: user.GetFiles()[len(user.GetFiles())-1].ToDTO()}, nil
+// checkStorageQuota tells whether a file of the size fits in the storage quota of the user
+func checkStorageQuota(repository domain.UserRepository, userID string, size int64, defaultQuota *model.StorageQuota) error {
+	usage, err := repository.GetStorageUsage(userID)
+	if err != nil {
+		return err
+	}
+	return usage.Check(size, defaultQuota)
+}
 
+// checkContentType detects the type of the content and applies the policy to it. The declared type and file name
+// are not trusted, a declared type that the content can't be is rejected.
+func checkContentType(policy *model.UploadPolicy, content io.Reader, declaredType string, size int64) (string, error) {
+	detected, err := mimetype.DetectReader(content)
+	if err != nil {
+		return "", err
+	}
+	if !typesMatch(detected, declaredType) {
+		return "", fmt.Errorf("%w: detected %s, declared %s", model.ErrFileTypeMismatch, detected, declaredType)
+	}
+
+	contentType := model.MediaTypeEssence(detected.String())
+	if err := policy.Check(contentType, size); err != nil {
+		return "", err
+	}
+	return contentType, nil
+}
+
+// declaredContentType is the type sent by the client, or the one of the file name extension if the client
+// didn't send a specific one
+func declaredContentType(contentType, filename string) string {
+	declared := model.MediaTypeEssence(contentType)
+	if declared == "" || declared == genericContentType {
+		declared = model.MediaTypeEssence(mime.TypeByExtension(filepath.Ext(filename)))
+	}
+	return declared
+}
+
+// typesMatch tells whether the content can be what the client declared. Detection only knows the formats it
+// has signatures for, e.g. a CSV can be detected as plain text and a DOCX as a ZIP, so either type can be a
+// more generic kind of the other one. Unknown binary content doesn't match any specific type.
+func typesMatch(detected *mimetype.MIME, declared string) bool {
+	if declared == "" || declared == genericContentType {
+		return true
+	}
+	for m := detected; m != nil; m = m.Parent() {
+		if m.Is(declared) {
+			return true
+		}
+	}
+	if known := mimetype.Lookup(declared); known != nil {
+		for m := known; m.Parent() != nil; m = m.Parent() {
+			if m.Is(detected.String()) {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"mime/multipart"
+	"strings"
 	"testing"
 
 	"github.com/bizio/abc-user-service/internal/domain"
@@ -16,6 +17,10 @@ import (
 func TestAddFileApplicationService_Do(t *testing.T) {
 	userID := "user-123"
 	maxSize := int64(1024)
+	pngContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	pngDigest := "2d8c7bd1e0eae3dd8fba6a2b3c80c2f6c06c66d2ec2b77bcf6ad5a77f8f98ba0"
+	policy, _ := model.NewUploadPolicy(nil, []string{"application/vnd.microsoft.portable-executable"}, maxSize, nil)
+	quota := &model.StorageQuota{Bytes: 4096, Files: 10}
 
 	// Create a base user for tests
 	user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
@@ -24,22 +29,20 @@ func TestAddFileApplicationService_Do(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
-		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, maxSize)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
 
-		fileHeader := &multipart.FileHeader{
-			Filename: "test.jpg",
-			Size:     512,
-		}
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
 		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
-		filePath := "/uploads/test.jpg"
+		filePath := "/uploads/test.png"
 
 		// Need a fresh copy of the user for the mock return
 		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
 		userCopy.ID = userID
 
 		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
-		mockFileRepo.On("Upload", userID, fileHeader).Return(filePath, nil).Once()
-		mockUserRepo.On("Update", userID, mock.Anything).Return(nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return(filePath, pngDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.AnythingOfType("*model.File"), quota).Return(nil).Once()
 
 		res, err := service.Do(req)
 
@@ -47,15 +50,69 @@ func TestAddFileApplicationService_Do(t *testing.T) {
 		assert.NotNil(t, res)
 		assert.Equal(t, fileHeader.Filename, res.File.Name)
 		assert.Equal(t, filePath, res.File.Path)
+		assert.Equal(t, "image/png", res.File.ContentType)
+		assert.Equal(t, "image/png", res.File.DeclaredType)
+		assert.Equal(t, pngDigest, res.File.Digest)
+		assert.Empty(t, res.Duplicates)
 		assert.NotEmpty(t, res.File.ID)
+		// the content is stored under the generated ID, not the name sent by the client
+		mockFileRepo.AssertCalled(t, "Upload", userID, res.File.ID, fileHeader)
 		mockUserRepo.AssertExpectations(t)
 		mockFileRepo.AssertExpectations(t)
 	})
 
+	t.Run("Infected", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota,
+			NewFileScanner(mockScanner, mockQuarantine, mockPublisher, false), noFileVersions)
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockScanner.On("Scan", mock.Anything).Return("Eicar-Test-Signature", nil).Once()
+		mockQuarantine.On("Save", userID, mock.Anything, mock.Anything).Return("/quarantine/q-123", nil).Once()
+		mockPublisher.On("Publish", mock.AnythingOfType("*domain.Event")).Return(nil).Once()
+
+		res, err := service.Do(&v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "test.png", pngContent)})
+
+		assert.ErrorIs(t, err, model.ErrFileInfected)
+		assert.Nil(t, res)
+		mockQuarantine.AssertExpectations(t)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "AddFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Pending Scan", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota,
+			NewFileScanner(new(mocks.Scanner), new(mocks.FileRepository), new(mocks.EventPublisher), true), noFileVersions)
+
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/test.png", pngDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.AnythingOfType("*model.File"), quota).Return(nil).Once()
+
+		res, err := service.Do(&v1.UploadFileRequest{UserID: userID, File: fileHeader})
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ScanPending, res.File.ScanStatus)
+	})
+
 	t.Run("User Not Found", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
-		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, maxSize)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
 
 		req := &v1.UploadFileRequest{UserID: "not-found"}
 
@@ -65,14 +122,108 @@ func TestAddFileApplicationService_Do(t *testing.T) {
 
 		assert.ErrorIs(t, err, domain.ErrUserNotFound)
 		assert.Nil(t, res)
-		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
-		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "AddFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("In Folder", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
+		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader, FolderID: "photos"}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/test.png", pngDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.MatchedBy(func(f *model.File) bool { return f.FolderID == "photos" }), quota).Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photos", res.File.FolderID)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Same Name In Another Folder", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner,
+			NewFileVersions(mockUserRepo, mockFileRepo, 10))
+
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
+		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader, FolderID: "photos"}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+		userCopy.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "test.png", FolderID: "documents", Version: 1})
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/test.png", pngDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.ID != "file-123" && f.FolderID == "photos" && f.Version == 1
+		}), quota).Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, "file-123", res.File.ID)
+		mockUserRepo.AssertExpectations(t)
+		// the file with the same name in the other folder is left alone
+		mockUserRepo.AssertNotCalled(t, "ReplaceFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Re-upload To Unknown Folder", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		req := &v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "test.png", pngContent), FolderID: "unknown"}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+		userCopy.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "test.png", FolderID: "unknown", Version: 1})
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Folder Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		req := &v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "test.png", pngContent), FolderID: "unknown"}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+		assert.Nil(t, res)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("File Too Large", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
-		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, maxSize)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
 
 		fileHeader := &multipart.FileHeader{Size: maxSize + 1}
 		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
@@ -86,37 +237,59 @@ func TestAddFileApplicationService_Do(t *testing.T) {
 
 		assert.ErrorIs(t, err, model.ErrFileTooLarge)
 		assert.Nil(t, res)
-		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("Storage Upload Fails", func(t *testing.T) {
+	t.Run("Suspended User", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
-		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, maxSize)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
 
 		fileHeader := &multipart.FileHeader{Size: 512}
 		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+		userCopy.RestoreStatus(model.UserSuspended, "abuse")
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+		assert.Nil(t, res)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Storage Upload Fails", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
+		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
 		uploadErr := errors.New("s3 upload failed")
 
 		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
 		userCopy.ID = userID
 
 		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
-		mockFileRepo.On("Upload", userID, fileHeader).Return("", uploadErr).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("", "", uploadErr).Once()
 
 		res, err := service.Do(req)
 
 		assert.ErrorIs(t, err, uploadErr)
 		assert.Nil(t, res)
-		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "AddFile", mock.Anything, mock.Anything)
 	})
 
-	t.Run("User Update Fails", func(t *testing.T) {
+	t.Run("Add File Fails", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
-		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, maxSize)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
 
-		fileHeader := &multipart.FileHeader{Size: 512}
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
 		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
 		updateErr := errors.New("db update failed")
 
@@ -124,8 +297,10 @@ func TestAddFileApplicationService_Do(t *testing.T) {
 		userCopy.ID = userID
 
 		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
-		mockFileRepo.On("Upload", userID, fileHeader).Return("/path", nil).Once()
-		mockUserRepo.On("Update", userID, mock.Anything).Return(updateErr).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/path", pngDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.AnythingOfType("*model.File"), quota).Return(updateErr).Once()
+		mockFileRepo.On("Delete", userID, mock.AnythingOfType("string")).Return(nil).Once()
 
 		res, err := service.Do(req)
 
@@ -134,4 +309,222 @@ func TestAddFileApplicationService_Do(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 		mockFileRepo.AssertExpectations(t)
 	})
+
+	t.Run("Storage Quota Exceeded", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		req := &v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "test.png", pngContent)}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+		usage := &model.StorageUsage{UserID: userID, Bytes: quota.Bytes - 4, Files: 3}
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(usage, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrStorageQuotaExceeded)
+		assert.Nil(t, res)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Declared Type Mismatch", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		req := &v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "test.png", []byte("plain text"))}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFileTypeMismatch)
+		assert.Nil(t, res)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Type Not Allowed", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		// the name hides the type, the content is detected anyway
+		req := &v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "notes", []byte("MZ\x90\x00\x03\x00\x00\x00"))}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFileTypeNotAllowed)
+		assert.Nil(t, res)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Type Size Limit", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		imagePolicy, _ := model.NewUploadPolicy(nil, nil, maxSize, map[string]int64{"image/*": 8})
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, imagePolicy, quota, noFileScanner, noFileVersions)
+
+		req := &v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "test.png", pngContent)}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFileTooLarge)
+		assert.Nil(t, res)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Duplicate Content", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		fileHeader := newTestFileHeader(t, "copy.png", pngContent)
+		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+		userCopy.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "test.png", Digest: pngDigest})
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/copy.png", pngDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.AnythingOfType("*model.File"), quota).Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"file-123"}, res.Duplicates)
+	})
+
+	t.Run("Replace Existing", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner,
+			NewFileVersions(mockUserRepo, mockFileRepo, 10))
+
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
+		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+		userCopy.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "test.png", Size: 5, Digest: helloDigest, Version: 1})
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/new", pngDigest, nil).Once()
+		mockUserRepo.On("ReplaceFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.ID == "file-123" && f.Version == 2 && f.Digest == pngDigest && f.StorageName() != "test.png"
+		}), mock.MatchedBy(func(v *model.FileVersion) bool {
+			return v.FileID == "file-123" && v.Version == 1 && v.Size == 5 && v.Digest == helloDigest &&
+				v.StorageName() == "test.png"
+		}), (*model.FileVersion)(nil), quota).Return(nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-123").Return([]*model.FileVersion{{ID: "version-1"}}, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "file-123", res.File.ID)
+		assert.Equal(t, 2, res.File.Version)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		// the quota is checked with the version when the file is replaced
+		mockUserRepo.AssertNotCalled(t, "GetStorageUsage", mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "AddFile", mock.Anything, mock.Anything)
+		// the new content is stored under a new key, the previous one is kept as the version
+		mockFileRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Replace Fails", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner,
+			NewFileVersions(mockUserRepo, mockFileRepo, 10))
+
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
+		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+		userCopy.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "test.png", Size: 5, Version: 1})
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/new", pngDigest, nil).Once()
+		mockUserRepo.On("ReplaceFile", mock.Anything, mock.Anything, (*model.FileVersion)(nil), quota).
+			Return(model.ErrStorageQuotaExceeded).Once()
+		// only the new content is deleted, the previous one was never touched
+		mockFileRepo.On("Delete", userID, mock.MatchedBy(func(name string) bool { return name != "test.png" })).
+			Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrStorageQuotaExceeded)
+		assert.Nil(t, res)
+		mockFileRepo.AssertExpectations(t)
+		file, _ := userCopy.GetFile("file-123")
+		assert.Equal(t, 1, file.Version)
+	})
+
+	t.Run("Digest Mismatch", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		fileHeader := newTestFileHeader(t, "test.png", pngContent)
+		req := &v1.UploadFileRequest{UserID: userID, File: fileHeader, Digest: strings.Repeat("0", 64)}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/test.png", pngDigest, nil).Once()
+		mockFileRepo.On("Delete", userID, mock.AnythingOfType("string")).Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrDigestMismatch)
+		assert.Nil(t, res)
+		mockFileRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "AddFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid Digest", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions)
+
+		req := &v1.UploadFileRequest{UserID: userID, File: newTestFileHeader(t, "test.png", pngContent), Digest: "md5=abc"}
+
+		userCopy, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		userCopy.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrInvalidDigest)
+		assert.Nil(t, res)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
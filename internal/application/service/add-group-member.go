@@ -0,0 +1,58 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/event"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewAddGroupMemberApplicationService(groups domain.GroupRepository, users domain.UserRepository, publisher domain.EventPublisher) *AddGroupMemberApplicationService {
+	return &AddGroupMemberApplicationService{groups, users, publisher}
+}
+
+type AddGroupMemberApplicationService struct {
+	groups    domain.GroupRepository
+	users     domain.UserRepository
+	publisher domain.EventPublisher
+}
+
+func (s *AddGroupMemberApplicationService) Do(req *v1.GroupMemberRequest) (*v1.GroupMemberResponse, error) {
+	_, err := s.groups.Get(req.GroupID)
+	if err != nil {
+		return &v1.GroupMemberResponse{}, err
+	}
+
+	_, err = s.users.Get(req.UserID)
+	if err != nil {
+		return &v1.GroupMemberResponse{}, err
+	}
+
+	err = s.groups.AddMember(req.GroupID, req.UserID)
+	if err != nil {
+		return &v1.GroupMemberResponse{}, err
+	}
+
+	// reload the user to pick up the roles of the group
+	user, err := s.users.Get(req.UserID)
+	if err != nil {
+		return &v1.GroupMemberResponse{}, err
+	}
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return &v1.GroupMemberResponse{User: user.ToDTO()}, nil
+}
+
+// publishUsersUpdated notifies downstream services of users whose roles may have changed
+func publishUsersUpdated(publisher domain.EventPublisher, users []*model.User) {
+	go func() {
+		for _, user := range users {
+			err := publisher.Publish(event.NewUserUpdatedEvent(user))
+			if err != nil {
+				log.Printf("Failed to publish user updated event: %v", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddGroupMemberApplicationService_Do(t *testing.T) {
+	req := &v1.GroupMemberRequest{GroupID: "group-123", UserID: "user-123"}
+
+	group, _ := model.NewGroup("Admins", "", []string{"admin"})
+	group.ID = req.GroupID
+
+	t.Run("Success", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewAddGroupMemberApplicationService(mockGroupRepo, mockUserRepo, mockEventPublisher)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = req.UserID
+		member, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		member.ID = req.UserID
+		member.SetRoles([]string{"admin"})
+
+		mockGroupRepo.On("Get", req.GroupID).Return(group, nil).Once()
+		mockUserRepo.On("Get", req.UserID).Return(user, nil).Once()
+		mockGroupRepo.On("AddMember", req.GroupID, req.UserID).Return(nil).Once()
+		mockUserRepo.On("Get", req.UserID).Return(member, nil).Once()
+		mockEventPublisher.On("Publish", mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == domain.UserUpdatedEvent && e.User.ToDTO().Roles[0] == "admin"
+		})).Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"admin"}, res.User.Roles)
+		mockGroupRepo.AssertExpectations(t)
+		assert.Eventually(t, func() bool {
+			return len(mockEventPublisher.Calls) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Unknown User", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewAddGroupMemberApplicationService(mockGroupRepo, mockUserRepo, nil)
+
+		mockGroupRepo.On("Get", req.GroupID).Return(group, nil).Once()
+		mockUserRepo.On("Get", req.UserID).Return(nil, domain.ErrUserNotFound).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Equal(t, &v1.GroupMemberResponse{}, res)
+		mockGroupRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+}
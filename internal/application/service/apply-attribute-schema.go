@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+)
+
+// maxReportedViolations caps the users listed when a schema is rejected
+const maxReportedViolations = 10
+
+func NewApplyAttributeSchemaApplicationService(
+	repository domain.UserRepository,
+	schemas domain.AttributeSchemaRepository,
+	schema domain.AttributeSchema,
+) *ApplyAttributeSchemaApplicationService {
+	return &ApplyAttributeSchemaApplicationService{repository, schemas, schema}
+}
+
+// ApplyAttributeSchemaApplicationService accepts a new attribute schema only if all the existing users match it
+type ApplyAttributeSchemaApplicationService struct {
+	repository domain.UserRepository
+	schemas    domain.AttributeSchemaRepository
+	schema     domain.AttributeSchema
+}
+
+func (s *ApplyAttributeSchemaApplicationService) Do() error {
+	accepted, err := s.schemas.GetAcceptedDigest()
+	if err != nil {
+		return err
+	}
+	if accepted == s.schema.Digest() {
+		return nil
+	}
+
+	users, err := s.repository.List(&domain.UserFilter{})
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	for _, user := range users {
+		if err := s.schema.Validate(user.GetAttributes()); err != nil {
+			violations = append(violations, fmt.Sprintf("user %s: %v", user.ID, err))
+		}
+	}
+	if len(violations) > 0 {
+		reported := violations[:min(len(violations), maxReportedViolations)]
+		return fmt.Errorf("%w: %d of %d users are invalid\n%s",
+			domain.ErrAttributeSchemaIncompatible, len(violations), len(users), strings.Join(reported, "\n"))
+	}
+
+	log.Printf("Accepting attribute schema %s, %d users validated", s.schema.Digest(), len(users))
+	return s.schemas.Accept(s.schema.Digest(), time.Now())
+}
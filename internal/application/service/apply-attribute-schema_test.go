@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApplyAttributeSchemaApplicationService_Do(t *testing.T) {
+	newUser := func(id string, attributes map[string]any) *model.User {
+		user, _ := model.NewUser("Test User", id+"@example.com", "1990-01-01")
+		user.ID = id
+		_ = user.SetAttributes(attributes)
+		return user
+	}
+
+	t.Run("Already Accepted", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSchemaRepo := new(mocks.AttributeSchemaRepository)
+		mockSchema := new(mocks.AttributeSchema)
+		service := NewApplyAttributeSchemaApplicationService(mockUserRepo, mockSchemaRepo, mockSchema)
+
+		mockSchemaRepo.On("GetAcceptedDigest").Return("digest-1", nil).Once()
+		mockSchema.On("Digest").Return("digest-1")
+
+		err := service.Do()
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("Compatible Schema", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSchemaRepo := new(mocks.AttributeSchemaRepository)
+		mockSchema := new(mocks.AttributeSchema)
+		service := NewApplyAttributeSchemaApplicationService(mockUserRepo, mockSchemaRepo, mockSchema)
+
+		user := newUser("user-1", map[string]any{"department": "sales"})
+		mockSchemaRepo.On("GetAcceptedDigest").Return("digest-1", nil).Once()
+		mockSchema.On("Digest").Return("digest-2")
+		mockUserRepo.On("List", &domain.UserFilter{}).Return([]*model.User{user}, nil).Once()
+		mockSchema.On("Validate", user.GetAttributes()).Return(nil).Once()
+		mockSchemaRepo.On("Accept", "digest-2", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		err := service.Do()
+
+		assert.NoError(t, err)
+		mockSchemaRepo.AssertExpectations(t)
+	})
+
+	t.Run("Incompatible Schema", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSchemaRepo := new(mocks.AttributeSchemaRepository)
+		mockSchema := new(mocks.AttributeSchema)
+		service := NewApplyAttributeSchemaApplicationService(mockUserRepo, mockSchemaRepo, mockSchema)
+
+		valid := newUser("user-1", map[string]any{"department": "sales"})
+		invalid := newUser("user-2", map[string]any{})
+		mockSchemaRepo.On("GetAcceptedDigest").Return("", nil).Once()
+		mockSchema.On("Digest").Return("digest-2")
+		mockUserRepo.On("List", &domain.UserFilter{}).Return([]*model.User{valid, invalid}, nil).Once()
+		mockSchema.On("Validate", valid.GetAttributes()).Return(nil).Once()
+		mockSchema.On("Validate", invalid.GetAttributes()).Return(domain.ErrInvalidAttributes).Once()
+
+		err := service.Do()
+
+		assert.ErrorIs(t, err, domain.ErrAttributeSchemaIncompatible)
+		assert.Contains(t, err.Error(), "user user-2")
+		assert.NotContains(t, err.Error(), "user user-1")
+		mockSchemaRepo.AssertNotCalled(t, "Accept", mock.Anything, mock.Anything)
+	})
+}
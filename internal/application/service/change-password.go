@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewChangePasswordApplicationService(credentials domain.CredentialRepository, hasher domain.PasswordHasher) *ChangePasswordApplicationService {
+	return &ChangePasswordApplicationService{credentials, hasher}
+}
+
+// ChangePasswordApplicationService replaces a password after checking the current one
+type ChangePasswordApplicationService struct {
+	credentials domain.CredentialRepository
+	hasher      domain.PasswordHasher
+}
+
+func (s *ChangePasswordApplicationService) Do(req *v1.ChangePasswordRequest) error {
+	credential, err := s.credentials.Get(req.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCredentialNotFound) {
+			return model.ErrPasswordNotSet
+		}
+		return err
+	}
+
+	ok, err := s.hasher.Verify(req.CurrentPassword, credential.Hash)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.ErrCurrentPasswordInvalid
+	}
+
+	return setPassword(s.credentials, s.hasher, req.UserID, req.NewPassword)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChangePasswordApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	req := &v1.ChangePasswordRequest{UserID: userID, CurrentPassword: "old password 123", NewPassword: "new password 456"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockHasher := new(mocks.PasswordHasher)
+		service := NewChangePasswordApplicationService(mockCredentialRepo, mockHasher)
+
+		mockCredentialRepo.On("Get", userID).Return(model.NewCredential(userID, "old-hash"), nil).Twice()
+		mockHasher.On("Verify", req.CurrentPassword, "old-hash").Return(true, nil).Once()
+		mockHasher.On("Hash", req.NewPassword).Return("new-hash", nil).Once()
+		mockCredentialRepo.On("Save", model.NewCredential(userID, "new-hash")).Return(nil).Once()
+
+		err := service.Do(req)
+
+		assert.NoError(t, err)
+		mockCredentialRepo.AssertExpectations(t)
+	})
+
+	t.Run("Wrong Current Password", func(t *testing.T) {
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockHasher := new(mocks.PasswordHasher)
+		service := NewChangePasswordApplicationService(mockCredentialRepo, mockHasher)
+
+		mockCredentialRepo.On("Get", userID).Return(model.NewCredential(userID, "old-hash"), nil).Once()
+		mockHasher.On("Verify", req.CurrentPassword, "old-hash").Return(false, nil).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrCurrentPasswordInvalid)
+		mockCredentialRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("No Password Set", func(t *testing.T) {
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		service := NewChangePasswordApplicationService(mockCredentialRepo, nil)
+
+		mockCredentialRepo.On("Get", userID).Return(nil, domain.ErrCredentialNotFound).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrPasswordNotSet)
+	})
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/google/uuid"
+)
+
+func NewCreateFolderApplicationService(repository domain.UserRepository) *CreateFolderApplicationService {
+	return &CreateFolderApplicationService{repository}
+}
+
+type CreateFolderApplicationService struct {
+	repository domain.UserRepository
+}
+
+func (s *CreateFolderApplicationService) Do(req *v1.CreateFolderRequest) (*v1.CreateFolderResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.CreateFolderResponse{}, err
+	}
+	if !user.CanModifyFiles() {
+		return &v1.CreateFolderResponse{}, model.ErrFilesReadOnly
+	}
+
+	folder, err := model.NewFolder(uuid.NewString(), user.ID, req.ParentID, req.Name, time.Now())
+	if err != nil {
+		return &v1.CreateFolderResponse{}, err
+	}
+
+	folders, err := s.repository.GetFolders(user.ID)
+	if err != nil {
+		return &v1.CreateFolderResponse{}, err
+	}
+	tree := model.NewFolderTree(folders)
+	if err := tree.Add(folder); err != nil {
+		return &v1.CreateFolderResponse{}, err
+	}
+
+	if err := s.repository.CreateFolder(folder); err != nil {
+		return &v1.CreateFolderResponse{}, err
+	}
+
+	return &v1.CreateFolderResponse{Folder: tree.ToDTO(folder)}, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestFolders are /documents, /documents/2024 and /photos
+func newTestFolders() []*model.Folder {
+	return []*model.Folder{
+		{ID: "documents", UserID: "user-123", Name: "documents"},
+		{ID: "2024", UserID: "user-123", ParentID: "documents", Name: "2024"},
+		{ID: "photos", UserID: "user-123", Name: "photos"},
+	}
+}
+
+func TestCreateFolderApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewCreateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockRepo.On("CreateFolder", mock.MatchedBy(func(f *model.Folder) bool {
+			return f.ID != "" && f.UserID == userID && f.ParentID == "2024" && f.Name == "taxes"
+		})).Return(nil).Once()
+
+		res, err := service.Do(&v1.CreateFolderRequest{UserID: userID, ParentID: "2024", Name: " taxes "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/documents/2024/taxes", res.Folder.Path)
+		assert.Equal(t, "2024", res.Folder.ParentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Name Taken", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewCreateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		_, err := service.Do(&v1.CreateFolderRequest{UserID: userID, Name: "Photos"})
+
+		assert.ErrorIs(t, err, model.ErrFolderNameTaken)
+		mockRepo.AssertNotCalled(t, "CreateFolder", mock.Anything)
+	})
+
+	t.Run("Parent Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewCreateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		_, err := service.Do(&v1.CreateFolderRequest{UserID: userID, ParentID: "unknown", Name: "taxes"})
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+		mockRepo.AssertNotCalled(t, "CreateFolder", mock.Anything)
+	})
+
+	t.Run("Invalid Name", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewCreateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.CreateFolderRequest{UserID: userID, Name: "a/b"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidFolderName)
+		mockRepo.AssertNotCalled(t, "GetFolders", mock.Anything)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewCreateFolderApplicationService(mockRepo)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, err := service.Do(&v1.CreateFolderRequest{UserID: userID, Name: "taxes"})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewCreateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		res, err := service.Do(&v1.CreateFolderRequest{UserID: userID, Name: "taxes"})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Equal(t, &v1.CreateFolderResponse{}, res)
+	})
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewCreateGroupApplicationService(groups domain.GroupRepository, roles domain.RoleRepository) *CreateGroupApplicationService {
+	return &CreateGroupApplicationService{groups, roles}
+}
+
+type CreateGroupApplicationService struct {
+	groups domain.GroupRepository
+	roles  domain.RoleRepository
+}
+
+func (s *CreateGroupApplicationService) Do(req *v1.CreateGroupRequest) (*v1.CreateGroupResponse, error) {
+	group, err := model.NewGroup(req.Name, req.Description, req.Roles)
+	if err != nil {
+		return &v1.CreateGroupResponse{}, err
+	}
+
+	err = checkRolesExist(s.roles, group.GetRoles())
+	if err != nil {
+		return &v1.CreateGroupResponse{}, err
+	}
+
+	_, err = s.groups.Create(group)
+	if err != nil {
+		return &v1.CreateGroupResponse{}, err
+	}
+
+	return &v1.CreateGroupResponse{Group: group.ToDTO()}, nil
+}
+
+// checkRolesExist makes sure that only defined roles are assigned to groups
+func checkRolesExist(roles domain.RoleRepository, names []string) error {
+	for _, name := range names {
+		if _, err := roles.Get(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
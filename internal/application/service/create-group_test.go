@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateGroupApplicationService_Do(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewCreateGroupApplicationService(mockGroupRepo, mockRoleRepo)
+
+		mockRoleRepo.On("Get", "admin").Return(&model.Role{Name: "admin"}, nil).Once()
+		mockRoleRepo.On("Get", "editor").Return(&model.Role{Name: "editor"}, nil).Once()
+		mockGroupRepo.On("Create", mock.AnythingOfType("*model.Group")).Return("group-123", nil).Once()
+
+		res, err := service.Do(&v1.CreateGroupRequest{Name: "Staff", Roles: []string{"editor", "admin", "editor"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Staff", res.Group.Name)
+		assert.Equal(t, []string{"admin", "editor"}, res.Group.Roles)
+		mockGroupRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Role", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewCreateGroupApplicationService(mockGroupRepo, mockRoleRepo)
+
+		mockRoleRepo.On("Get", "admin").Return(nil, domain.ErrRoleNotFound).Once()
+
+		res, err := service.Do(&v1.CreateGroupRequest{Name: "Staff", Roles: []string{"admin"}})
+
+		assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+		assert.Equal(t, &v1.CreateGroupResponse{}, res)
+		mockGroupRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Invalid Name", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		service := NewCreateGroupApplicationService(mockGroupRepo, nil)
+
+		_, err := service.Do(&v1.CreateGroupRequest{Name: "   "})
+
+		assert.ErrorIs(t, err, model.ErrInvalidGroupName)
+		mockGroupRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
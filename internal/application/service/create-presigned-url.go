@@ -0,0 +1,101 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+// the public endpoints the pre-signed URLs point to
+const (
+	presignedDownloadPath = "/v1/presigned/download"
+	presignedUploadPath   = "/v1/presigned/upload"
+)
+
+func NewCreatePresignedURLApplicationService(
+	repository domain.UserRepository,
+	signer domain.URLSigner,
+	baseURL string,
+	ttl time.Duration,
+	maxTTL time.Duration,
+) *CreatePresignedURLApplicationService {
+	return &CreatePresignedURLApplicationService{repository, signer, strings.TrimSuffix(baseURL, "/"), ttl, maxTTL}
+}
+
+// CreatePresignedURLApplicationService mints the URLs served by PresignedDownloadApplicationService and
+// PresignedUploadApplicationService. They are relative if there is no base URL.
+type CreatePresignedURLApplicationService struct {
+	repository domain.UserRepository
+	signer     domain.URLSigner
+	baseURL    string
+	ttl        time.Duration
+	maxTTL     time.Duration
+}
+
+func (s *CreatePresignedURLApplicationService) Do(req *v1.CreatePresignedURLRequest) (*v1.CreatePresignedURLResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.CreatePresignedURLResponse{}, err
+	}
+
+	method, path := http.MethodGet, presignedDownloadPath
+	switch req.Action {
+	case model.PresignedDownload:
+		if _, err := user.GetFile(req.FileID); err != nil {
+			return &v1.CreatePresignedURLResponse{}, err
+		}
+	case model.PresignedUpload:
+		if !user.CanModifyFiles() {
+			return &v1.CreatePresignedURLResponse{}, model.ErrFilesReadOnly
+		}
+		method, path = http.MethodPost, presignedUploadPath
+	}
+
+	ttl := s.ttl
+	if req.ExpiresIn != 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	presigned, err := model.NewPresignedURL(req.Action, user.ID, req.FileID, req.IP, ttl, s.maxTTL, time.Now())
+	if err != nil {
+		return &v1.CreatePresignedURLResponse{}, err
+	}
+
+	query := url.Values{}
+	query.Set("user", presigned.UserID)
+	if presigned.FileID != "" {
+		query.Set("fileID", presigned.FileID)
+	}
+	query.Set("expires", strconv.FormatInt(presigned.ExpiresAt.Unix(), 10))
+	if presigned.IP != "" {
+		query.Set("ip", presigned.IP)
+	}
+	query.Set("signature", s.signer.Sign(presigned.Payload()))
+
+	return &v1.CreatePresignedURLResponse{
+		URL:       s.baseURL + path + "?" + query.Encode(),
+		Method:    method,
+		ExpiresAt: presigned.ExpiresAt,
+	}, nil
+}
+
+// verifyPresignedURL tells whether the query is a pre-signed URL for the action that the client can still use.
+// The signature is checked first, so that nothing is disclosed about forged URLs.
+func verifyPresignedURL(signer domain.URLSigner, action string, query *v1.PresignedURLQuery, now time.Time) error {
+	presigned := &model.PresignedURL{
+		Action:    action,
+		UserID:    query.UserID,
+		FileID:    query.FileID,
+		ExpiresAt: time.Unix(query.Expires, 0),
+		IP:        query.IP,
+	}
+	if !signer.Verify(presigned.Payload(), query.Signature) {
+		return model.ErrInvalidSignature
+	}
+	return presigned.Check(now, query.ClientIP)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePresignedURLApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt"})
+		return user
+	}
+
+	t.Run("Download", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewCreatePresignedURLApplicationService(mockUserRepo, mockSigner, "https://api.example.com/", 15*time.Minute, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockSigner.On("Sign", mock.MatchedBy(func(payload string) bool {
+			return strings.HasPrefix(payload, "download\nuser-123\nfile-123\n")
+		})).Return("c2lnbmF0dXJl").Once()
+
+		res, err := service.Do(&v1.CreatePresignedURLRequest{UserID: userID, Action: "download", FileID: "file-123", IP: "203.0.113.7"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "GET", res.Method)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), res.ExpiresAt, 2*time.Second)
+		u, err := url.Parse(res.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://api.example.com/v1/presigned/download", u.Scheme+"://"+u.Host+u.Path)
+		assert.Equal(t, userID, u.Query().Get("user"))
+		assert.Equal(t, "file-123", u.Query().Get("fileID"))
+		assert.Equal(t, "203.0.113.7", u.Query().Get("ip"))
+		assert.Equal(t, "c2lnbmF0dXJl", u.Query().Get("signature"))
+		mockSigner.AssertExpectations(t)
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewCreatePresignedURLApplicationService(mockUserRepo, mockSigner, "", 15*time.Minute, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockSigner.On("Sign", mock.Anything).Return("c2lnbmF0dXJl").Once()
+
+		res, err := service.Do(&v1.CreatePresignedURLRequest{UserID: userID, Action: "upload", ExpiresIn: 60})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "POST", res.Method)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), res.ExpiresAt, 2*time.Second)
+		u, err := url.Parse(res.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "/v1/presigned/upload", u.Path)
+		assert.False(t, u.Query().Has("fileID"))
+		assert.False(t, u.Query().Has("ip"))
+	})
+
+	t.Run("File Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewCreatePresignedURLApplicationService(mockUserRepo, mockSigner, "", 15*time.Minute, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.CreatePresignedURLRequest{UserID: userID, Action: "download", FileID: "missing"})
+
+		assert.ErrorIs(t, err, model.ErrFileNotFound)
+		mockSigner.AssertNotCalled(t, "Sign", mock.Anything)
+	})
+
+	t.Run("Files Read Only", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewCreatePresignedURLApplicationService(mockUserRepo, mockSigner, "", 15*time.Minute, time.Hour)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, err := service.Do(&v1.CreatePresignedURLRequest{UserID: userID, Action: "upload"})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+		mockSigner.AssertNotCalled(t, "Sign", mock.Anything)
+	})
+
+	t.Run("Expiry Too Long", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewCreatePresignedURLApplicationService(mockUserRepo, mockSigner, "", 15*time.Minute, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.CreatePresignedURLRequest{UserID: userID, Action: "download", FileID: "file-123", ExpiresIn: 7200})
+
+		assert.ErrorIs(t, err, model.ErrInvalidPresignedExpiry)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewCreatePresignedURLApplicationService(mockUserRepo, mockSigner, "", 15*time.Minute, time.Hour)
+
+		mockUserRepo.On("Get", "not-found").Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(&v1.CreatePresignedURLRequest{UserID: "not-found", Action: "upload"})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
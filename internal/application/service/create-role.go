@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewCreateRoleApplicationService(roles domain.RoleRepository) *CreateRoleApplicationService {
+	return &CreateRoleApplicationService{roles}
+}
+
+type CreateRoleApplicationService struct {
+	roles domain.RoleRepository
+}
+
+func (s *CreateRoleApplicationService) Do(req *v1.CreateRoleRequest) (*v1.CreateRoleResponse, error) {
+	role, err := model.NewRole(req.Name, req.Description)
+	if err != nil {
+		return &v1.CreateRoleResponse{}, err
+	}
+
+	err = s.roles.Create(role)
+	if err != nil {
+		return &v1.CreateRoleResponse{}, err
+	}
+
+	return &v1.CreateRoleResponse{Role: role.ToDTO()}, nil
+}
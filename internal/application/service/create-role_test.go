@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRoleApplicationService_Do(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewCreateRoleApplicationService(mockRoleRepo)
+
+		mockRoleRepo.On("Create", &model.Role{Name: "billing:admin", Description: "Manage invoices"}).Return(nil).Once()
+
+		res, err := service.Do(&v1.CreateRoleRequest{Name: "billing:admin", Description: "Manage invoices"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &v1.Role{Name: "billing:admin", Description: "Manage invoices"}, res.Role)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Name", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewCreateRoleApplicationService(mockRoleRepo)
+
+		res, err := service.Do(&v1.CreateRoleRequest{Name: "Billing Admin"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidRoleName)
+		assert.Equal(t, &v1.CreateRoleResponse{}, res)
+		mockRoleRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Already Exists", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewCreateRoleApplicationService(mockRoleRepo)
+
+		mockRoleRepo.On("Create", mock.Anything).Return(domain.ErrRoleAlreadyExists).Once()
+
+		_, err := service.Do(&v1.CreateRoleRequest{Name: "admin"})
+
+		assert.ErrorIs(t, err, domain.ErrRoleAlreadyExists)
+	})
+}
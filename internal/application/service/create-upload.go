@@ -0,0 +1,75 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewCreateUploadApplicationService(
+	repository domain.UserRepository,
+	uploads domain.UploadRepository,
+	partials domain.PartialUploadRepository,
+	policy *model.UploadPolicy,
+	quota *model.StorageQuota,
+	ttl time.Duration,
+) *CreateUploadApplicationService {
+	return &CreateUploadApplicationService{repository, uploads, partials, policy, quota, ttl}
+}
+
+// CreateUploadApplicationService starts a resumable upload, its chunks are sent with PatchUploadApplicationService
+type CreateUploadApplicationService struct {
+	repository domain.UserRepository
+	uploads    domain.UploadRepository
+	partials   domain.PartialUploadRepository
+	policy     *model.UploadPolicy
+	quota      *model.StorageQuota
+	ttl        time.Duration
+}
+
+func (s *CreateUploadApplicationService) Do(req *v1.CreateUploadRequest) (*v1.CreateUploadResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.CreateUploadResponse{}, err
+	}
+
+	if !user.CanModifyFiles() {
+		return &v1.CreateUploadResponse{}, model.ErrFilesReadOnly
+	}
+
+	// the type is only known once the content is complete, the largest limit applies until then
+	if req.Length > s.policy.LargestMaxSize() {
+		return &v1.CreateUploadResponse{}, model.ErrFileTooLarge
+	}
+	// the quota is checked again once the upload is complete, other files may be added in the meantime
+	if err := checkStorageQuota(s.repository, user.ID, req.Length, s.quota); err != nil {
+		return &v1.CreateUploadResponse{}, err
+	}
+
+	folderID := req.Metadata["folderID"]
+	if err := checkFolder(s.repository, user.ID, folderID); err != nil {
+		return &v1.CreateUploadResponse{}, err
+	}
+
+	filename := req.Metadata["filename"]
+	upload, err := model.NewUpload(user.ID, filename, folderID,
+		declaredContentType(req.Metadata["filetype"], filename), req.Metadata["digest"], req.Length, time.Now().Add(s.ttl))
+	if err != nil {
+		return &v1.CreateUploadResponse{}, err
+	}
+
+	if _, err := s.uploads.Create(upload); err != nil {
+		return &v1.CreateUploadResponse{}, err
+	}
+	if err := s.partials.Create(upload.ID); err != nil {
+		if err := s.uploads.Delete(upload.ID); err != nil {
+			log.Printf("error deleting upload %s: %s", upload.ID, err)
+		}
+		return &v1.CreateUploadResponse{}, err
+	}
+
+	return &v1.CreateUploadResponse{Upload: upload.ToDTO()}, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateUploadApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	policy, _ := model.NewUploadPolicy(nil, nil, 1024, map[string]int64{"application/pdf": 4096})
+	quota := &model.StorageQuota{Bytes: 8192, Files: 10}
+	usage := &model.StorageUsage{UserID: userID}
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(usage, nil).Once()
+		mockUploadRepo.On("Create", mock.MatchedBy(func(u *model.Upload) bool {
+			return u.Filename == "report.pdf" && u.DeclaredType == "application/pdf" && u.Length == 4096
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*model.Upload).ID = "upload-123"
+		}).Return("upload-123", nil).Once()
+		mockPartialRepo.On("Create", "upload-123").Return(nil).Once()
+
+		res, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 4096, Metadata: map[string]string{"filename": "report.pdf"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "upload-123", res.Upload.ID)
+		assert.Equal(t, int64(0), res.Upload.Offset)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), res.Upload.ExpiresAt, time.Minute)
+		mockUploadRepo.AssertExpectations(t)
+		mockPartialRepo.AssertExpectations(t)
+	})
+
+	t.Run("In Folder", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(usage, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockUploadRepo.On("Create", mock.MatchedBy(func(u *model.Upload) bool { return u.FolderID == "2024" })).
+			Return("upload-123", nil).Once()
+		mockPartialRepo.On("Create", mock.Anything).Return(nil).Once()
+
+		res, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 10,
+			Metadata: map[string]string{"filename": "a.txt", "folderID": "2024"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024", res.Upload.FolderID)
+		mockUploadRepo.AssertExpectations(t)
+	})
+
+	t.Run("Folder Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(usage, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		_, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 10,
+			Metadata: map[string]string{"filename": "a.txt", "folderID": "unknown"}})
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+		mockUploadRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Too Large", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 4097, Metadata: map[string]string{"filename": "report.pdf"}})
+
+		assert.ErrorIs(t, err, model.ErrFileTooLarge)
+		mockUploadRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Storage Quota Exceeded", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID, Files: 10}, nil).Once()
+
+		_, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 10, Metadata: map[string]string{"filename": "a.txt"}})
+
+		assert.ErrorIs(t, err, model.ErrStorageQuotaExceeded)
+		mockUploadRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Missing Filename", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(usage, nil).Once()
+
+		_, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 10, Metadata: map[string]string{}})
+
+		assert.ErrorIs(t, err, model.ErrInvalidFilename)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 10, Metadata: map[string]string{"filename": "a.txt"}})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+	})
+
+	t.Run("Partial Storage Fails", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+		storageErr := errors.New("disk full")
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(usage, nil).Once()
+		mockUploadRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(0).(*model.Upload).ID = "upload-123"
+		}).Return("upload-123", nil).Once()
+		mockPartialRepo.On("Create", "upload-123").Return(storageErr).Once()
+		mockUploadRepo.On("Delete", "upload-123").Return(nil).Once()
+
+		_, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 10, Metadata: map[string]string{"filename": "a.txt"}})
+
+		assert.ErrorIs(t, err, storageErr)
+		mockUploadRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewCreateUploadApplicationService(mockUserRepo, mockUploadRepo, mockPartialRepo, policy, quota, time.Hour)
+
+		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(&v1.CreateUploadRequest{UserID: userID, Length: 10})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
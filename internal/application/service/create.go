@@ -9,13 +9,20 @@ import (
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
 )
 
-func NewCreateUserApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *CreateUserApplicationService {
-	return &CreateUserApplicationService{repository, publisher}
+func NewCreateUserApplicationService(
+	repository domain.UserRepository,
+	publisher domain.EventPublisher,
+	verifier *EmailVerifier,
+	schema domain.AttributeSchema,
+) *CreateUserApplicationService {
+	return &CreateUserApplicationService{repository, publisher, verifier, schema}
 }
 
 type CreateUserApplicationService struct {
 	repository domain.UserRepository
 	publisher  domain.EventPublisher
+	verifier   *EmailVerifier
+	schema     domain.AttributeSchema
 }
 
 func (s *CreateUserApplicationService) Do(req *v1.CreateUserRequest) (*v1.CreateUserResponse, error) {
@@ -25,6 +32,17 @@ func (s *CreateUserApplicationService) Do(req *v1.CreateUserRequest) (*v1.Create
 		return &v1.CreateUserResponse{}, err
 	}
 
+	err = user.SetAttributes(req.Attributes)
+	if err != nil {
+		return &v1.CreateUserResponse{}, err
+	}
+
+	// the schema may require attributes, so they are validated even if none were given
+	err = s.schema.Validate(user.GetAttributes())
+	if err != nil {
+		return &v1.CreateUserResponse{}, err
+	}
+
 	// check if user with same email already exists
 	existingUser, err := s.repository.GetByEmail(req.Email)
 	if existingUser != nil && err == nil {
@@ -36,6 +54,12 @@ func (s *CreateUserApplicationService) Do(req *v1.CreateUserRequest) (*v1.Create
 		return &v1.CreateUserResponse{}, err
 	}
 
+	// the user can ask for a new verification email, so this doesn't fail the creation
+	err = s.verifier.Send(user)
+	if err != nil {
+		log.Printf("Failed to send verification email: %v", err)
+	}
+
 	go func() {
 		err = s.publisher.Publish(event.NewUserCreatedEvent(user))
 		if err != nil {
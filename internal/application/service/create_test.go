@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/bizio/abc-user-service/internal/domain"
@@ -16,7 +17,8 @@ func TestCreateUserApplicationService_Do(t *testing.T) {
 	t.Run("DOB Validation Error", func(t *testing.T) {
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.CreateUserRequest{
 			Name:  "Jane Doe",
@@ -33,7 +35,8 @@ func TestCreateUserApplicationService_Do(t *testing.T) {
 	t.Run("Email Validation Error", func(t *testing.T) {
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.CreateUserRequest{
 			Name:  "Jane Doe",
@@ -50,7 +53,8 @@ func TestCreateUserApplicationService_Do(t *testing.T) {
 	t.Run("User Already Exists Error", func(t *testing.T) {
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.CreateUserRequest{
 			Name:  "Jane Doe",
@@ -72,7 +76,8 @@ func TestCreateUserApplicationService_Do(t *testing.T) {
 	t.Run("Repository Error", func(t *testing.T) {
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.CreateUserRequest{
 			Name:  "John Doe",
@@ -95,7 +100,8 @@ func TestCreateUserApplicationService_Do(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, mockTokenRepo, mockMailer := newTestEmailVerifier()
+		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.CreateUserRequest{
 			Name:  "John Doe",
@@ -110,12 +116,64 @@ func TestCreateUserApplicationService_Do(t *testing.T) {
 		mockRepo.On("Create", user).Return(expectedUserID, nil).Once()
 		mockRepo.On("GetByEmail", req.Email).Return(nil, domain.ErrUserNotFound).Once()
 		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once()
+		mockTokenRepo.On("DeleteByUser", mock.Anything).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.Anything).Return(nil).Once()
+		mockMailer.On("Send", req.Email, mock.Anything, mock.Anything).Return(nil).Once()
 		res, err := service.Do(req)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, expectedUserID, res.ID)
 		mockRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
 	})
 
+	t.Run("Invalid Attributes", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockSchema := new(mocks.AttributeSchema)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewCreateUserApplicationService(mockRepo, mockEventPublisher, verifier, mockSchema)
+
+		req := &v1.CreateUserRequest{
+			Name:       "John Doe",
+			Email:      "john.doe@example.com",
+			DOB:        "2000-01-01",
+			Attributes: map[string]any{"department": 42},
+		}
+
+		schemaErr := fmt.Errorf("%w: /department: got number, want string", domain.ErrInvalidAttributes)
+		mockSchema.On("Validate", req.Attributes).Return(schemaErr).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAttributes)
+		assert.Equal(t, &v1.CreateUserResponse{}, res)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Invalid Attribute Name", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewCreateUserApplicationService(mockRepo, nil, verifier, newTestAttributeSchema())
+
+		req := &v1.CreateUserRequest{
+			Name:       "John Doe",
+			Email:      "john.doe@example.com",
+			DOB:        "2000-01-01",
+			Attributes: map[string]any{"cost-center": "42"},
+		}
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrInvalidAttributeName)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+// newTestAttributeSchema returns a schema that accepts any attributes
+func newTestAttributeSchema() *mocks.AttributeSchema {
+	schema := new(mocks.AttributeSchema)
+	schema.On("Validate", mock.Anything).Return(nil).Maybe()
+	return schema
 }
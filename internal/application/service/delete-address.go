@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteAddressApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *DeleteAddressApplicationService {
+	return &DeleteAddressApplicationService{repository, publisher}
+}
+
+type DeleteAddressApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *DeleteAddressApplicationService) Do(req *v1.AddressRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = user.RemoveAddress(req.AddressID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return err
+	}
+
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteAddressApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddAddress(&model.Address{ID: "address-1", Type: model.AddressHome, Lines: []string{"Street 1"}, Locality: "Berlin", PostalCode: "10117", Country: "DE"})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewDeleteAddressApplicationService(mockRepo, mockEventPublisher)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("Update", userID, mock.MatchedBy(func(u *model.User) bool { return len(u.GetAddresses()) == 0 })).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		err := service.Do(&v1.AddressRequest{UserID: userID, AddressID: "address-1"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Address Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewDeleteAddressApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		err := service.Do(&v1.AddressRequest{UserID: userID, AddressID: "missing"})
+
+		assert.ErrorIs(t, err, model.ErrAddressNotFound)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
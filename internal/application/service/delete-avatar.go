@@ -0,0 +1,47 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteAvatarApplicationService(
+	repository domain.UserRepository,
+	avatars domain.FileRepository,
+	publisher domain.EventPublisher) *DeleteAvatarApplicationService {
+	return &DeleteAvatarApplicationService{repository, avatars, publisher}
+}
+
+type DeleteAvatarApplicationService struct {
+	repository domain.UserRepository
+	avatars    domain.FileRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *DeleteAvatarApplicationService) Do(req *v1.DeleteAvatarRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanModifyFiles() {
+		return model.ErrFilesReadOnly
+	}
+
+	avatar, err := user.GetAvatar()
+	if err != nil {
+		return err
+	}
+
+	user.SetAvatar(nil)
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return err
+	}
+
+	deleteAvatar(s.avatars, user.ID, avatar)
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return nil
+}
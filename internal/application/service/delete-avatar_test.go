@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteAvatarApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewDeleteAvatarApplicationService(mockUserRepo, mockAvatarRepo, mockEventPublisher)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		avatar := &model.Avatar{ID: "avatar-1", ContentType: "image/png"}
+		user.SetAvatar(avatar)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("Update", userID, mock.MatchedBy(func(u *model.User) bool { return u.ToDTO().AvatarURL == "" })).Return(nil).Once()
+		for _, filename := range avatar.Filenames() {
+			mockAvatarRepo.On("Delete", userID, filename).Return(nil).Once()
+		}
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		err := service.Do(&v1.DeleteAvatarRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockAvatarRepo.AssertExpectations(t)
+	})
+
+	t.Run("No Avatar", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewDeleteAvatarApplicationService(mockUserRepo, nil, nil)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		err := service.Do(&v1.DeleteAvatarRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, model.ErrAvatarNotFound)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewDeleteAvatarApplicationService(mockUserRepo, nil, nil)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.SetAvatar(&model.Avatar{ID: "avatar-1", ContentType: "image/png"})
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		err := service.Do(&v1.DeleteAvatarRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
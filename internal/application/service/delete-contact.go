@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteContactPointApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *DeleteContactPointApplicationService {
+	return &DeleteContactPointApplicationService{repository, publisher}
+}
+
+type DeleteContactPointApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *DeleteContactPointApplicationService) Do(req *v1.ContactPointRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = user.RemoveContactPoint(req.ContactID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return err
+	}
+
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteContactPointApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewDeleteContactPointApplicationService(mockRepo, mockEventPublisher)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "phone-1", Type: model.ContactPhone, Value: "+447911123456"})
+
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+		mockRepo.On("Update", userID, mock.MatchedBy(func(u *model.User) bool { return len(u.GetContactPoints()) == 0 })).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		err := service.Do(&v1.ContactPointRequest{UserID: userID, ContactID: "phone-1"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewDeleteContactPointApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		err := service.Do(&v1.ContactPointRequest{UserID: userID, ContactID: "phone-1"})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteFileApplicationService(repository domain.UserRepository, storage domain.FileRepository) *DeleteFileApplicationService {
+	return &DeleteFileApplicationService{repository, storage}
+}
+
+// DeleteFileApplicationService deletes a file of a user with its versions and gives their space back to the
+// user's quota
+type DeleteFileApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+}
+
+func (s *DeleteFileApplicationService) Do(req *v1.DeleteFileRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !user.CanModifyFiles() {
+		return model.ErrFilesReadOnly
+	}
+
+	file, err := user.GetFile(req.FileID)
+	if err != nil {
+		return err
+	}
+
+	return deleteFile(s.repository, s.storage, file)
+}
+
+// deleteFile deletes the file with its versions, then their content
+func deleteFile(repository domain.UserRepository, storage domain.FileRepository, file *model.File) error {
+	// the versions are deleted with the file, their content is listed before they are gone
+	versions, err := repository.GetFileVersions(file.UserID, file.ID)
+	if err != nil {
+		return err
+	}
+
+	err = repository.DeleteFile(file.UserID, file.ID)
+	if err != nil {
+		return err
+	}
+
+	// the file is already gone for the user, content left behind only wastes space
+	if err := storage.Delete(file.UserID, file.StorageName()); err != nil {
+		log.Printf("error deleting content of file %s: %s", file.ID, err)
+	}
+	for _, version := range versions {
+		if err := storage.Delete(file.UserID, version.StorageName()); err != nil {
+			log.Printf("error deleting content of version %s: %s", version.ID, err)
+		}
+	}
+
+	return nil
+}
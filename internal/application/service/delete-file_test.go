@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteFileApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "test.txt", Size: 5})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDeleteFileApplicationService(mockUserRepo, mockFileRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-123").Return([]*model.FileVersion{{ID: "version-1"}}, nil).Once()
+		mockUserRepo.On("DeleteFile", userID, "file-123").Return(nil).Once()
+		mockFileRepo.On("Delete", userID, "test.txt").Return(nil).Once()
+		mockFileRepo.On("Delete", userID, ".version-version-1").Return(nil).Once()
+
+		err := service.Do(&v1.DeleteFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+	})
+
+	t.Run("File Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDeleteFileApplicationService(mockUserRepo, mockFileRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		err := service.Do(&v1.DeleteFileRequest{UserID: userID, FileID: "unknown"})
+
+		assert.ErrorIs(t, err, model.ErrFileNotFound)
+		mockUserRepo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+		mockFileRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewDeleteFileApplicationService(mockUserRepo, nil)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		err := service.Do(&v1.DeleteFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+		mockUserRepo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
 )
 
 func NewDeleteFilesApplicationService(repository domain.UserRepository, storage domain.FileRepository) *DeleteFilesApplicationService {
@@ -19,6 +20,10 @@ func (s *DeleteFilesApplicationService) Do(userID string) error {
 		return err
 	}
 
+	if !user.CanModifyFiles() {
+		return model.ErrFilesReadOnly
+	}
+
 	err = s.storage.DeleteFiles(user.ID)
 	if err != nil {
 		return err
@@ -48,6 +48,24 @@ func TestDeleteFilesApplicationService_Do(t *testing.T) {
 		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 
+	t.Run("Suspended User", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDeleteFilesApplicationService(mockUserRepo, mockFileRepo)
+
+		user, _ := model.NewUser("Test", "test@test.com", "1990-01-01")
+		user.ID = userID
+		user.RestoreStatus(model.UserSuspended, "abuse")
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		err := service.Do(userID)
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+		mockFileRepo.AssertNotCalled(t, "DeleteFiles", mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "DeleteFiles", mock.Anything)
+	})
+
 	t.Run("Storage Deletion Fails", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteFolderApplicationService(repository domain.UserRepository, storage domain.FileRepository) *DeleteFolderApplicationService {
+	return &DeleteFolderApplicationService{repository, storage}
+}
+
+// DeleteFolderApplicationService deletes a folder. A folder with files or subfolders is only deleted recursively:
+// the files in it and its subfolders are deleted with their versions first, then the folders from the deepest.
+// A failure leaves the folders that are still holding files.
+type DeleteFolderApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+}
+
+func (s *DeleteFolderApplicationService) Do(req *v1.DeleteFolderRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.CanModifyFiles() {
+		return model.ErrFilesReadOnly
+	}
+
+	folders, err := s.repository.GetFolders(user.ID)
+	if err != nil {
+		return err
+	}
+	tree := model.NewFolderTree(folders)
+	subtree := tree.Subtree(req.FolderID)
+	if len(subtree) == 0 {
+		return model.ErrFolderNotFound
+	}
+
+	inSubtree := make(map[string]bool, len(subtree))
+	for _, folder := range subtree {
+		inSubtree[folder.ID] = true
+	}
+	var files []*model.File
+	for _, file := range user.GetFiles() {
+		if inSubtree[tree.FolderOf(file)] {
+			files = append(files, file)
+		}
+	}
+
+	if !req.Recursive && (len(subtree) > 1 || len(files) > 0) {
+		return model.ErrFolderNotEmpty
+	}
+
+	for _, file := range files {
+		if err := deleteFile(s.repository, s.storage, file); err != nil {
+			return err
+		}
+	}
+	// subfolders come after their parent in the subtree
+	for _, folder := range slices.Backward(subtree) {
+		if err := s.repository.DeleteFolder(user.ID, folder.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
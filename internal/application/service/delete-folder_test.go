@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteFolderApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-1", UserID: userID, Name: "report.pdf", FolderID: "2024"})
+		user.AddFile(&model.File{ID: "file-2", UserID: userID, Name: "beach.jpg", FolderID: "photos"})
+		return user
+	}
+
+	t.Run("Empty Folder", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewDeleteFolderApplicationService(mockRepo, nil)
+
+		user := newUser()
+		user.DeleteFile("file-2")
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockRepo.On("DeleteFolder", userID, "photos").Return(nil).Once()
+
+		err := service.Do(&v1.DeleteFolderRequest{UserID: userID, FolderID: "photos"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Empty", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewDeleteFolderApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		err := service.Do(&v1.DeleteFolderRequest{UserID: userID, FolderID: "photos"})
+
+		assert.ErrorIs(t, err, model.ErrFolderNotEmpty)
+		mockRepo.AssertNotCalled(t, "DeleteFolder", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDeleteFolderApplicationService(mockUserRepo, mockFileRepo)
+
+		var deleted []string
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-1").Return([]*model.FileVersion{{ID: "version-1"}}, nil).Once()
+		mockUserRepo.On("DeleteFile", userID, "file-1").Return(nil).Once()
+		mockFileRepo.On("Delete", userID, "report.pdf").Return(nil).Once()
+		mockFileRepo.On("Delete", userID, ".version-version-1").Return(nil).Once()
+		mockUserRepo.On("DeleteFolder", userID, mock.AnythingOfType("string")).Return(nil).Twice().
+			Run(func(args mock.Arguments) { deleted = append(deleted, args.String(1)) })
+
+		err := service.Do(&v1.DeleteFolderRequest{UserID: userID, FolderID: "documents", Recursive: true})
+
+		assert.NoError(t, err)
+		// subfolders go first
+		assert.Equal(t, []string{"2024", "documents"}, deleted)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "DeleteFile", userID, "file-2")
+	})
+
+	t.Run("Folder Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewDeleteFolderApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		err := service.Do(&v1.DeleteFolderRequest{UserID: userID, FolderID: "unknown", Recursive: true})
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewDeleteFolderApplicationService(mockRepo, nil)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		err := service.Do(&v1.DeleteFolderRequest{UserID: userID, FolderID: "photos"})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+	})
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteGroupApplicationService(groups domain.GroupRepository, users domain.UserRepository, publisher domain.EventPublisher) *DeleteGroupApplicationService {
+	return &DeleteGroupApplicationService{groups, users, publisher}
+}
+
+// DeleteGroupApplicationService deletes groups, the former members are notified of their new roles
+type DeleteGroupApplicationService struct {
+	groups    domain.GroupRepository
+	users     domain.UserRepository
+	publisher domain.EventPublisher
+}
+
+func (s *DeleteGroupApplicationService) Do(req *v1.DeleteGroupRequest) error {
+	_, err := s.groups.Get(req.ID)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.users.List(&domain.UserFilter{GroupID: req.ID})
+	if err != nil {
+		return err
+	}
+
+	err = s.groups.Delete(req.ID)
+	if err != nil {
+		return err
+	}
+
+	// reload the former members to pick up the roles they lost
+	updated := make([]*model.User, 0, len(members))
+	for _, member := range members {
+		user, err := s.users.Get(member.ID)
+		if err != nil {
+			log.Printf("Failed to reload former member %s of group %s: %v", member.ID, req.ID, err)
+			continue
+		}
+		updated = append(updated, user)
+	}
+	publishUsersUpdated(s.publisher, updated)
+
+	return nil
+}
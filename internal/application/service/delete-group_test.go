@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteGroupApplicationService_Do(t *testing.T) {
+	groupID := "group-123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewDeleteGroupApplicationService(mockGroupRepo, mockUserRepo, mockEventPublisher)
+
+		group, _ := model.NewGroup("Editors", "", []string{"editor"})
+		group.ID = groupID
+		member, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		member.ID = "user-123"
+		member.SetRoles([]string{"editor"})
+		formerMember, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		formerMember.ID = "user-123"
+
+		mockGroupRepo.On("Get", groupID).Return(group, nil).Once()
+		mockUserRepo.On("List", &domain.UserFilter{GroupID: groupID}).Return([]*model.User{member}, nil).Once()
+		mockGroupRepo.On("Delete", groupID).Return(nil).Once()
+		mockUserRepo.On("Get", "user-123").Return(formerMember, nil).Once()
+		mockEventPublisher.On("Publish", mock.MatchedBy(func(e *domain.Event) bool {
+			return e.UserID == "user-123" && len(e.User.GetRoles()) == 0
+		})).Return(nil).Once()
+
+		err := service.Do(&v1.DeleteGroupRequest{ID: groupID})
+
+		assert.NoError(t, err)
+		mockGroupRepo.AssertExpectations(t)
+		assert.Eventually(t, func() bool {
+			return len(mockEventPublisher.Calls) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		service := NewDeleteGroupApplicationService(mockGroupRepo, nil, nil)
+
+		mockGroupRepo.On("Get", groupID).Return(nil, domain.ErrGroupNotFound).Once()
+
+		err := service.Do(&v1.DeleteGroupRequest{ID: groupID})
+
+		assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+		mockGroupRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+}
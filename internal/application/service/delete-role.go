@@ -0,0 +1,19 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteRoleApplicationService(roles domain.RoleRepository) *DeleteRoleApplicationService {
+	return &DeleteRoleApplicationService{roles}
+}
+
+// DeleteRoleApplicationService deletes roles, a role has to be removed from all groups first
+type DeleteRoleApplicationService struct {
+	roles domain.RoleRepository
+}
+
+func (s *DeleteRoleApplicationService) Do(req *v1.DeleteRoleRequest) error {
+	return s.roles.Delete(req.Name)
+}
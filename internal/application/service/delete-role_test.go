@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRoleApplicationService_Do(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewDeleteRoleApplicationService(mockRoleRepo)
+
+		mockRoleRepo.On("Delete", "admin").Return(nil).Once()
+
+		err := service.Do(&v1.DeleteRoleRequest{Name: "admin"})
+
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Role In Use", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewDeleteRoleApplicationService(mockRoleRepo)
+
+		mockRoleRepo.On("Delete", "admin").Return(domain.ErrRoleInUse).Once()
+
+		err := service.Do(&v1.DeleteRoleRequest{Name: "admin"})
+
+		assert.ErrorIs(t, err, domain.ErrRoleInUse)
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteStorageQuotaApplicationService(
+	repository domain.UserRepository,
+	quota *model.StorageQuota) *DeleteStorageQuotaApplicationService {
+	return &DeleteStorageQuotaApplicationService{repository, quota}
+}
+
+// DeleteStorageQuotaApplicationService restores the default quota of a user
+type DeleteStorageQuotaApplicationService struct {
+	repository domain.UserRepository
+	quota      *model.StorageQuota
+}
+
+func (s *DeleteStorageQuotaApplicationService) Do(req *v1.DeleteStorageQuotaRequest) (*v1.DeleteStorageQuotaResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.DeleteStorageQuotaResponse{}, err
+	}
+
+	res, err := setStorageQuota(s.repository, user.ID, nil, s.quota)
+	if err != nil {
+		return &v1.DeleteStorageQuotaResponse{}, err
+	}
+	return &v1.DeleteStorageQuotaResponse{Usage: res.Usage}, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteStorageQuotaApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	defaultQuota := &model.StorageQuota{Bytes: 1024}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewDeleteStorageQuotaApplicationService(mockUserRepo, defaultQuota)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("SetStorageQuota", userID, (*model.StorageQuota)(nil)).Return(nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+
+		res, err := service.Do(&v1.DeleteStorageQuotaRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1024), res.Usage.QuotaBytes)
+		assert.False(t, res.Usage.QuotaOverridden)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewDeleteStorageQuotaApplicationService(mockUserRepo, defaultQuota)
+
+		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(&v1.DeleteStorageQuotaRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockUserRepo.AssertNotCalled(t, "SetStorageQuota", mock.Anything, mock.Anything)
+	})
+}
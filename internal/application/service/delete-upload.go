@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDeleteUploadApplicationService(
+	uploads domain.UploadRepository,
+	partials domain.PartialUploadRepository,
+	locks *UploadLocks,
+) *DeleteUploadApplicationService {
+	return &DeleteUploadApplicationService{uploads, partials, locks}
+}
+
+// DeleteUploadApplicationService terminates a resumable upload and discards the content received so far
+type DeleteUploadApplicationService struct {
+	uploads  domain.UploadRepository
+	partials domain.PartialUploadRepository
+	locks    *UploadLocks
+}
+
+func (s *DeleteUploadApplicationService) Do(req *v1.DeleteUploadRequest) error {
+	upload, err := s.uploads.Get(req.UserID, req.UploadID)
+	if err != nil {
+		return err
+	}
+
+	if !s.locks.TryLock(upload.ID) {
+		return domain.ErrUploadLocked
+	}
+	defer s.locks.Unlock(upload.ID)
+
+	return discardUpload(s.uploads, s.partials, upload.ID)
+}
+
+// discardUpload deletes the content first, so a failure leaves the record for a retry
+func discardUpload(uploads domain.UploadRepository, partials domain.PartialUploadRepository, uploadID string) error {
+	if err := partials.Delete(uploadID); err != nil {
+		return err
+	}
+	return uploads.Delete(uploadID)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteUploadApplicationService_Do(t *testing.T) {
+	req := &v1.DeleteUploadRequest{UserID: "user-123", UploadID: "upload-123"}
+	upload := &model.Upload{ID: "upload-123", UserID: "user-123"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewDeleteUploadApplicationService(mockUploadRepo, mockPartialRepo, NewUploadLocks())
+
+		mockUploadRepo.On("Get", "user-123", "upload-123").Return(upload, nil).Once()
+		mockPartialRepo.On("Delete", "upload-123").Return(nil).Once()
+		mockUploadRepo.On("Delete", "upload-123").Return(nil).Once()
+
+		err := service.Do(req)
+
+		assert.NoError(t, err)
+		mockUploadRepo.AssertExpectations(t)
+		mockPartialRepo.AssertExpectations(t)
+	})
+
+	t.Run("Receiving A Chunk", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		locks := NewUploadLocks()
+		locks.TryLock("upload-123")
+		service := NewDeleteUploadApplicationService(mockUploadRepo, mockPartialRepo, locks)
+
+		mockUploadRepo.On("Get", "user-123", "upload-123").Return(upload, nil).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrUploadLocked)
+		mockPartialRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("Content Deletion Fails", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewDeleteUploadApplicationService(mockUploadRepo, mockPartialRepo, NewUploadLocks())
+		deleteErr := errors.New("permission denied")
+
+		mockUploadRepo.On("Get", "user-123", "upload-123").Return(upload, nil).Once()
+		mockPartialRepo.On("Delete", "upload-123").Return(deleteErr).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, deleteErr)
+		mockUploadRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewDeleteUploadApplicationService(mockUploadRepo, mockPartialRepo, NewUploadLocks())
+
+		mockUploadRepo.On("Get", "user-123", "upload-123").Return(nil, domain.ErrUploadNotFound).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+	})
+}
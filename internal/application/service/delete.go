@@ -7,13 +7,18 @@ import (
 	"github.com/bizio/abc-user-service/internal/domain/event"
 )
 
-func NewDeleteUserApplicationService(repository domain.UserRepository, storage domain.FileRepository, publisher domain.EventPublisher) *DeleteUserApplicationService {
-	return &DeleteUserApplicationService{repository, storage, publisher}
+func NewDeleteUserApplicationService(
+	repository domain.UserRepository,
+	storage domain.FileRepository,
+	avatars domain.FileRepository,
+	publisher domain.EventPublisher) *DeleteUserApplicationService {
+	return &DeleteUserApplicationService{repository, storage, avatars, publisher}
 }
 
 type DeleteUserApplicationService struct {
 	repository domain.UserRepository
 	storage    domain.FileRepository
+	avatars    domain.FileRepository
 	publisher  domain.EventPublisher
 }
 
@@ -28,6 +33,11 @@ func (s *DeleteUserApplicationService) Do(id string) error {
 		return err
 	}
 
+	err = s.avatars.DeleteFiles(id)
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		err = s.publisher.Publish(event.NewUserDeletedEvent(id))
 		if err != nil {
@@ -15,11 +15,13 @@ func TestDeleteUserApplicationService_Do(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewDeleteUserApplicationService(mockUserRepo, mockFileRepo, mockEventPublisher)
+		service := NewDeleteUserApplicationService(mockUserRepo, mockFileRepo, mockAvatarRepo, mockEventPublisher)
 
 		mockUserRepo.On("Delete", userID).Return(nil).Once()
 		mockFileRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockAvatarRepo.On("DeleteFiles", userID).Return(nil).Once()
 		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once()
 
 		err := service.Do(userID)
@@ -27,13 +29,15 @@ func TestDeleteUserApplicationService_Do(t *testing.T) {
 		assert.NoError(t, err)
 		mockUserRepo.AssertExpectations(t)
 		mockFileRepo.AssertExpectations(t)
+		mockAvatarRepo.AssertExpectations(t)
 	})
 
 	t.Run("User repository error", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewDeleteUserApplicationService(mockUserRepo, mockFileRepo, mockEventPublisher)
+		service := NewDeleteUserApplicationService(mockUserRepo, mockFileRepo, mockAvatarRepo, mockEventPublisher)
 
 		repoErr := errors.New("user not found in db")
 		mockUserRepo.On("Delete", userID).Return(repoErr).Once()
@@ -49,8 +53,9 @@ func TestDeleteUserApplicationService_Do(t *testing.T) {
 	t.Run("File repository error", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		mockFileRepo := new(mocks.FileRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewDeleteUserApplicationService(mockUserRepo, mockFileRepo, mockEventPublisher)
+		service := NewDeleteUserApplicationService(mockUserRepo, mockFileRepo, mockAvatarRepo, mockEventPublisher)
 
 		storageErr := errors.New("s3 bucket error")
 
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"crypto/subtle"
+	"io"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDownloadExportApplicationService(exports domain.ExportRepository, archives domain.ArchiveRepository) *DownloadExportApplicationService {
+	return &DownloadExportApplicationService{exports, archives}
+}
+
+type DownloadExportApplicationService struct {
+	exports  domain.ExportRepository
+	archives domain.ArchiveRepository
+}
+
+func (s *DownloadExportApplicationService) Do(req *v1.DownloadExportRequest) (io.ReadCloser, error) {
+	export, err := s.exports.Get(req.UserID, req.ExportID)
+	if err != nil {
+		return nil, err
+	}
+
+	// an invalid token is reported as not found, so the link can't be guessed
+	if subtle.ConstantTimeCompare([]byte(export.Token), []byte(req.Token)) != 1 {
+		return nil, domain.ErrExportNotFound
+	}
+
+	if export.IsExpired(time.Now()) {
+		if err := s.archives.Delete(export.ArchiveName()); err != nil {
+			log.Printf("error deleting expired archive of export %s: %s", export.ID, err)
+		}
+		return nil, domain.ErrExportExpired
+	}
+
+	if export.Status != model.ExportCompleted {
+		return nil, domain.ErrExportNotReady
+	}
+
+	return s.archives.Open(export.ArchiveName())
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDownloadExportApplicationService_Do(t *testing.T) {
+	req := &v1.DownloadExportRequest{UserID: "user-123", ExportID: "export-1", Token: "secret"}
+
+	newExport := func(status model.ExportStatus, expiresAt time.Time) *model.Export {
+		return &model.Export{ID: "export-1", UserID: "user-123", Status: status, Token: "secret", ExpiresAt: expiresAt}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewDownloadExportApplicationService(mockExportRepo, mockArchiveRepo)
+
+		archive := io.NopCloser(strings.NewReader("zip"))
+		mockExportRepo.On("Get", "user-123", "export-1").Return(newExport(model.ExportCompleted, time.Now().Add(time.Hour)), nil).Once()
+		mockArchiveRepo.On("Open", "export-1.zip").Return(archive, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, archive, res)
+		mockArchiveRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewDownloadExportApplicationService(mockExportRepo, mockArchiveRepo)
+
+		mockExportRepo.On("Get", "user-123", "export-1").Return(newExport(model.ExportCompleted, time.Now().Add(time.Hour)), nil).Once()
+
+		res, err := service.Do(&v1.DownloadExportRequest{UserID: "user-123", ExportID: "export-1", Token: "guess"})
+
+		assert.ErrorIs(t, err, domain.ErrExportNotFound)
+		assert.Nil(t, res)
+		mockArchiveRepo.AssertNotCalled(t, "Open", mock.Anything)
+	})
+
+	t.Run("Not Ready", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewDownloadExportApplicationService(mockExportRepo, mockArchiveRepo)
+
+		mockExportRepo.On("Get", "user-123", "export-1").Return(newExport(model.ExportPending, time.Time{}), nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrExportNotReady)
+		assert.Nil(t, res)
+		mockArchiveRepo.AssertNotCalled(t, "Open", mock.Anything)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewDownloadExportApplicationService(mockExportRepo, mockArchiveRepo)
+
+		mockExportRepo.On("Get", "user-123", "export-1").Return(newExport(model.ExportCompleted, time.Now().Add(-time.Minute)), nil).Once()
+		mockArchiveRepo.On("Delete", "export-1.zip").Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrExportExpired)
+		assert.Nil(t, res)
+		mockArchiveRepo.AssertExpectations(t)
+		mockArchiveRepo.AssertNotCalled(t, "Open", mock.Anything)
+	})
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"io"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDownloadFileVersionApplicationService(repository domain.UserRepository, storage domain.FileRepository) *DownloadFileVersionApplicationService {
+	return &DownloadFileVersionApplicationService{repository, storage}
+}
+
+type DownloadFileVersionApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+}
+
+// Do opens the content of a prior version of a file, it is served as the file was at that version. Versions
+// pending a malware scan are not served.
+func (s *DownloadFileVersionApplicationService) Do(req *v1.DownloadFileVersionRequest) (*model.File, io.ReadCloser, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := user.GetFile(req.FileID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, err := s.repository.GetFileVersion(user.ID, file.ID, req.VersionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version.IsPendingScan() {
+		return nil, nil, model.ErrFilePendingScan
+	}
+
+	content, err := s.storage.Get(user.ID, version.StorageName())
+	if err != nil {
+		return nil, nil, err
+	}
+	return file.AtVersion(version), content, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"io"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDownloadFileVersionApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	req := &v1.DownloadFileVersionRequest{UserID: userID, FileID: "file-123", VersionID: "version-1"}
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "contract.pdf", Size: 7, Version: 2})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFileVersionApplicationService(mockUserRepo, mockFileRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFileVersion", userID, "file-123", "version-1").Return(&model.FileVersion{
+			ID: "version-1", FileID: "file-123", Version: 1, Size: 5, Digest: helloDigest,
+		}, nil).Once()
+		mockFileRepo.On("Get", userID, ".version-version-1").Return(newTestBlob(t, "hello"), nil).Once()
+
+		file, content, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "contract.pdf", file.Name)
+		assert.Equal(t, 1, file.Version)
+		assert.Equal(t, int64(5), file.Size)
+		body, _ := io.ReadAll(content)
+		assert.Equal(t, "hello", string(body))
+	})
+
+	t.Run("Version Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFileVersionApplicationService(mockUserRepo, mockFileRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFileVersion", userID, "file-123", "version-1").Return(nil, model.ErrFileVersionNotFound).Once()
+
+		_, _, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFileVersionNotFound)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Pending Scan", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFileVersionApplicationService(mockUserRepo, mockFileRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFileVersion", userID, "file-123", "version-1").Return(&model.FileVersion{
+			ID: "version-1", FileID: "file-123", ScanStatus: model.ScanPending,
+		}, nil).Once()
+
+		_, _, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFilePendingScan)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"io"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewDownloadFileApplicationService(repository domain.UserRepository, storage domain.FileRepository) *DownloadFileApplicationService {
+	return &DownloadFileApplicationService{repository, storage}
+}
+
+type DownloadFileApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+}
+
+// Do opens the content of a file, files flagged as corrupted or pending a malware scan are not served
+func (s *DownloadFileApplicationService) Do(req *v1.DownloadFileRequest) (*model.File, io.ReadCloser, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := user.GetFile(req.FileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if file.Corrupted {
+		return nil, nil, model.ErrFileCorrupted
+	}
+	if file.IsPendingScan() {
+		return nil, nil, model.ErrFilePendingScan
+	}
+
+	content, err := s.storage.Get(user.ID, file.StorageName())
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, content, nil
+}
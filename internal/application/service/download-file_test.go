@@ -0,0 +1,80 @@
+package service
+
+import (
+	"io"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDownloadFileApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func(file *model.File) *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(file)
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Digest: helloDigest})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "hello.txt").Return(newTestBlob(t, "hello"), nil).Once()
+
+		file, content, err := service.Do(&v1.DownloadFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, helloDigest, file.Digest)
+		body, _ := io.ReadAll(content)
+		assert.Equal(t, "hello", string(body))
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Digest: helloDigest, Corrupted: true})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, _, err := service.Do(&v1.DownloadFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.ErrorIs(t, err, model.ErrFileCorrupted)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Pending Scan", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", ScanStatus: model.ScanPending})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, _, err := service.Do(&v1.DownloadFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.ErrorIs(t, err, model.ErrFilePendingScan)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("File Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt"})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, _, err := service.Do(&v1.DownloadFileRequest{UserID: userID, FileID: "missing"})
+
+		assert.ErrorIs(t, err, model.ErrFileNotFound)
+	})
+}
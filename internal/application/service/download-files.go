@@ -0,0 +1,123 @@
+package service
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+// manifestEntry is the name of the manifest in an archive of a user's files
+const manifestEntry = "manifest.json"
+
+func NewDownloadFilesApplicationService(repository domain.UserRepository, storage domain.FileRepository) *DownloadFilesApplicationService {
+	return &DownloadFilesApplicationService{repository, storage}
+}
+
+// DownloadFilesApplicationService streams all the files of a user as a ZIP archive. The archive is written
+// while it is read, from the stored content of one file at a time, and is never held on disk or in memory.
+type DownloadFilesApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+}
+
+// Do opens the archive of the user's files. Files flagged as corrupted or pending a malware scan are left out,
+// as are files whose content can't be read; the manifest lists them as skipped. The archive is cut short if
+// writing it fails, so a client can't mistake it for a complete one.
+func (s *DownloadFilesApplicationService) Do(req *v1.DownloadFilesRequest) (io.ReadCloser, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := user.GetFiles()
+	reader, writer := io.Pipe()
+	go func() {
+		err := s.writeArchive(writer, user.ID, files, req.Manifest)
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			log.Printf("error streaming files of user %s: %s", user.ID, err)
+		}
+		writer.CloseWithError(err)
+	}()
+	return reader, nil
+}
+
+func (s *DownloadFilesApplicationService) writeArchive(w io.Writer, userID string, files []*model.File, withManifest bool) error {
+	var reserved []string
+	if withManifest {
+		reserved = append(reserved, manifestEntry)
+	}
+	entries := model.ArchiveEntryNames(files, reserved...)
+	manifest := &v1.FilesArchiveManifest{UserID: userID, Files: []*v1.ArchivedFile{}}
+
+	zw := zip.NewWriter(w)
+	for i, file := range files {
+		archived, err := s.writeFileEntry(zw, file, entries[i])
+		if err != nil {
+			return err
+		}
+		if archived != nil {
+			manifest.Files = append(manifest.Files, archived)
+		} else {
+			manifest.Skipped = append(manifest.Skipped, s.skipped(file))
+		}
+	}
+
+	if withManifest {
+		if err := writeJSONEntry(zw, manifestEntry, manifest); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeFileEntry copies the content of the file to the archive, or returns nil if it can't be served
+func (s *DownloadFilesApplicationService) writeFileEntry(zw *zip.Writer, file *model.File, entry string) (*v1.ArchivedFile, error) {
+	if file.Corrupted || file.IsPendingScan() {
+		return nil, nil
+	}
+	content, err := s.storage.Get(file.UserID, file.StorageName())
+	if err != nil {
+		log.Printf("error reading content of file %s: %s", file.ID, err)
+		return nil, nil
+	}
+	defer content.Close()
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: entry, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(w, hash), content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.ArchivedFile{
+		ID:          file.ID,
+		Name:        file.Name,
+		Entry:       entry,
+		Size:        size,
+		ContentType: file.ContentType,
+		Digest:      hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// skipped tells why the content of the file isn't in the archive
+func (s *DownloadFilesApplicationService) skipped(file *model.File) *v1.SkippedFile {
+	reason := "content unavailable"
+	switch {
+	case file.Corrupted:
+		reason = model.ErrFileCorrupted.Error()
+	case file.IsPendingScan():
+		reason = model.ErrFilePendingScan.Error()
+	}
+	return &v1.SkippedFile{ID: file.ID, Name: file.Name, Reason: reason}
+}
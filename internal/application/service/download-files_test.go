@@ -0,0 +1,159 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// readTestArchive reads the whole archive and returns the content of its entries by name, in order
+func readTestArchive(t *testing.T, archive io.ReadCloser) ([]string, map[string]string) {
+	defer archive.Close()
+	body, err := io.ReadAll(archive)
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(t, err)
+
+	var names []string
+	entries := make(map[string]string)
+	for _, entry := range zr.File {
+		r, err := entry.Open()
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(r)
+		r.Close()
+		names = append(names, entry.Name)
+		entries[entry.Name] = string(content)
+	}
+	return names, entries
+}
+
+func TestDownloadFilesApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func(files ...*model.File) *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		for _, file := range files {
+			user.AddFile(file)
+		}
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFilesApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(
+			&model.File{ID: "file-1", UserID: userID, Name: "hello.txt", StorageKey: "file-1", ContentType: "text/plain"},
+			&model.File{ID: "file-2", UserID: userID, Name: "HELLO.txt", StorageKey: "file-2"},
+			&model.File{ID: "file-3", UserID: userID, Name: "manifest.json", StorageKey: "file-3"},
+			&model.File{ID: "file-4", UserID: userID, Name: "broken.txt", StorageKey: "file-4", Corrupted: true},
+			&model.File{ID: "file-5", UserID: userID, Name: "new.txt", StorageKey: "file-5", ScanStatus: model.ScanPending},
+		)
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "file-1").Return(newTestBlob(t, "hello"), nil).Once()
+		mockFileRepo.On("Get", userID, "file-2").Return(newTestBlob(t, "HELLO"), nil).Once()
+		mockFileRepo.On("Get", userID, "file-3").Return(newTestBlob(t, "{}"), nil).Once()
+
+		archive, err := service.Do(&v1.DownloadFilesRequest{UserID: userID, Manifest: true})
+
+		assert.NoError(t, err)
+		names, entries := readTestArchive(t, archive)
+		assert.Equal(t, []string{"hello.txt", "HELLO (1).txt", "manifest (1).json", "manifest.json"}, names)
+		assert.Equal(t, "hello", entries["hello.txt"])
+		assert.Equal(t, "HELLO", entries["HELLO (1).txt"])
+
+		manifest := &v1.FilesArchiveManifest{}
+		assert.NoError(t, json.Unmarshal([]byte(entries["manifest.json"]), manifest))
+		assert.Equal(t, userID, manifest.UserID)
+		assert.Len(t, manifest.Files, 3)
+		assert.Equal(t, &v1.ArchivedFile{
+			ID: "file-1", Name: "hello.txt", Entry: "hello.txt", Size: 5, ContentType: "text/plain", Digest: helloDigest,
+		}, manifest.Files[0])
+		assert.Equal(t, "HELLO (1).txt", manifest.Files[1].Entry)
+		assert.Len(t, manifest.Skipped, 2)
+		assert.Equal(t, model.ErrFileCorrupted.Error(), manifest.Skipped[0].Reason)
+		assert.Equal(t, model.ErrFilePendingScan.Error(), manifest.Skipped[1].Reason)
+		mockFileRepo.AssertExpectations(t)
+	})
+
+	t.Run("Without Manifest", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFilesApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-1", UserID: userID, Name: "manifest.json", StorageKey: "file-1"})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "file-1").Return(newTestBlob(t, "{}"), nil).Once()
+
+		archive, err := service.Do(&v1.DownloadFilesRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		names, _ := readTestArchive(t, archive)
+		assert.Equal(t, []string{"manifest.json"}, names)
+	})
+
+	t.Run("Content Unavailable", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFilesApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(
+			&model.File{ID: "file-1", UserID: userID, Name: "gone.txt", StorageKey: "file-1"},
+			&model.File{ID: "file-2", UserID: userID, Name: "hello.txt", StorageKey: "file-2"},
+		)
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "file-1").Return(nil, errors.New("no such file")).Once()
+		mockFileRepo.On("Get", userID, "file-2").Return(newTestBlob(t, "hello"), nil).Once()
+
+		archive, err := service.Do(&v1.DownloadFilesRequest{UserID: userID, Manifest: true})
+
+		assert.NoError(t, err)
+		names, entries := readTestArchive(t, archive)
+		assert.Equal(t, []string{"hello.txt", "manifest.json"}, names)
+		manifest := &v1.FilesArchiveManifest{}
+		assert.NoError(t, json.Unmarshal([]byte(entries["manifest.json"]), manifest))
+		assert.Equal(t, []*v1.SkippedFile{{ID: "file-1", Name: "gone.txt", Reason: "content unavailable"}}, manifest.Skipped)
+	})
+
+	t.Run("Closed Early", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFilesApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-1", UserID: userID, Name: "hello.txt", StorageKey: "file-1"})
+		blob := newTestBlob(t, "hello")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "file-1").Return(blob, nil).Once()
+
+		archive, err := service.Do(&v1.DownloadFilesRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		assert.NoError(t, archive.Close())
+		_, err = archive.Read(make([]byte, 1))
+		assert.ErrorIs(t, err, io.ErrClosedPipe)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewDownloadFilesApplicationService(mockUserRepo, mockFileRepo)
+
+		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(&v1.DownloadFilesRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+const verificationEmailSubject = "Verify your email address"
+
+func NewEmailVerifier(tokens domain.VerificationTokenRepository, mailer domain.Mailer, ttl time.Duration) *EmailVerifier {
+	return &EmailVerifier{tokens, mailer, ttl}
+}
+
+// EmailVerifier issues verification tokens for a user's addresses and mails them.
+// Issuing a new token invalidates the previous ones.
+type EmailVerifier struct {
+	tokens domain.VerificationTokenRepository
+	mailer domain.Mailer
+	ttl    time.Duration
+}
+
+// Send sends a token for the user's current address
+func (v *EmailVerifier) Send(user *model.User) error {
+	return v.SendTo(user, user.ToDTO().Email)
+}
+
+// SendTo sends a token for one of the user's addresses, e.g. a secondary email contact point
+func (v *EmailVerifier) SendTo(user *model.User, email string) error {
+	dto := user.ToDTO()
+
+	token, plain, err := model.NewVerificationToken(dto.ID, email, v.ttl)
+	if err != nil {
+		return err
+	}
+
+	err = v.tokens.DeleteByUser(dto.ID)
+	if err != nil {
+		return err
+	}
+
+	err = v.tokens.Create(token)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\nuse the following token to verify your email address, it expires at %s.\n\n"+
+		"POST /v1/users/%s/email/verify\n{\"token\": \"%s\"}\n",
+		dto.Name, token.ExpiresAt.Format(time.RFC1123), dto.ID, plain)
+
+	return v.mailer.Send(email, verificationEmailSubject, body)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestEmailVerifier returns a verifier backed by mocks with no expectations set
+func newTestEmailVerifier() (*EmailVerifier, *mocks.VerificationTokenRepository, *mocks.Mailer) {
+	mockTokenRepo := new(mocks.VerificationTokenRepository)
+	mockMailer := new(mocks.Mailer)
+	return NewEmailVerifier(mockTokenRepo, mockMailer, time.Hour), mockTokenRepo, mockMailer
+}
+
+func TestEmailVerifier_Send(t *testing.T) {
+	user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+	user.ID = "user-123"
+
+	t.Run("Success", func(t *testing.T) {
+		verifier, mockTokenRepo, mockMailer := newTestEmailVerifier()
+
+		var token *model.VerificationToken
+		mockTokenRepo.On("DeleteByUser", "user-123").Return(nil).Once()
+		mockTokenRepo.On("Create", mock.AnythingOfType("*model.VerificationToken")).Return(nil).Once().
+			Run(func(args mock.Arguments) { token = args.Get(0).(*model.VerificationToken) })
+		mockMailer.On("Send", "test@example.com", verificationEmailSubject, mock.MatchedBy(func(body string) bool {
+			// the mail carries the plain token, only its hash is persisted
+			for _, field := range strings.Fields(body) {
+				plain := strings.Trim(field, `"}`)
+				if model.HashVerificationToken(plain) == token.Hash {
+					return true
+				}
+			}
+			return false
+		})).Return(nil).Once()
+
+		err := verifier.Send(user)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test@example.com", token.Email)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+		mockTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Token repository error", func(t *testing.T) {
+		verifier, mockTokenRepo, mockMailer := newTestEmailVerifier()
+
+		repoErr := errors.New("db error")
+		mockTokenRepo.On("DeleteByUser", "user-123").Return(nil).Once()
+		mockTokenRepo.On("Create", mock.Anything).Return(repoErr).Once()
+
+		err := verifier.Send(user)
+
+		assert.ErrorIs(t, err, repoErr)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/event"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewEraseUserApplicationService(
+	repository domain.UserRepository,
+	credentials domain.CredentialRepository,
+	storage domain.FileRepository,
+	avatars domain.FileRepository,
+	quarantine domain.FileRepository,
+	exports domain.ExportRepository,
+	archives domain.ArchiveRepository,
+	uploads domain.UploadRepository,
+	partials domain.PartialUploadRepository,
+	publisher domain.EventPublisher) *EraseUserApplicationService {
+	return &EraseUserApplicationService{repository, credentials, storage, avatars, quarantine, exports, archives, uploads, partials, publisher}
+}
+
+// EraseUserApplicationService irreversibly anonymises a user, deleted or not, and purges its files, its
+// quarantined files, the archives of its exports and its resumable uploads
+type EraseUserApplicationService struct {
+	repository  domain.UserRepository
+	credentials domain.CredentialRepository
+	storage     domain.FileRepository
+	avatars     domain.FileRepository
+	quarantine  domain.FileRepository
+	exports     domain.ExportRepository
+	archives    domain.ArchiveRepository
+	uploads     domain.UploadRepository
+	partials    domain.PartialUploadRepository
+	publisher   domain.EventPublisher
+}
+
+func (s *EraseUserApplicationService) Do(id string) (*v1.EraseUserResponse, error) {
+	user, err := s.repository.GetIncludingDeleted(id)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+
+	// blobs go first, so a failure leaves the rows in place for a retry
+	err = s.storage.DeleteFiles(user.ID)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+
+	err = s.avatars.DeleteFiles(user.ID)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+
+	err = s.quarantine.DeleteFiles(user.ID)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+
+	exports, err := s.exports.List(user.ID)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+	for _, export := range exports {
+		if err := s.archives.Delete(export.ArchiveName()); err != nil {
+			return &v1.EraseUserResponse{}, err
+		}
+	}
+
+	uploads, err := s.uploads.List(user.ID)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+	for _, upload := range uploads {
+		if err := s.partials.Delete(upload.ID); err != nil {
+			return &v1.EraseUserResponse{}, err
+		}
+	}
+
+	err = s.credentials.Delete(user.ID)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+
+	erasure := model.NewErasure(user.ID, len(user.GetFiles()))
+	user.Erase()
+
+	err = s.repository.Erase(user, erasure)
+	if err != nil {
+		return &v1.EraseUserResponse{}, err
+	}
+
+	go func() {
+		err = s.publisher.Publish(event.NewUserErasedEvent(user.ID))
+		if err != nil {
+			log.Printf("Failed to publish user erased event: %v", err)
+		}
+	}()
+
+	return &v1.EraseUserResponse{Erasure: erasure.ToDTO()}, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEraseUserApplicationService_Do(t *testing.T) {
+	userID := "user-to-erase"
+
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-1", UserID: userID, Name: "passport.pdf"})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockQuarantineRepo := new(mocks.FileRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewEraseUserApplicationService(mockUserRepo, mockCredentialRepo, mockFileRepo, mockAvatarRepo,
+			mockQuarantineRepo, mockExportRepo, mockArchiveRepo, mockUploadRepo, mockPartialRepo, mockEventPublisher)
+
+		published := make(chan *domain.Event, 1)
+		mockUserRepo.On("GetIncludingDeleted", userID).Return(newUser(), nil).Once()
+		mockFileRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockAvatarRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockQuarantineRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockExportRepo.On("List", userID).Return([]*model.Export{{ID: "export-1", UserID: userID}}, nil).Once()
+		mockArchiveRepo.On("Delete", "export-1.zip").Return(nil).Once()
+		mockUploadRepo.On("List", userID).Return([]*model.Upload{{ID: "upload-1", UserID: userID}}, nil).Once()
+		mockPartialRepo.On("Delete", "upload-1").Return(nil).Once()
+		mockCredentialRepo.On("Delete", userID).Return(nil).Once()
+		mockUserRepo.On("Erase", mock.MatchedBy(func(u *model.User) bool {
+			dto := u.ToDTO()
+			return dto.Name == model.ErasedName && dto.Email == model.ErasedEmail(userID) && len(dto.Files) == 0
+		}), mock.AnythingOfType("*model.Erasure")).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once().
+			Run(func(args mock.Arguments) { published <- args.Get(0).(*domain.Event) })
+
+		res, err := service.Do(userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, userID, res.Erasure.UserID)
+		assert.Equal(t, 1, res.Erasure.FilesErased)
+		assert.Equal(t, domain.UserErasedEvent, (<-published).Type)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockAvatarRepo.AssertExpectations(t)
+		mockQuarantineRepo.AssertExpectations(t)
+		mockCredentialRepo.AssertExpectations(t)
+		mockArchiveRepo.AssertExpectations(t)
+		mockPartialRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockQuarantineRepo := new(mocks.FileRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewEraseUserApplicationService(mockUserRepo, mockCredentialRepo, mockFileRepo, mockAvatarRepo,
+			mockQuarantineRepo, mockExportRepo, mockArchiveRepo, mockUploadRepo, mockPartialRepo, mockEventPublisher)
+
+		mockUserRepo.On("GetIncludingDeleted", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		res, err := service.Do(userID)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Equal(t, &v1.EraseUserResponse{}, res)
+		mockFileRepo.AssertNotCalled(t, "DeleteFiles", userID)
+	})
+
+	t.Run("File repository error", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockQuarantineRepo := new(mocks.FileRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewEraseUserApplicationService(mockUserRepo, mockCredentialRepo, mockFileRepo, mockAvatarRepo,
+			mockQuarantineRepo, mockExportRepo, mockArchiveRepo, mockUploadRepo, mockPartialRepo, mockEventPublisher)
+
+		storageErr := errors.New("disk error")
+		mockUserRepo.On("GetIncludingDeleted", userID).Return(newUser(), nil).Once()
+		mockFileRepo.On("DeleteFiles", userID).Return(storageErr).Once()
+
+		res, err := service.Do(userID)
+
+		assert.ErrorIs(t, err, storageErr)
+		assert.Equal(t, &v1.EraseUserResponse{}, res)
+		mockUserRepo.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Quarantine error", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockQuarantineRepo := new(mocks.FileRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewEraseUserApplicationService(mockUserRepo, mockCredentialRepo, mockFileRepo, mockAvatarRepo,
+			mockQuarantineRepo, mockExportRepo, mockArchiveRepo, mockUploadRepo, mockPartialRepo, mockEventPublisher)
+
+		storageErr := errors.New("disk error")
+		mockUserRepo.On("GetIncludingDeleted", userID).Return(newUser(), nil).Once()
+		mockFileRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockAvatarRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockQuarantineRepo.On("DeleteFiles", userID).Return(storageErr).Once()
+
+		_, err := service.Do(userID)
+
+		assert.ErrorIs(t, err, storageErr)
+		mockUserRepo.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Export archive error", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockQuarantineRepo := new(mocks.FileRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewEraseUserApplicationService(mockUserRepo, mockCredentialRepo, mockFileRepo, mockAvatarRepo,
+			mockQuarantineRepo, mockExportRepo, mockArchiveRepo, mockUploadRepo, mockPartialRepo, mockEventPublisher)
+
+		storageErr := errors.New("disk error")
+		mockUserRepo.On("GetIncludingDeleted", userID).Return(newUser(), nil).Once()
+		mockFileRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockAvatarRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockQuarantineRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockExportRepo.On("List", userID).Return([]*model.Export{{ID: "export-1", UserID: userID}}, nil).Once()
+		mockArchiveRepo.On("Delete", "export-1.zip").Return(storageErr).Once()
+
+		_, err := service.Do(userID)
+
+		assert.ErrorIs(t, err, storageErr)
+		// the rows stay for a retry
+		mockUserRepo.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Partial upload error", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockQuarantineRepo := new(mocks.FileRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewEraseUserApplicationService(mockUserRepo, mockCredentialRepo, mockFileRepo, mockAvatarRepo,
+			mockQuarantineRepo, mockExportRepo, mockArchiveRepo, mockUploadRepo, mockPartialRepo, mockEventPublisher)
+
+		storageErr := errors.New("disk error")
+		mockUserRepo.On("GetIncludingDeleted", userID).Return(newUser(), nil).Once()
+		mockFileRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockAvatarRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockQuarantineRepo.On("DeleteFiles", userID).Return(nil).Once()
+		mockExportRepo.On("List", userID).Return([]*model.Export{}, nil).Once()
+		mockUploadRepo.On("List", userID).Return([]*model.Upload{{ID: "upload-1", UserID: userID}}, nil).Once()
+		mockPartialRepo.On("Delete", "upload-1").Return(storageErr).Once()
+
+		_, err := service.Do(userID)
+
+		assert.ErrorIs(t, err, storageErr)
+		mockCredentialRepo.AssertNotCalled(t, "Delete", mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"log"
+	"path"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewExportUserApplicationService(
+	repository domain.UserRepository,
+	exports domain.ExportRepository,
+	storage domain.FileRepository,
+	archives domain.ArchiveRepository,
+	ttl time.Duration) *ExportUserApplicationService {
+	return &ExportUserApplicationService{repository, exports, storage, archives, ttl}
+}
+
+// ExportUserApplicationService builds a ZIP archive with all the data held about a user,
+// so that subject access requests can be answered without hand-assembling data
+type ExportUserApplicationService struct {
+	repository domain.UserRepository
+	exports    domain.ExportRepository
+	storage    domain.FileRepository
+	archives   domain.ArchiveRepository
+	ttl        time.Duration
+}
+
+func (s *ExportUserApplicationService) Do(req *v1.ExportUserRequest) (*v1.ExportUserResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.ExportUserResponse{}, err
+	}
+
+	export, err := model.NewExport(user.ID)
+	if err != nil {
+		return &v1.ExportUserResponse{}, err
+	}
+
+	_, err = s.exports.Create(export)
+	if err != nil {
+		return &v1.ExportUserResponse{}, err
+	}
+
+	res := &v1.ExportUserResponse{Export: export.ToDTO()}
+	go s.build(user, export)
+
+	return res, nil
+}
+
+// build writes the archive and records the outcome on the export
+func (s *ExportUserApplicationService) build(user *model.User, export *model.Export) {
+	err := s.writeArchive(user, export)
+	if err != nil {
+		log.Printf("error building export %s: %s", export.ID, err)
+		export.Fail(err)
+		if err := s.archives.Delete(export.ArchiveName()); err != nil {
+			log.Printf("error deleting archive of export %s: %s", export.ID, err)
+		}
+	} else {
+		export.Complete(time.Now().Add(s.ttl))
+	}
+
+	if err := s.exports.Update(export); err != nil {
+		log.Printf("error updating export %s: %s", export.ID, err)
+	}
+}
+
+func (s *ExportUserApplicationService) writeArchive(user *model.User, export *model.Export) error {
+	dst, err := s.archives.Create(export.ArchiveName())
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(dst)
+	err = s.writeEntries(zw, user)
+	if err != nil {
+		zw.Close()
+		dst.Close()
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func (s *ExportUserApplicationService) writeEntries(zw *zip.Writer, user *model.User) error {
+	err := writeJSONEntry(zw, "profile.json", user.ToDTO())
+	if err != nil {
+		return err
+	}
+
+	files := make([]*v1.File, 0, len(user.GetFiles()))
+	for _, file := range user.GetFiles() {
+		files = append(files, file.ToDTO())
+	}
+	err = writeJSONEntry(zw, "files.json", files)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range user.GetFiles() {
+		// files.json lists them, their content is only served once it is found clean
+		if file.IsPendingScan() {
+			continue
+		}
+		err = s.writeFileEntry(zw, user.ID, file)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ExportUserApplicationService) writeFileEntry(zw *zip.Writer, userID string, file *model.File) error {
+	blob, err := s.storage.Get(userID, file.StorageName())
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	w, err := zw.Create(path.Join("files", file.ID+"-"+file.AttachmentName()))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, blob)
+	return err
+}
+
+func writeJSONEntry(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
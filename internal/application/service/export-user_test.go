@@ -0,0 +1,149 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// bufferWriteCloser collects the archive written by the service
+type bufferWriteCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferWriteCloser) Close() error {
+	return nil
+}
+
+func TestExportUserApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	ttl := time.Hour
+
+	t.Run("Success", func(t *testing.T) {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+
+		mockUserRepo := new(mocks.UserRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewExportUserApplicationService(mockUserRepo, mockExportRepo, mockFileRepo, mockArchiveRepo, ttl)
+
+		done := make(chan struct{})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockExportRepo.On("Create", mock.AnythingOfType("*model.Export")).Return("export-1", nil).Once()
+		mockArchiveRepo.On("Create", mock.Anything).Return(&bufferWriteCloser{}, nil).Once()
+		mockExportRepo.On("Update", mock.AnythingOfType("*model.Export")).Return(nil).Once().
+			Run(func(args mock.Arguments) { close(done) })
+
+		res, err := service.Do(&v1.ExportUserRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(model.ExportPending), res.Export.Status)
+		assert.Empty(t, res.Export.DownloadURL)
+
+		<-done
+		mockUserRepo.AssertExpectations(t)
+		mockExportRepo.AssertExpectations(t)
+		mockArchiveRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockExportRepo := new(mocks.ExportRepository)
+		service := NewExportUserApplicationService(mockUserRepo, mockExportRepo, nil, nil, ttl)
+
+		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		res, err := service.Do(&v1.ExportUserRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Equal(t, &v1.ExportUserResponse{}, res)
+		mockExportRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestExportUserApplicationService_build(t *testing.T) {
+	userID := "user-123"
+	ttl := time.Hour
+
+	blobPath := path.Join(t.TempDir(), "contract.pdf")
+	assert.NoError(t, os.WriteFile(blobPath, []byte("contract content"), 0o600))
+
+	t.Run("Archive contains profile, metadata and files", func(t *testing.T) {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-1", UserID: userID, Name: "contract.pdf", Size: 16})
+		export := &model.Export{ID: "export-1", UserID: userID, Status: model.ExportPending}
+
+		mockExportRepo := new(mocks.ExportRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewExportUserApplicationService(nil, mockExportRepo, mockFileRepo, mockArchiveRepo, ttl)
+
+		blob, err := os.Open(blobPath)
+		assert.NoError(t, err)
+
+		archive := &bufferWriteCloser{}
+		mockArchiveRepo.On("Create", "export-1.zip").Return(archive, nil).Once()
+		mockFileRepo.On("Get", userID, "contract.pdf").Return(blob, nil).Once()
+		mockExportRepo.On("Update", export).Return(nil).Once()
+
+		service.build(user, export)
+
+		assert.Equal(t, model.ExportCompleted, export.Status)
+		assert.WithinDuration(t, time.Now().Add(ttl), export.ExpiresAt, time.Minute)
+
+		zr, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+		assert.NoError(t, err)
+		names := make([]string, 0, len(zr.File))
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"profile.json", "files.json", "files/file-1-contract.pdf"}, names)
+
+		content, err := zr.File[2].Open()
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(content)
+		assert.Equal(t, "contract content", string(data))
+		mockExportRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+	})
+
+	t.Run("Storage error fails the export", func(t *testing.T) {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-1", UserID: userID, Name: "contract.pdf", Size: 16})
+		export := &model.Export{ID: "export-1", UserID: userID, Status: model.ExportPending}
+
+		mockExportRepo := new(mocks.ExportRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewExportUserApplicationService(nil, mockExportRepo, mockFileRepo, mockArchiveRepo, ttl)
+
+		storageErr := errors.New("disk error")
+		mockArchiveRepo.On("Create", "export-1.zip").Return(&bufferWriteCloser{}, nil).Once()
+		mockFileRepo.On("Get", userID, "contract.pdf").Return(nil, storageErr).Once()
+		mockArchiveRepo.On("Delete", "export-1.zip").Return(nil).Once()
+		mockExportRepo.On("Update", export).Return(nil).Once()
+
+		service.build(user, export)
+
+		assert.Equal(t, model.ExportFailed, export.Status)
+		assert.Equal(t, storageErr.Error(), export.Error)
+		mockArchiveRepo.AssertExpectations(t)
+		mockExportRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/event"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func NewFileScanner(
+	scanner domain.Scanner,
+	quarantine domain.FileRepository,
+	publisher domain.EventPublisher,
+	async bool,
+) *FileScanner {
+	return &FileScanner{scanner, quarantine, publisher, async}
+}
+
+// FileScanner scans the files added by the users for malware. Infected content is moved to the quarantine storage
+// and a FileQuarantined event is published. In async mode the files are added pending a scan, which
+// ScanPendingFilesApplicationService does later. Nothing is scanned without a scanner.
+type FileScanner struct {
+	scanner    domain.Scanner
+	quarantine domain.FileRepository
+	publisher  domain.EventPublisher
+	async      bool
+}
+
+// Check scans the content of a file about to be added and returns its scan status. Infected content is
+// quarantined, open is called again to read it.
+func (s *FileScanner) Check(userID, filename string, size int64, open func() (io.ReadCloser, error)) (string, error) {
+	if s.scanner == nil {
+		return "", nil
+	}
+	if s.async {
+		return model.ScanPending, nil
+	}
+
+	threat, err := s.scan(open)
+	if err != nil {
+		return "", err
+	}
+	if threat == "" {
+		return model.ScanClean, nil
+	}
+
+	s.quarantineContent(&model.Quarantine{UserID: userID, Filename: filename, Size: size, Threat: threat}, open)
+	return "", fmt.Errorf("%w: %s", model.ErrFileInfected, threat)
+}
+
+func (s *FileScanner) scan(open func() (io.ReadCloser, error)) (string, error) {
+	content, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+	return s.scanner.Scan(content)
+}
+
+// quarantineContent moves the content to the quarantine storage and publishes the event. Failures are logged,
+// the content is rejected either way and the event tells it has no location.
+func (s *FileScanner) quarantineContent(quarantine *model.Quarantine, open func() (io.ReadCloser, error)) {
+	quarantine.ID = uuid.NewString()
+	quarantine.At = time.Now()
+	log.Printf("quarantining file %s of user %s: %s", quarantine.Filename, quarantine.UserID, quarantine.Threat)
+
+	if location, err := s.save(quarantine, open); err != nil {
+		log.Printf("error quarantining file %s of user %s: %s", quarantine.Filename, quarantine.UserID, err)
+	} else {
+		quarantine.Location = location
+	}
+
+	if err := s.publisher.Publish(event.NewFileQuarantinedEvent(quarantine)); err != nil {
+		log.Printf("error publishing event: %s", err)
+	}
+}
+
+// save stores the content under the ID of the quarantine, infected files can share a name
+func (s *FileScanner) save(quarantine *model.Quarantine, open func() (io.ReadCloser, error)) (string, error) {
+	content, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+	return s.quarantine.Save(quarantine.UserID, quarantine.ID, content)
+}
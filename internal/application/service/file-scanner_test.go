@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// noFileScanner scans nothing, as when no scanner is configured
+var noFileScanner = NewFileScanner(nil, nil, nil, false)
+
+func TestFileScanner_Check(t *testing.T) {
+	userID := "user-123"
+	open := func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("content")), nil
+	}
+
+	t.Run("Clean", func(t *testing.T) {
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		scanner := NewFileScanner(mockScanner, mockQuarantine, mockPublisher, false)
+
+		mockScanner.On("Scan", mock.Anything).Return("", nil).Once()
+
+		status, err := scanner.Check(userID, "hello.txt", 7, open)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ScanClean, status)
+		mockQuarantine.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Infected", func(t *testing.T) {
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		scanner := NewFileScanner(mockScanner, mockQuarantine, mockPublisher, false)
+
+		mockScanner.On("Scan", mock.Anything).Return("Eicar-Test-Signature", nil).Once()
+		mockQuarantine.On("Save", userID, mock.AnythingOfType("string"), mock.Anything).Return("/quarantine/q-123", nil).Once()
+		mockPublisher.On("Publish", mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == domain.FileQuarantinedEvent && e.UserID == userID && e.Quarantine.Filename == "eicar.txt" &&
+				e.Quarantine.Threat == "Eicar-Test-Signature" && e.Quarantine.Location == "/quarantine/q-123"
+		})).Return(nil).Once()
+
+		_, err := scanner.Check(userID, "eicar.txt", 68, open)
+
+		assert.ErrorIs(t, err, model.ErrFileInfected)
+		assert.Contains(t, err.Error(), "Eicar-Test-Signature")
+		mockQuarantine.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("Quarantine Failed", func(t *testing.T) {
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		scanner := NewFileScanner(mockScanner, mockQuarantine, mockPublisher, false)
+
+		mockScanner.On("Scan", mock.Anything).Return("Eicar-Test-Signature", nil).Once()
+		mockQuarantine.On("Save", userID, mock.Anything, mock.Anything).Return("", errors.New("disk full")).Once()
+		mockPublisher.On("Publish", mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Quarantine.Location == ""
+		})).Return(nil).Once()
+
+		_, err := scanner.Check(userID, "eicar.txt", 68, open)
+
+		assert.ErrorIs(t, err, model.ErrFileInfected)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("Scan Failed", func(t *testing.T) {
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		scanner := NewFileScanner(mockScanner, mockQuarantine, mockPublisher, false)
+
+		mockScanner.On("Scan", mock.Anything).Return("", domain.ErrScanFailed).Once()
+
+		_, err := scanner.Check(userID, "hello.txt", 7, open)
+
+		assert.ErrorIs(t, err, domain.ErrScanFailed)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		mockScanner := new(mocks.Scanner)
+		scanner := NewFileScanner(mockScanner, new(mocks.FileRepository), new(mocks.EventPublisher), true)
+
+		status, err := scanner.Check(userID, "hello.txt", 7, open)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ScanPending, status)
+		mockScanner.AssertNotCalled(t, "Scan", mock.Anything)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		status, err := noFileScanner.Check(userID, "hello.txt", 7, open)
+
+		assert.NoError(t, err)
+		assert.Empty(t, status)
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func NewFileVersions(repository domain.UserRepository, storage domain.FileRepository, retention int) *FileVersions {
+	return &FileVersions{repository, storage, retention}
+}
+
+// FileVersions keeps the prior content of the files whose content is replaced, by a new upload of the same name
+// or by restoring a version. Content is never overwritten: new content is stored under a new key and the
+// replaced one stays where it is, as a version. At most retention versions are kept per file, the oldest ones
+// are deleted first; no versions are kept with a retention of 0.
+type FileVersions struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+	retention  int
+}
+
+// archive records the current content of the file as a new version, before the file is switched to new content
+func (v *FileVersions) archive(file *model.File) *model.FileVersion {
+	return file.Snapshot(uuid.NewString(), time.Now())
+}
+
+// replace records the new content of the file and its archived version, then deletes the versions beyond the
+// retention. The restored version is the one whose content the file gets, nil for new content. The caller
+// deletes the new content if it fails.
+func (v *FileVersions) replace(file *model.File, version, restored *model.FileVersion, quota *model.StorageQuota) error {
+	if v.retention <= 0 {
+		if err := v.repository.ReplaceFile(file, nil, restored, quota); err != nil {
+			return err
+		}
+		if err := v.storage.Delete(file.UserID, version.StorageName()); err != nil {
+			log.Printf("error deleting content of version %s: %s", version.ID, err)
+		}
+		return nil
+	}
+
+	if err := v.repository.ReplaceFile(file, version, restored, quota); err != nil {
+		return err
+	}
+	v.prune(file)
+	return nil
+}
+
+// prune deletes the oldest versions of the file beyond the retention. Failures are logged, the file was
+// replaced already and the versions are pruned again on the next replacement.
+func (v *FileVersions) prune(file *model.File) {
+	versions, err := v.repository.GetFileVersions(file.UserID, file.ID)
+	if err != nil {
+		log.Printf("error listing versions of file %s: %s", file.ID, err)
+		return
+	}
+	if len(versions) <= v.retention {
+		return
+	}
+	for _, version := range versions[v.retention:] {
+		if err := v.repository.DeleteFileVersion(file.UserID, version.ID); err != nil {
+			log.Printf("error deleting version %s: %s", version.ID, err)
+			continue
+		}
+		if err := v.storage.Delete(file.UserID, version.StorageName()); err != nil {
+			log.Printf("error deleting content of version %s: %s", version.ID, err)
+		}
+	}
+}
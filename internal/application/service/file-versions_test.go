@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// noFileVersions keeps no versions, for the services that don't replace files in the tests
+var noFileVersions = NewFileVersions(nil, nil, 0)
+
+func TestFileVersions_Replace(t *testing.T) {
+	userID := "user-123"
+	quota := &model.StorageQuota{Bytes: 4096}
+	newFile := func() *model.File {
+		return &model.File{ID: "file-123", UserID: userID, Name: "contract.pdf", Size: 5, Version: 3}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		versions := NewFileVersions(mockUserRepo, mockFileRepo, 2)
+
+		file := newFile()
+		version := versions.archive(file)
+		// the content stays where it is, nothing is copied
+		assert.Equal(t, "contract.pdf", version.StorageName())
+		assert.Equal(t, 3, version.Version)
+		assert.Equal(t, int64(5), version.Size)
+
+		file.Replace(&model.File{StorageKey: "new-key", Size: 7})
+		mockUserRepo.On("ReplaceFile", file, version, (*model.FileVersion)(nil), quota).Return(nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-123").Return([]*model.FileVersion{
+			version, {ID: "version-2", Version: 2}, {ID: "version-1", Version: 1},
+		}, nil).Once()
+		mockUserRepo.On("DeleteFileVersion", userID, "version-1").Return(nil).Once()
+		mockFileRepo.On("Delete", userID, ".version-version-1").Return(nil).Once()
+
+		err := versions.replace(file, version, nil, quota)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, file.Version)
+		assert.Equal(t, "new-key", file.StorageName())
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockFileRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("No Retention", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		versions := NewFileVersions(mockUserRepo, mockFileRepo, 0)
+
+		file := newFile()
+		version := file.Snapshot("version-3", time.Now())
+		file.Replace(&model.File{Size: 7})
+		mockUserRepo.On("ReplaceFile", file, (*model.FileVersion)(nil), (*model.FileVersion)(nil), quota).Return(nil).Once()
+		// the replaced content is deleted
+		mockFileRepo.On("Delete", userID, "contract.pdf").Return(nil).Once()
+
+		err := versions.replace(file, version, nil, quota)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "GetFileVersions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Replace Fails", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		versions := NewFileVersions(mockUserRepo, new(mocks.FileRepository), 2)
+
+		file := newFile()
+		version := &model.FileVersion{ID: "version-3"}
+		mockUserRepo.On("ReplaceFile", file, version, (*model.FileVersion)(nil), quota).Return(model.ErrStorageQuotaExceeded).Once()
+
+		err := versions.replace(file, version, nil, quota)
+
+		assert.ErrorIs(t, err, model.ErrStorageQuotaExceeded)
+		mockUserRepo.AssertNotCalled(t, "GetFileVersions", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewGetAddressApplicationService(repository domain.UserRepository) *GetAddressApplicationService {
+	return &GetAddressApplicationService{repository}
+}
+
+type GetAddressApplicationService struct {
+	repository domain.UserRepository
+}
+
+func (s *GetAddressApplicationService) Do(req *v1.AddressRequest) (*v1.GetAddressResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.GetAddressResponse{}, err
+	}
+
+	address, err := user.GetAddress(req.AddressID)
+	if err != nil {
+		return &v1.GetAddressResponse{}, err
+	}
+
+	return &v1.GetAddressResponse{Address: address.ToDTO()}, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAddressApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+	user.ID = userID
+	user.AddAddress(&model.Address{ID: "address-1", Type: model.AddressHome, Lines: []string{"Street 1"}, Locality: "Berlin", PostalCode: "10117", Country: "DE"})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewGetAddressApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		res, err := service.Do(&v1.AddressRequest{UserID: userID, AddressID: "address-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Berlin", res.Address.Locality)
+	})
+
+	t.Run("Address Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewGetAddressApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		res, err := service.Do(&v1.AddressRequest{UserID: userID, AddressID: "missing"})
+
+		assert.ErrorIs(t, err, model.ErrAddressNotFound)
+		assert.Equal(t, &v1.GetAddressResponse{}, res)
+	})
+}
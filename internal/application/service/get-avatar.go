@@ -0,0 +1,41 @@
+package service
+
+import (
+	"io"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewGetAvatarApplicationService(repository domain.UserRepository, avatars domain.FileRepository) *GetAvatarApplicationService {
+	return &GetAvatarApplicationService{repository, avatars}
+}
+
+type GetAvatarApplicationService struct {
+	repository domain.UserRepository
+	avatars    domain.FileRepository
+}
+
+// Do opens the thumbnail of the requested size and tells its content type
+func (s *GetAvatarApplicationService) Do(req *v1.GetAvatarRequest) (io.ReadCloser, string, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	avatar, err := user.GetAvatar()
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename, err := avatar.Filename(req.Size)
+	if err != nil {
+		return nil, "", err
+	}
+
+	thumbnail, err := s.avatars.Get(user.ID, filename)
+	if err != nil {
+		return nil, "", err
+	}
+	return thumbnail, avatar.ContentType, nil
+}
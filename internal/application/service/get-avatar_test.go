@@ -0,0 +1,65 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAvatarApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.SetAvatar(&model.Avatar{ID: "avatar-1", ContentType: "image/jpeg"})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		service := NewGetAvatarApplicationService(mockUserRepo, mockAvatarRepo)
+
+		thumbnail, err := os.CreateTemp(t.TempDir(), "avatar")
+		assert.NoError(t, err)
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockAvatarRepo.On("Get", userID, "avatar-avatar-1-128.jpg").Return(thumbnail, nil).Once()
+
+		reader, contentType, err := service.Do(&v1.GetAvatarRequest{UserID: userID, Size: 128})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "image/jpeg", contentType)
+		assert.NoError(t, reader.Close())
+		mockAvatarRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Size", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		service := NewGetAvatarApplicationService(mockUserRepo, mockAvatarRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, _, err := service.Do(&v1.GetAvatarRequest{UserID: userID, Size: 100})
+
+		assert.ErrorIs(t, err, model.ErrInvalidAvatarSize)
+		mockAvatarRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("No Avatar", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewGetAvatarApplicationService(mockUserRepo, nil)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, _, err := service.Do(&v1.GetAvatarRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, model.ErrAvatarNotFound)
+	})
+}
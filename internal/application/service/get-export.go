@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewGetExportApplicationService(exports domain.ExportRepository) *GetExportApplicationService {
+	return &GetExportApplicationService{exports}
+}
+
+type GetExportApplicationService struct {
+	exports domain.ExportRepository
+}
+
+func (s *GetExportApplicationService) Do(req *v1.GetExportRequest) (*v1.GetExportResponse, error) {
+	export, err := s.exports.Get(req.UserID, req.ExportID)
+	if err != nil {
+		return &v1.GetExportResponse{}, err
+	}
+
+	return &v1.GetExportResponse{Export: export.ToDTO()}, nil
+}
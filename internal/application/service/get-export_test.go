@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExportApplicationService_Do(t *testing.T) {
+	req := &v1.GetExportRequest{UserID: "user-123", ExportID: "export-1"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		service := NewGetExportApplicationService(mockExportRepo)
+
+		export := &model.Export{ID: "export-1", UserID: "user-123", Status: model.ExportPending}
+		mockExportRepo.On("Get", "user-123", "export-1").Return(export, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, export.ToDTO(), res.Export)
+		mockExportRepo.AssertExpectations(t)
+	})
+
+	t.Run("Export Not Found", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		service := NewGetExportApplicationService(mockExportRepo)
+
+		mockExportRepo.On("Get", "user-123", "export-1").Return(nil, domain.ErrExportNotFound).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrExportNotFound)
+		assert.Equal(t, &v1.GetExportResponse{}, res)
+		mockExportRepo.AssertExpectations(t)
+	})
+}
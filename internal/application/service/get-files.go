@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
 )
 
@@ -13,17 +14,39 @@ type GetFilesApplicationService struct {
 	repository domain.UserRepository
 }
 
-func (s *GetFilesApplicationService) Do(userID string) (*v1.GetFilesResponse, error) {
-	user, err := s.repository.Get(userID)
+// Do lists the files of the user, filtered on the tags and labels of the request. A folder path narrows the
+// list to the files directly in the folder, the tree view has the files of the folder and its subfolders.
+func (s *GetFilesApplicationService) Do(req *v1.GetFilesRequest) (*v1.GetFilesResponse, error) {
+	user, err := s.repository.Get(req.UserID)
 	if err != nil {
 		return &v1.GetFilesResponse{}, err
 	}
 
-	files := make([]*v1.File, 0, len(user.GetFiles()))
-	for _, file := range user.GetFiles() {
-		files = append(files, file.ToDTO())
+	matching := user.FilterFiles(&model.FileFilter{Tags: req.Tags, Labels: req.Labels})
+	var tree *model.FolderTree
+	folderID := ""
+	if req.Folder != "" || req.Tree {
+		folders, err := s.repository.GetFolders(user.ID)
+		if err != nil {
+			return &v1.GetFilesResponse{}, err
+		}
+		tree = model.NewFolderTree(folders)
+		if folderID, err = tree.Resolve(req.Folder); err != nil {
+			return &v1.GetFilesResponse{}, err
+		}
 	}
 
-	return &v1.GetFilesResponse{Files: files}, nil
+	files := make([]*v1.File, 0, len(matching))
+	for _, file := range matching {
+		if req.Folder != "" && tree.FolderOf(file) != folderID {
+			continue
+		}
+		files = append(files, file.ToDTO())
+	}
 
+	res := &v1.GetFilesResponse{Files: files}
+	if req.Tree {
+		res.Tree = tree.Node(folderID, matching)
+	}
+	return res, nil
 }
@@ -27,7 +27,7 @@ func TestGetFilesApplicationService_Do(t *testing.T) {
 
 		mockUserRepo.On("Get", userID).Return(user, nil).Once()
 
-		res, err := service.Do(userID)
+		res, err := service.Do(&v1.GetFilesRequest{UserID: userID})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -37,6 +37,87 @@ func TestGetFilesApplicationService_Do(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 
+	t.Run("Filtered by tag and label", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewGetFilesApplicationService(mockUserRepo)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		file1 := &model.File{ID: "file-1", Name: "photo.jpg", Tags: []string{"holiday"}, Labels: map[string]string{"year": "2024"}}
+		file2 := &model.File{ID: "file-2", Name: "resume.pdf", Tags: []string{"work"}, Labels: map[string]string{"year": "2024"}}
+		file3 := &model.File{ID: "file-3", Name: "beach.jpg", Tags: []string{"holiday"}, Labels: map[string]string{"year": "2023"}}
+		user.AddFile(file1)
+		user.AddFile(file2)
+		user.AddFile(file3)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		res, err := service.Do(&v1.GetFilesRequest{UserID: userID, Tags: []string{"Holiday"}, Labels: map[string]string{"year": "2024"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*v1.File{file1.ToDTO()}, res.Files)
+	})
+
+	t.Run("In folder", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewGetFilesApplicationService(mockUserRepo)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		file1 := &model.File{ID: "file-1", Name: "report.pdf", FolderID: "2024"}
+		file2 := &model.File{ID: "file-2", Name: "notes.txt", FolderID: "documents"}
+		user.AddFile(file1)
+		user.AddFile(file2)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		res, err := service.Do(&v1.GetFilesRequest{UserID: userID, Folder: "/Documents"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*v1.File{file2.ToDTO()}, res.Files)
+		assert.Nil(t, res.Tree)
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewGetFilesApplicationService(mockUserRepo)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		file1 := &model.File{ID: "file-1", Name: "report.pdf", FolderID: "2024"}
+		file2 := &model.File{ID: "file-2", Name: "notes.txt"}
+		user.AddFile(file1)
+		user.AddFile(file2)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		res, err := service.Do(&v1.GetFilesRequest{UserID: userID, Tree: true})
+
+		assert.NoError(t, err)
+		assert.Len(t, res.Files, 2)
+		assert.Equal(t, "/", res.Tree.Path)
+		assert.Equal(t, []*v1.File{file2.ToDTO()}, res.Tree.Files)
+		assert.Len(t, res.Tree.Folders, 2)
+		assert.Equal(t, []*v1.File{file1.ToDTO()}, res.Tree.Folders[0].Folders[0].Files)
+	})
+
+	t.Run("Folder not found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewGetFilesApplicationService(mockUserRepo)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		_, err := service.Do(&v1.GetFilesRequest{UserID: userID, Folder: "/documents/2023"})
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+	})
+
 	t.Run("Success with no files", func(t *testing.T) {
 		mockUserRepo := new(mocks.UserRepository)
 		service := NewGetFilesApplicationService(mockUserRepo)
@@ -47,7 +128,7 @@ func TestGetFilesApplicationService_Do(t *testing.T) {
 
 		mockUserRepo.On("Get", userID).Return(user, nil).Once()
 
-		res, err := service.Do(userID)
+		res, err := service.Do(&v1.GetFilesRequest{UserID: userID})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -61,7 +142,7 @@ func TestGetFilesApplicationService_Do(t *testing.T) {
 
 		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
 
-		res, err := service.Do(userID)
+		res, err := service.Do(&v1.GetFilesRequest{UserID: userID})
 
 		assert.ErrorIs(t, err, domain.ErrUserNotFound)
 		assert.Equal(t, &v1.GetFilesResponse{}, res)
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewGetGroupApplicationService(groups domain.GroupRepository) *GetGroupApplicationService {
+	return &GetGroupApplicationService{groups}
+}
+
+type GetGroupApplicationService struct {
+	groups domain.GroupRepository
+}
+
+func (s *GetGroupApplicationService) Do(req *v1.GetGroupRequest) (*v1.GetGroupResponse, error) {
+	group, err := s.groups.Get(req.ID)
+	if err != nil {
+		return &v1.GetGroupResponse{}, err
+	}
+
+	return &v1.GetGroupResponse{Group: group.ToDTO()}, nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGroupApplicationService_Do(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		service := NewGetGroupApplicationService(mockGroupRepo)
+
+		group, _ := model.NewGroup("Editors", "Content editors", []string{"editor"})
+		group.ID = "group-123"
+		mockGroupRepo.On("Get", "group-123").Return(group, nil).Once()
+
+		res, err := service.Do(&v1.GetGroupRequest{ID: "group-123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, group.ToDTO(), res.Group)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		service := NewGetGroupApplicationService(mockGroupRepo)
+
+		mockGroupRepo.On("Get", "missing").Return(nil, domain.ErrGroupNotFound).Once()
+
+		res, err := service.Do(&v1.GetGroupRequest{ID: "missing"})
+
+		assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+		assert.Equal(t, &v1.GetGroupResponse{}, res)
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewGetStorageUsageApplicationService(
+	repository domain.UserRepository,
+	quota *model.StorageQuota) *GetStorageUsageApplicationService {
+	return &GetStorageUsageApplicationService{repository, quota}
+}
+
+type GetStorageUsageApplicationService struct {
+	repository domain.UserRepository
+	quota      *model.StorageQuota
+}
+
+func (s *GetStorageUsageApplicationService) Do(req *v1.GetStorageUsageRequest) (*v1.GetStorageUsageResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.GetStorageUsageResponse{}, err
+	}
+
+	usage, err := s.repository.GetStorageUsage(user.ID)
+	if err != nil {
+		return &v1.GetStorageUsageResponse{}, err
+	}
+
+	return &v1.GetStorageUsageResponse{Usage: usage.ToDTO(s.quota)}, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStorageUsageApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	quota := &model.StorageQuota{Bytes: 1024}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewGetStorageUsageApplicationService(mockUserRepo, quota)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID, Bytes: 10, Files: 2}, nil).Once()
+
+		res, err := service.Do(&v1.GetStorageUsageRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &v1.StorageUsage{UserID: userID, UsedBytes: 10, FileCount: 2, QuotaBytes: 1024}, res.Usage)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewGetStorageUsageApplicationService(mockUserRepo, quota)
+
+		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(&v1.GetStorageUsageRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockUserRepo.AssertNotCalled(t, "GetStorageUsage", userID)
+	})
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewGetUploadApplicationService(uploads domain.UploadRepository) *GetUploadApplicationService {
+	return &GetUploadApplicationService{uploads}
+}
+
+// GetUploadApplicationService tells how many bytes of a resumable upload were received, so it can be resumed
+type GetUploadApplicationService struct {
+	uploads domain.UploadRepository
+}
+
+func (s *GetUploadApplicationService) Do(req *v1.GetUploadRequest) (*v1.GetUploadResponse, error) {
+	upload, err := s.uploads.Get(req.UserID, req.UploadID)
+	if err != nil {
+		return &v1.GetUploadResponse{}, err
+	}
+
+	if upload.IsExpired(time.Now()) {
+		return &v1.GetUploadResponse{}, domain.ErrUploadExpired
+	}
+
+	return &v1.GetUploadResponse{Upload: upload.ToDTO()}, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUploadApplicationService_Do(t *testing.T) {
+	req := &v1.GetUploadRequest{UserID: "user-123", UploadID: "upload-123"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		service := NewGetUploadApplicationService(mockUploadRepo)
+
+		upload := &model.Upload{ID: "upload-123", UserID: "user-123", Length: 100, Offset: 40, ExpiresAt: time.Now().Add(time.Hour)}
+		mockUploadRepo.On("Get", "user-123", "upload-123").Return(upload, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(40), res.Upload.Offset)
+		assert.Equal(t, int64(100), res.Upload.Length)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		service := NewGetUploadApplicationService(mockUploadRepo)
+
+		upload := &model.Upload{ID: "upload-123", UserID: "user-123", Length: 100, ExpiresAt: time.Now().Add(-time.Minute)}
+		mockUploadRepo.On("Get", "user-123", "upload-123").Return(upload, nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrUploadExpired)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		service := NewGetUploadApplicationService(mockUploadRepo)
+
+		mockUploadRepo.On("Get", "user-123", "upload-123").Return(nil, domain.ErrUploadNotFound).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+	})
+}
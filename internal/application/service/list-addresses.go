@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewListAddressesApplicationService(repository domain.UserRepository) *ListAddressesApplicationService {
+	return &ListAddressesApplicationService{repository}
+}
+
+type ListAddressesApplicationService struct {
+	repository domain.UserRepository
+}
+
+func (s *ListAddressesApplicationService) Do(req *v1.ListAddressesRequest) (*v1.ListAddressesResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.ListAddressesResponse{}, err
+	}
+
+	addresses := user.ToDTO().Addresses
+	return &v1.ListAddressesResponse{Addresses: addresses, Count: int32(len(addresses))}, nil
+}
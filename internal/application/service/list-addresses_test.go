@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAddressesApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListAddressesApplicationService(mockRepo)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddAddress(&model.Address{ID: "address-1", Type: model.AddressHome, Lines: []string{"Street 1"}, Locality: "Berlin", PostalCode: "10117", Country: "DE"})
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		res, err := service.Do(&v1.ListAddressesRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), res.Count)
+		assert.Equal(t, "address-1", res.Addresses[0].ID)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListAddressesApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		res, err := service.Do(&v1.ListAddressesRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Equal(t, &v1.ListAddressesResponse{}, res)
+	})
+}
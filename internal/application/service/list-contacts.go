@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewListContactPointsApplicationService(repository domain.UserRepository) *ListContactPointsApplicationService {
+	return &ListContactPointsApplicationService{repository}
+}
+
+type ListContactPointsApplicationService struct {
+	repository domain.UserRepository
+}
+
+func (s *ListContactPointsApplicationService) Do(req *v1.ListContactPointsRequest) (*v1.ListContactPointsResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.ListContactPointsResponse{}, err
+	}
+
+	contacts := user.ToDTO().Contacts
+	return &v1.ListContactPointsResponse{Contacts: contacts, Count: int32(len(contacts))}, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListContactPointsApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListContactPointsApplicationService(mockRepo)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "phone-1", Type: model.ContactPhone, Value: "+447911123456"})
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		res, err := service.Do(&v1.ListContactPointsRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), res.Count)
+		assert.Equal(t, "phone-1", res.Contacts[0].ID)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListContactPointsApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		res, err := service.Do(&v1.ListContactPointsRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Equal(t, &v1.ListContactPointsResponse{}, res)
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewListFileVersionsApplicationService(repository domain.UserRepository) *ListFileVersionsApplicationService {
+	return &ListFileVersionsApplicationService{repository}
+}
+
+// ListFileVersionsApplicationService lists the prior versions of a file of a user
+type ListFileVersionsApplicationService struct {
+	repository domain.UserRepository
+}
+
+func (s *ListFileVersionsApplicationService) Do(req *v1.ListFileVersionsRequest) (*v1.ListFileVersionsResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.ListFileVersionsResponse{}, err
+	}
+
+	file, err := user.GetFile(req.FileID)
+	if err != nil {
+		return &v1.ListFileVersionsResponse{}, err
+	}
+
+	versions, err := s.repository.GetFileVersions(user.ID, file.ID)
+	if err != nil {
+		return &v1.ListFileVersionsResponse{}, err
+	}
+
+	dtos := make([]*v1.FileVersion, 0, len(versions))
+	for _, version := range versions {
+		dtos = append(dtos, version.ToDTO())
+	}
+	return &v1.ListFileVersionsResponse{File: file.ToDTO(), Versions: dtos}, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListFileVersionsApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "contract.pdf", Version: 3})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewListFileVersionsApplicationService(mockUserRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-123").Return([]*model.FileVersion{
+			{ID: "version-2", FileID: "file-123", Version: 2, Size: 7},
+			{ID: "version-1", FileID: "file-123", Version: 1, Size: 5},
+		}, nil).Once()
+
+		res, err := service.Do(&v1.ListFileVersionsRequest{UserID: userID, FileID: "file-123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res.File.Version)
+		assert.Len(t, res.Versions, 2)
+		assert.Equal(t, "version-2", res.Versions[0].ID)
+		assert.Equal(t, int64(5), res.Versions[1].Size)
+	})
+
+	t.Run("File Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewListFileVersionsApplicationService(mockUserRepo)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.ListFileVersionsRequest{UserID: userID, FileID: "unknown"})
+
+		assert.ErrorIs(t, err, model.ErrFileNotFound)
+		mockUserRepo.AssertNotCalled(t, "GetFileVersions", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewListFoldersApplicationService(repository domain.UserRepository) *ListFoldersApplicationService {
+	return &ListFoldersApplicationService{repository}
+}
+
+type ListFoldersApplicationService struct {
+	repository domain.UserRepository
+}
+
+// Do lists the folders of the user with their paths, sorted by path
+func (s *ListFoldersApplicationService) Do(req *v1.ListFoldersRequest) (*v1.ListFoldersResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.ListFoldersResponse{}, err
+	}
+
+	folders, err := s.repository.GetFolders(user.ID)
+	if err != nil {
+		return &v1.ListFoldersResponse{}, err
+	}
+	tree := model.NewFolderTree(folders)
+
+	res := &v1.ListFoldersResponse{Folders: make([]*v1.Folder, 0, len(folders))}
+	for _, folder := range folders {
+		res.Folders = append(res.Folders, tree.ToDTO(folder))
+	}
+	slices.SortFunc(res.Folders, func(a, b *v1.Folder) int {
+		return strings.Compare(strings.ToLower(a.Path), strings.ToLower(b.Path))
+	})
+	return res, nil
+}
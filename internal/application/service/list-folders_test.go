@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListFoldersApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListFoldersApplicationService(mockRepo)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		res, err := service.Do(&v1.ListFoldersRequest{UserID: userID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*v1.Folder{
+			{ID: "documents", Name: "documents", Path: "/documents"},
+			{ID: "2024", ParentID: "documents", Name: "2024", Path: "/documents/2024"},
+			{ID: "photos", Name: "photos", Path: "/photos"},
+		}, res.Folders)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListFoldersApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		res, err := service.Do(&v1.ListFoldersRequest{UserID: userID})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Equal(t, &v1.ListFoldersResponse{}, res)
+	})
+}
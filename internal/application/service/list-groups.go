@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewListGroupsApplicationService(groups domain.GroupRepository) *ListGroupsApplicationService {
+	return &ListGroupsApplicationService{groups}
+}
+
+type ListGroupsApplicationService struct {
+	groups domain.GroupRepository
+}
+
+func (s *ListGroupsApplicationService) Do() (*v1.ListGroupsResponse, error) {
+	groups, err := s.groups.List()
+	if err != nil {
+		return &v1.ListGroupsResponse{}, err
+	}
+
+	groupDTOs := make([]*v1.Group, len(groups))
+	for i, group := range groups {
+		groupDTOs[i] = group.ToDTO()
+	}
+
+	return &v1.ListGroupsResponse{
+		Groups: groupDTOs,
+		Count:  int32(len(groupDTOs)),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListGroupsApplicationService_Do(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		service := NewListGroupsApplicationService(mockGroupRepo)
+
+		group, _ := model.NewGroup("Admins", "", []string{"admin"})
+		group.ID = "group-123"
+		mockGroupRepo.On("List").Return([]*model.Group{group}, nil).Once()
+
+		res, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), res.Count)
+		assert.Equal(t, &v1.Group{ID: "group-123", Name: "Admins", Roles: []string{"admin"}}, res.Groups[0])
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		service := NewListGroupsApplicationService(mockGroupRepo)
+
+		repoErr := errors.New("database connection lost")
+		mockGroupRepo.On("List").Return(nil, repoErr).Once()
+
+		res, err := service.Do()
+
+		assert.ErrorIs(t, err, repoErr)
+		assert.Equal(t, &v1.ListGroupsResponse{}, res)
+	})
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewListRolesApplicationService(roles domain.RoleRepository) *ListRolesApplicationService {
+	return &ListRolesApplicationService{roles}
+}
+
+type ListRolesApplicationService struct {
+	roles domain.RoleRepository
+}
+
+func (s *ListRolesApplicationService) Do() (*v1.ListRolesResponse, error) {
+	roles, err := s.roles.List()
+	if err != nil {
+		return &v1.ListRolesResponse{}, err
+	}
+
+	roleDTOs := make([]*v1.Role, len(roles))
+	for i, role := range roles {
+		roleDTOs[i] = role.ToDTO()
+	}
+
+	return &v1.ListRolesResponse{
+		Roles: roleDTOs,
+		Count: int32(len(roleDTOs)),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRolesApplicationService_Do(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewListRolesApplicationService(mockRoleRepo)
+
+		mockRoleRepo.On("List").Return([]*model.Role{{Name: "admin"}, {Name: "editor"}}, nil).Once()
+
+		res, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), res.Count)
+		assert.Equal(t, &v1.Role{Name: "editor"}, res.Roles[1])
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRoleRepo := new(mocks.RoleRepository)
+		service := NewListRolesApplicationService(mockRoleRepo)
+
+		repoErr := errors.New("database connection lost")
+		mockRoleRepo.On("List").Return(nil, repoErr).Once()
+
+		res, err := service.Do()
+
+		assert.ErrorIs(t, err, repoErr)
+		assert.Equal(t, &v1.ListRolesResponse{}, res)
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
 )
 
@@ -13,8 +14,18 @@ type ListUsersApplicationService struct {
 	repository domain.UserRepository
 }
 
-func (s *ListUsersApplicationService) Do() (*v1.ListUsersResponse, error) {
-	users, err := s.repository.List()
+func (s *ListUsersApplicationService) Do(req *v1.ListUsersRequest) (*v1.ListUsersResponse, error) {
+	for name := range req.Attributes {
+		if err := model.ValidateAttributeName(name); err != nil {
+			return &v1.ListUsersResponse{}, err
+		}
+	}
+
+	users, err := s.repository.List(&domain.UserFilter{
+		EmailVerified: req.EmailVerified,
+		GroupID:       req.GroupID,
+		Attributes:    req.Attributes,
+	})
 	if err != nil {
 		return &v1.ListUsersResponse{}, err
 	}
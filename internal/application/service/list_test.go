@@ -4,10 +4,12 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/bizio/abc-user-service/internal/domain"
 	"github.com/bizio/abc-user-service/internal/domain/model"
 	"github.com/bizio/abc-user-service/mocks"
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestListUsersApplicationService_Do(t *testing.T) {
@@ -23,9 +25,9 @@ func TestListUsersApplicationService_Do(t *testing.T) {
 
 		expectedUsers := []*model.User{user1, user2}
 
-		mockRepo.On("List").Return(expectedUsers, nil).Once()
+		mockRepo.On("List", &domain.UserFilter{}).Return(expectedUsers, nil).Once()
 
-		res, err := service.Do()
+		res, err := service.Do(&v1.ListUsersRequest{})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -42,9 +44,9 @@ func TestListUsersApplicationService_Do(t *testing.T) {
 
 		expectedUsers := []*model.User{} // Empty slice
 
-		mockRepo.On("List").Return(expectedUsers, nil).Once()
+		mockRepo.On("List", &domain.UserFilter{}).Return(expectedUsers, nil).Once()
 
-		res, err := service.Do()
+		res, err := service.Do(&v1.ListUsersRequest{})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
@@ -53,15 +55,58 @@ func TestListUsersApplicationService_Do(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Filter on email verification", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListUsersApplicationService(mockRepo)
+
+		verified := true
+		user, _ := model.NewUser("User One", "one@example.com", "1991-01-01")
+		user.VerifyEmail()
+
+		mockRepo.On("List", &domain.UserFilter{EmailVerified: &verified}).Return([]*model.User{user}, nil).Once()
+
+		res, err := service.Do(&v1.ListUsersRequest{EmailVerified: &verified})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), res.Count)
+		assert.True(t, res.Users[0].EmailVerified)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Filter on group and attributes", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListUsersApplicationService(mockRepo)
+
+		filter := &domain.UserFilter{GroupID: "group-123", Attributes: map[string]string{"department": "sales"}}
+		mockRepo.On("List", filter).Return([]*model.User{}, nil).Once()
+
+		res, err := service.Do(&v1.ListUsersRequest{GroupID: "group-123", Attributes: map[string]string{"department": "sales"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), res.Count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid attribute filter", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewListUsersApplicationService(mockRepo)
+
+		res, err := service.Do(&v1.ListUsersRequest{Attributes: map[string]string{"a')) OR 1=1 --": "x"}})
+
+		assert.ErrorIs(t, err, model.ErrInvalidAttributeName)
+		assert.Equal(t, &v1.ListUsersResponse{}, res)
+		mockRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
 	t.Run("Repository Error", func(t *testing.T) {
 		mockRepo := new(mocks.UserRepository)
 		service := NewListUsersApplicationService(mockRepo)
 
 		repoErr := errors.New("database connection lost")
 
-		mockRepo.On("List").Return(nil, repoErr).Once()
+		mockRepo.On("List", &domain.UserFilter{}).Return(nil, repoErr).Once()
 
-		res, err := service.Do()
+		res, err := service.Do(&v1.ListUsersRequest{})
 
 		assert.ErrorIs(t, err, repoErr)
 		assert.Equal(t, &v1.ListUsersResponse{}, res)
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewLoginApplicationService(
+	repository domain.UserRepository,
+	credentials domain.CredentialRepository,
+	hasher domain.PasswordHasher,
+	issuer domain.TokenIssuer,
+	maxAttempts int,
+	lockout time.Duration) *LoginApplicationService {
+	return &LoginApplicationService{
+		repository:  repository,
+		credentials: credentials,
+		hasher:      hasher,
+		issuer:      issuer,
+		maxAttempts: maxAttempts,
+		lockout:     lockout,
+	}
+}
+
+// LoginApplicationService checks a user's password and issues an access token.
+// Credentials are locked for a while after maxAttempts consecutive failures.
+type LoginApplicationService struct {
+	repository  domain.UserRepository
+	credentials domain.CredentialRepository
+	hasher      domain.PasswordHasher
+	issuer      domain.TokenIssuer
+	maxAttempts int
+	lockout     time.Duration
+
+	dummyHashOnce sync.Once
+	dummyHash     string
+}
+
+func (s *LoginApplicationService) Do(req *v1.LoginRequest) (*v1.LoginResponse, error) {
+	user, err := s.repository.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, s.rejectUnknown(req.Password)
+		}
+		return nil, err
+	}
+
+	credential, err := s.credentials.Get(user.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCredentialNotFound) {
+			return nil, s.rejectUnknown(req.Password)
+		}
+		return nil, err
+	}
+
+	// the password is verified even while the credential is locked, and a locked credential is refused like a
+	// wrong password, so that logins can't tell which accounts are locked
+	ok, err := s.hasher.Verify(req.Password, credential.Hash)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if credential.IsLocked(now) {
+		log.Printf("login for locked user %s", user.ID)
+		return nil, model.ErrInvalidCredentials
+	}
+	if !ok {
+		if err := s.credentials.RecordFailure(user.ID, now, s.maxAttempts, s.lockout); err != nil {
+			return nil, err
+		}
+		log.Printf("failed login for user %s", user.ID)
+		return nil, model.ErrInvalidCredentials
+	}
+
+	// the status is checked once the password is known to be right, not to disclose it
+	if !user.CanLogin() {
+		return nil, model.ErrAccountDisabled
+	}
+
+	if credential.FailedAttempts > 0 {
+		if err := s.credentials.RecordSuccess(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	token, expiresAt, err := s.issuer.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.LoginResponse{AccessToken: token, TokenType: "Bearer", ExpiresAt: expiresAt}, nil
+}
+
+// rejectUnknown spends the same time as a wrong password would, so that logins can't tell who has an account
+func (s *LoginApplicationService) rejectUnknown(password string) error {
+	s.dummyHashOnce.Do(func() {
+		hash, err := s.hasher.Hash("not-a-real-password")
+		if err != nil {
+			log.Printf("error computing dummy password hash: %s", err)
+		}
+		s.dummyHash = hash
+	})
+	if s.dummyHash != "" {
+		_, _ = s.hasher.Verify(password, s.dummyHash)
+	}
+	return model.ErrInvalidCredentials
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLoginApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	req := &v1.LoginRequest{Email: "test@example.com", Password: "correct horse battery staple"}
+
+	newUser := func(status model.UserStatus) *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.RestoreStatus(status, "")
+		return user
+	}
+
+	newService := func() (*LoginApplicationService, *mocks.UserRepository, *mocks.CredentialRepository, *mocks.PasswordHasher, *mocks.TokenIssuer) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockHasher := new(mocks.PasswordHasher)
+		mockIssuer := new(mocks.TokenIssuer)
+		service := NewLoginApplicationService(mockUserRepo, mockCredentialRepo, mockHasher, mockIssuer, 3, time.Minute)
+		return service, mockUserRepo, mockCredentialRepo, mockHasher, mockIssuer
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mockUserRepo, mockCredentialRepo, mockHasher, mockIssuer := newService()
+
+		user := newUser(model.UserActive)
+		expiresAt := time.Now().Add(time.Hour)
+		mockUserRepo.On("GetByEmail", req.Email).Return(user, nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(model.NewCredential(userID, "hash"), nil).Once()
+		mockHasher.On("Verify", req.Password, "hash").Return(true, nil).Once()
+		mockIssuer.On("Issue", user).Return("signed.jwt.token", expiresAt, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &v1.LoginResponse{AccessToken: "signed.jwt.token", TokenType: "Bearer", ExpiresAt: expiresAt}, res)
+		mockCredentialRepo.AssertNotCalled(t, "RecordSuccess", mock.Anything)
+	})
+
+	t.Run("Success Resets Failures", func(t *testing.T) {
+		service, mockUserRepo, mockCredentialRepo, mockHasher, mockIssuer := newService()
+
+		user := newUser(model.UserActive)
+		credential := model.NewCredential(userID, "hash")
+		credential.FailedAttempts = 2
+		mockUserRepo.On("GetByEmail", req.Email).Return(user, nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(credential, nil).Once()
+		mockHasher.On("Verify", req.Password, "hash").Return(true, nil).Once()
+		mockCredentialRepo.On("RecordSuccess", userID).Return(nil).Once()
+		mockIssuer.On("Issue", user).Return("signed.jwt.token", time.Now().Add(time.Hour), nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.NoError(t, err)
+		mockCredentialRepo.AssertExpectations(t)
+		// the password read before the verification is never written back
+		mockCredentialRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("Wrong Password Is Counted", func(t *testing.T) {
+		service, mockUserRepo, mockCredentialRepo, mockHasher, mockIssuer := newService()
+
+		mockUserRepo.On("GetByEmail", req.Email).Return(newUser(model.UserActive), nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(model.NewCredential(userID, "hash"), nil).Once()
+		mockHasher.On("Verify", req.Password, "hash").Return(false, nil).Once()
+		mockCredentialRepo.On("RecordFailure", userID, mock.AnythingOfType("time.Time"), 3, time.Minute).Return(nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+		assert.Nil(t, res)
+		mockCredentialRepo.AssertExpectations(t)
+		mockIssuer.AssertNotCalled(t, "Issue", mock.Anything)
+	})
+
+	t.Run("Locked Credential", func(t *testing.T) {
+		service, mockUserRepo, mockCredentialRepo, mockHasher, _ := newService()
+
+		credential := model.NewCredential(userID, "hash")
+		credential.LockedUntil = time.Now().Add(time.Minute)
+		mockUserRepo.On("GetByEmail", req.Email).Return(newUser(model.UserActive), nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(credential, nil).Once()
+		mockHasher.On("Verify", req.Password, "hash").Return(true, nil).Once()
+
+		_, err := service.Do(req)
+
+		// refused like a wrong password, even when the password is right
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+		mockHasher.AssertExpectations(t)
+		mockCredentialRepo.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockCredentialRepo.AssertNotCalled(t, "RecordSuccess", mock.Anything)
+	})
+
+	t.Run("Unknown User", func(t *testing.T) {
+		service, mockUserRepo, _, mockHasher, _ := newService()
+
+		mockUserRepo.On("GetByEmail", req.Email).Return(nil, domain.ErrUserNotFound).Once()
+		mockHasher.On("Hash", mock.Anything).Return("dummy-hash", nil).Once()
+		mockHasher.On("Verify", req.Password, "dummy-hash").Return(false, nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+		mockHasher.AssertExpectations(t)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		service, mockUserRepo, mockCredentialRepo, mockHasher, mockIssuer := newService()
+
+		mockUserRepo.On("GetByEmail", req.Email).Return(newUser(model.UserSuspended), nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(model.NewCredential(userID, "hash"), nil).Once()
+		mockHasher.On("Verify", req.Password, "hash").Return(true, nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrAccountDisabled)
+		mockIssuer.AssertNotCalled(t, "Issue", mock.Anything)
+	})
+}
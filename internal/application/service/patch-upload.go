@@ -0,0 +1,201 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/google/uuid"
+)
+
+func NewPatchUploadApplicationService(
+	repository domain.UserRepository,
+	uploads domain.UploadRepository,
+	partials domain.PartialUploadRepository,
+	storage domain.FileRepository,
+	policy *model.UploadPolicy,
+	quota *model.StorageQuota,
+	files *FileScanner,
+	versions *FileVersions,
+	locks *UploadLocks,
+	ttl time.Duration,
+) *PatchUploadApplicationService {
+	return &PatchUploadApplicationService{repository, uploads, partials, storage, policy, quota, files, versions, locks, ttl}
+}
+
+// PatchUploadApplicationService appends a chunk to a resumable upload. The file is stored and added to the user
+// only once the last chunk is received.
+type PatchUploadApplicationService struct {
+	repository domain.UserRepository
+	uploads    domain.UploadRepository
+	partials   domain.PartialUploadRepository
+	storage    domain.FileRepository
+	policy     *model.UploadPolicy
+	quota      *model.StorageQuota
+	files      *FileScanner
+	versions   *FileVersions
+	locks      *UploadLocks
+	ttl        time.Duration
+}
+
+func (s *PatchUploadApplicationService) Do(req *v1.PatchUploadRequest) (*v1.PatchUploadResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.PatchUploadResponse{}, err
+	}
+
+	if !user.CanModifyFiles() {
+		return &v1.PatchUploadResponse{}, model.ErrFilesReadOnly
+	}
+
+	upload, err := s.uploads.Get(user.ID, req.UploadID)
+	if err != nil {
+		return &v1.PatchUploadResponse{}, err
+	}
+
+	if !s.locks.TryLock(upload.ID) {
+		return &v1.PatchUploadResponse{}, domain.ErrUploadLocked
+	}
+	defer s.locks.Unlock(upload.ID)
+
+	if upload.IsExpired(time.Now()) {
+		return &v1.PatchUploadResponse{}, domain.ErrUploadExpired
+	}
+	if err := upload.CheckChunk(req.Offset, req.ContentLength); err != nil {
+		return &v1.PatchUploadResponse{}, err
+	}
+
+	// the bytes received before a dropped connection are kept, the client resumes from the new offset
+	received, appendErr := s.partials.Append(upload.ID, upload.Offset, io.LimitReader(req.Content, upload.Remaining()))
+	upload.Advance(received, time.Now().Add(s.ttl))
+	if err := s.uploads.Update(upload); err != nil {
+		return &v1.PatchUploadResponse{}, err
+	}
+	if appendErr != nil {
+		return &v1.PatchUploadResponse{}, appendErr
+	}
+
+	if !upload.IsComplete() {
+		return &v1.PatchUploadResponse{Upload: upload.ToDTO()}, nil
+	}
+
+	file, err := s.complete(user, upload)
+	if err != nil {
+		return &v1.PatchUploadResponse{}, err
+	}
+	return &v1.PatchUploadResponse{Upload: upload.ToDTO(), File: file.ToDTO()}, nil
+}
+
+// complete stores the content of the upload as a file of the user. Content that isn't allowed is discarded, as
+// resending it can't help; other failures keep the upload so an empty chunk can retry.
+func (s *PatchUploadApplicationService) complete(user *model.User, upload *model.Upload) (*model.File, error) {
+	content, err := s.partials.Open(upload.ID)
+	if err != nil {
+		return nil, err
+	}
+	contentType, err := checkContentType(s.policy, content, upload.DeclaredType, upload.Length)
+	content.Close()
+	if err != nil {
+		s.discard(upload)
+		return nil, err
+	}
+
+	scanStatus, err := s.files.Check(user.ID, upload.Filename, upload.Length, func() (io.ReadCloser, error) {
+		return s.partials.Open(upload.ID)
+	})
+	if err != nil {
+		// the scan is retried with an empty chunk if the scanner couldn't tell
+		if errors.Is(err, model.ErrFileInfected) {
+			s.discard(upload)
+		}
+		return nil, err
+	}
+
+	content, err = s.partials.Open(upload.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	// a file with the same name in the folder gets the content as its next version, the current one is kept
+	// where it is. The content is stored under a new generated key.
+	existing, err := user.GetFileByName(upload.FolderID, upload.Filename)
+	if err != nil {
+		existing = nil
+	}
+	// the folder may be deleted while the upload is in progress, retrying won't help
+	if existing == nil {
+		if err := checkFolder(s.repository, user.ID, upload.FolderID); err != nil {
+			if errors.Is(err, model.ErrFolderNotFound) {
+				s.discard(upload)
+			}
+			return nil, err
+		}
+	}
+	fileID := uuid.NewString()
+	storageName := fileID
+	undo := func(reason string) {
+		if err := s.storage.Delete(user.ID, storageName); err != nil {
+			log.Printf("error deleting file %s: %s", reason, err)
+		}
+	}
+
+	hash := sha256.New()
+	filepath, err := s.storage.Save(user.ID, storageName, io.TeeReader(content, hash))
+	if err != nil {
+		log.Printf("error storing upload %s: %s", upload.ID, err)
+		return nil, err
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if upload.Digest != "" && digest != upload.Digest {
+		undo("with mismatching digest")
+		s.discard(upload)
+		return nil, model.ErrDigestMismatch
+	}
+
+	file := &model.File{
+		ID:           fileID,
+		UserID:       user.ID,
+		Name:         upload.Filename,
+		StorageKey:   fileID,
+		Path:         filepath,
+		Size:         upload.Length,
+		ContentType:  contentType,
+		DeclaredType: upload.DeclaredType,
+		Digest:       digest,
+		ScanStatus:   scanStatus,
+		Version:      1,
+		FolderID:     upload.FolderID,
+	}
+	if existing != nil {
+		replaced := *existing
+		replaced.Replace(file)
+		err = s.versions.replace(&replaced, s.versions.archive(existing), nil, s.quota)
+		file = &replaced
+	} else {
+		err = s.repository.AddFile(file, s.quota)
+	}
+	if err != nil {
+		undo("that couldn't be added")
+		// the quota filled up while the upload was in progress, retrying won't help
+		if errors.Is(err, model.ErrStorageQuotaExceeded) || errors.Is(err, model.ErrFileTooLarge) {
+			s.discard(upload)
+		}
+		return nil, err
+	}
+
+	s.discard(upload)
+	return file, nil
+}
+
+func (s *PatchUploadApplicationService) discard(upload *model.Upload) {
+	if err := discardUpload(s.uploads, s.partials, upload.ID); err != nil {
+		log.Printf("error discarding upload %s: %s", upload.ID, err)
+	}
+}
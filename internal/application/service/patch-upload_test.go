@@ -0,0 +1,276 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPatchUploadApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	policy, _ := model.NewUploadPolicy(nil, []string{"text/html"}, 1024, nil)
+	quota := &model.StorageQuota{Bytes: 1024}
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		return user
+	}
+	newUpload := func(offset int64) *model.Upload {
+		return &model.Upload{ID: "upload-123", UserID: userID, Filename: "hello.txt", Length: 5, Offset: offset,
+			ExpiresAt: time.Now().Add(time.Hour)}
+	}
+	newRequest := func(offset int64, chunk string) *v1.PatchUploadRequest {
+		return &v1.PatchUploadRequest{UserID: userID, UploadID: "upload-123", Offset: offset,
+			ContentLength: int64(len(chunk)), Content: strings.NewReader(chunk)}
+	}
+	type repos struct {
+		users    *mocks.UserRepository
+		uploads  *mocks.UploadRepository
+		partials *mocks.PartialUploadRepository
+		storage  *mocks.FileRepository
+	}
+	newService := func() (*PatchUploadApplicationService, repos) {
+		r := repos{new(mocks.UserRepository), new(mocks.UploadRepository), new(mocks.PartialUploadRepository), new(mocks.FileRepository)}
+		return NewPatchUploadApplicationService(r.users, r.uploads, r.partials, r.storage, policy, quota, noFileScanner, noFileVersions, NewUploadLocks(), time.Hour), r
+	}
+	// appendChunk reads the chunk like the partial upload repository does
+	appendChunk := func(args mock.Arguments) {
+		_, _ = io.Copy(io.Discard, args.Get(2).(io.Reader))
+	}
+
+	t.Run("Chunk Received", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(0)
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+		r.partials.On("Append", "upload-123", int64(0), mock.Anything).Run(appendChunk).Return(int64(3), nil).Once()
+		r.uploads.On("Update", upload).Return(nil).Once()
+
+		res, err := service.Do(newRequest(0, "hel"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res.Upload.Offset)
+		assert.Nil(t, res.File)
+		r.users.AssertNotCalled(t, "AddFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Last Chunk Stores The File", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(3)
+		upload.Digest = helloDigest
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+		r.partials.On("Append", "upload-123", int64(3), mock.Anything).Run(appendChunk).Return(int64(2), nil).Once()
+		r.uploads.On("Update", upload).Return(nil).Once()
+		r.partials.On("Open", "upload-123").Return(func(string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("hello")), nil
+		}).Twice()
+		r.storage.On("Save", userID, mock.AnythingOfType("string"), mock.Anything).Run(appendChunk).Return("/files/hello.txt", nil).Once()
+		r.users.On("AddFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.UserID == userID && f.Digest == helloDigest
+		}), quota).Return(nil).Once()
+		r.partials.On("Delete", "upload-123").Return(nil).Once()
+		r.uploads.On("Delete", "upload-123").Return(nil).Once()
+
+		res, err := service.Do(newRequest(3, "lo"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hello.txt", res.File.Name)
+		assert.Equal(t, "text/plain", res.File.ContentType)
+		assert.Equal(t, int64(5), res.File.Size)
+		r.storage.AssertCalled(t, "Save", userID, res.File.ID, mock.Anything)
+		r.users.AssertExpectations(t)
+		r.uploads.AssertExpectations(t)
+		r.partials.AssertExpectations(t)
+	})
+
+	t.Run("Last Chunk Stores The File In Its Folder", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(3)
+		upload.FolderID = "2024"
+		// the file with the same name at the root isn't replaced
+		user := newUser()
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Version: 1})
+
+		r.users.On("Get", userID).Return(user, nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+		r.partials.On("Append", "upload-123", int64(3), mock.Anything).Run(appendChunk).Return(int64(2), nil).Once()
+		r.uploads.On("Update", upload).Return(nil).Once()
+		r.partials.On("Open", "upload-123").Return(func(string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("hello")), nil
+		}).Twice()
+		r.users.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		r.storage.On("Save", userID, mock.AnythingOfType("string"), mock.Anything).Run(appendChunk).Return("/files/hello.txt", nil).Once()
+		r.users.On("AddFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.ID != "file-123" && f.FolderID == "2024"
+		}), quota).Return(nil).Once()
+		r.partials.On("Delete", "upload-123").Return(nil).Once()
+		r.uploads.On("Delete", "upload-123").Return(nil).Once()
+
+		res, err := service.Do(newRequest(3, "lo"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024", res.File.FolderID)
+		r.users.AssertExpectations(t)
+		r.users.AssertNotCalled(t, "ReplaceFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Folder Deleted Meanwhile", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(3)
+		upload.FolderID = "deleted"
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+		r.partials.On("Append", "upload-123", int64(3), mock.Anything).Run(appendChunk).Return(int64(2), nil).Once()
+		r.uploads.On("Update", upload).Return(nil).Once()
+		r.partials.On("Open", "upload-123").Return(func(string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("hello")), nil
+		}).Twice()
+		r.users.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		r.partials.On("Delete", "upload-123").Return(nil).Once()
+		r.uploads.On("Delete", "upload-123").Return(nil).Once()
+
+		_, err := service.Do(newRequest(3, "lo"))
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+		r.storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+		r.partials.AssertExpectations(t)
+		r.uploads.AssertExpectations(t)
+	})
+
+	t.Run("Quota Filled Up Meanwhile", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(3)
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+		r.partials.On("Append", "upload-123", int64(3), mock.Anything).Run(appendChunk).Return(int64(2), nil).Once()
+		r.uploads.On("Update", upload).Return(nil).Once()
+		r.partials.On("Open", "upload-123").Return(func(string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("hello")), nil
+		}).Twice()
+		r.storage.On("Save", userID, mock.AnythingOfType("string"), mock.Anything).Run(appendChunk).Return("/files/hello.txt", nil).Once()
+		r.users.On("AddFile", mock.Anything, quota).Return(model.ErrStorageQuotaExceeded).Once()
+		r.storage.On("Delete", userID, mock.AnythingOfType("string")).Return(nil).Once()
+		r.partials.On("Delete", "upload-123").Return(nil).Once()
+		r.uploads.On("Delete", "upload-123").Return(nil).Once()
+
+		_, err := service.Do(newRequest(3, "lo"))
+
+		assert.ErrorIs(t, err, model.ErrStorageQuotaExceeded)
+		r.storage.AssertExpectations(t)
+		r.partials.AssertExpectations(t)
+		r.uploads.AssertExpectations(t)
+	})
+
+	t.Run("Offset Mismatch", func(t *testing.T) {
+		service, r := newService()
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(newUpload(3), nil).Once()
+
+		_, err := service.Do(newRequest(0, "hel"))
+
+		assert.ErrorIs(t, err, model.ErrUploadOffsetMismatch)
+		r.partials.AssertNotCalled(t, "Append", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Chunk Exceeds Length", func(t *testing.T) {
+		service, r := newService()
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(newUpload(3), nil).Once()
+
+		_, err := service.Do(newRequest(3, "lo!"))
+
+		assert.ErrorIs(t, err, model.ErrUploadExceedsLength)
+	})
+
+	t.Run("Interrupted Chunk Keeps The Bytes Received", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(0)
+		readErr := errors.New("connection reset")
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+		r.partials.On("Append", "upload-123", int64(0), mock.Anything).Return(int64(2), readErr).Once()
+		r.uploads.On("Update", mock.MatchedBy(func(u *model.Upload) bool { return u.Offset == 2 })).Return(nil).Once()
+
+		_, err := service.Do(newRequest(0, "hello"))
+
+		assert.ErrorIs(t, err, readErr)
+		r.uploads.AssertExpectations(t)
+	})
+
+	t.Run("Content Not Allowed", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(0)
+		upload.Length = 15
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+		r.partials.On("Append", "upload-123", int64(0), mock.Anything).Run(appendChunk).Return(int64(15), nil).Once()
+		r.uploads.On("Update", upload).Return(nil).Once()
+		r.partials.On("Open", "upload-123").Return(io.NopCloser(strings.NewReader("<html></html>\n")), nil).Once()
+		r.partials.On("Delete", "upload-123").Return(nil).Once()
+		r.uploads.On("Delete", "upload-123").Return(nil).Once()
+
+		_, err := service.Do(newRequest(0, "<html></html>\n "))
+
+		assert.ErrorIs(t, err, model.ErrFileTypeNotAllowed)
+		r.storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+		r.uploads.AssertExpectations(t)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		service, r := newService()
+		upload := newUpload(0)
+		upload.ExpiresAt = time.Now().Add(-time.Minute)
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(upload, nil).Once()
+
+		_, err := service.Do(newRequest(0, "hel"))
+
+		assert.ErrorIs(t, err, domain.ErrUploadExpired)
+	})
+
+	t.Run("Receiving Another Chunk", func(t *testing.T) {
+		r := repos{new(mocks.UserRepository), new(mocks.UploadRepository), new(mocks.PartialUploadRepository), new(mocks.FileRepository)}
+		locks := NewUploadLocks()
+		locks.TryLock("upload-123")
+		service := NewPatchUploadApplicationService(r.users, r.uploads, r.partials, r.storage, policy, quota, noFileScanner, noFileVersions, locks, time.Hour)
+
+		r.users.On("Get", userID).Return(newUser(), nil).Once()
+		r.uploads.On("Get", userID, "upload-123").Return(newUpload(0), nil).Once()
+
+		_, err := service.Do(newRequest(0, "hel"))
+
+		assert.ErrorIs(t, err, domain.ErrUploadLocked)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		service, r := newService()
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+
+		r.users.On("Get", userID).Return(user, nil).Once()
+
+		_, err := service.Do(newRequest(0, "hel"))
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+		r.uploads.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+}
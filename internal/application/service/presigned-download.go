@@ -0,0 +1,32 @@
+package service
+
+import (
+	"io"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewPresignedDownloadApplicationService(
+	signer domain.URLSigner,
+	downloads *DownloadFileApplicationService,
+) *PresignedDownloadApplicationService {
+	return &PresignedDownloadApplicationService{signer, downloads}
+}
+
+// PresignedDownloadApplicationService serves a file to the holder of a URL minted by
+// CreatePresignedURLApplicationService
+type PresignedDownloadApplicationService struct {
+	signer    domain.URLSigner
+	downloads *DownloadFileApplicationService
+}
+
+func (s *PresignedDownloadApplicationService) Do(req *v1.PresignedDownloadRequest) (*model.File, io.ReadCloser, error) {
+	if err := verifyPresignedURL(s.signer, model.PresignedDownload, &req.PresignedURLQuery, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	return s.downloads.Do(&v1.DownloadFileRequest{UserID: req.UserID, FileID: req.FileID})
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPresignedDownloadApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	expires := time.Now().Add(time.Minute).Unix()
+	newRequest := func(ip, clientIP string) *v1.PresignedDownloadRequest {
+		return &v1.PresignedDownloadRequest{PresignedURLQuery: v1.PresignedURLQuery{
+			UserID: userID, FileID: "file-123", Expires: expires, IP: ip, Signature: "c2lnbmF0dXJl", ClientIP: clientIP,
+		}}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewPresignedDownloadApplicationService(mockSigner, NewDownloadFileApplicationService(mockUserRepo, mockFileRepo))
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Digest: helloDigest})
+		payload := (&model.PresignedURL{
+			Action: "download", UserID: userID, FileID: "file-123", ExpiresAt: time.Unix(expires, 0), IP: "203.0.113.7",
+		}).Payload()
+		mockSigner.On("Verify", payload, "c2lnbmF0dXJl").Return(true).Once()
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "hello.txt").Return(newTestBlob(t, "hello"), nil).Once()
+
+		file, content, err := service.Do(newRequest("203.0.113.7", "203.0.113.7"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hello.txt", file.Name)
+		body, _ := io.ReadAll(content)
+		assert.Equal(t, "hello", string(body))
+		mockSigner.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Signature", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewPresignedDownloadApplicationService(mockSigner, NewDownloadFileApplicationService(mockUserRepo, mockFileRepo))
+
+		mockSigner.On("Verify", mock.Anything, "c2lnbmF0dXJl").Return(false).Once()
+
+		_, _, err := service.Do(newRequest("", "203.0.113.7"))
+
+		assert.ErrorIs(t, err, model.ErrInvalidSignature)
+		mockUserRepo.AssertNotCalled(t, "Get", mock.Anything)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewPresignedDownloadApplicationService(mockSigner, NewDownloadFileApplicationService(mockUserRepo, mockFileRepo))
+
+		mockSigner.On("Verify", mock.Anything, "c2lnbmF0dXJl").Return(true).Once()
+		req := newRequest("", "203.0.113.7")
+		req.Expires = time.Now().Add(-time.Second).Unix()
+
+		_, _, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrPresignedURLExpired)
+		mockUserRepo.AssertNotCalled(t, "Get", mock.Anything)
+	})
+
+	t.Run("IP Mismatch", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewPresignedDownloadApplicationService(mockSigner, NewDownloadFileApplicationService(mockUserRepo, mockFileRepo))
+
+		mockSigner.On("Verify", mock.Anything, "c2lnbmF0dXJl").Return(true).Once()
+
+		_, _, err := service.Do(newRequest("203.0.113.7", "198.51.100.1"))
+
+		assert.ErrorIs(t, err, model.ErrPresignedIPMismatch)
+		mockUserRepo.AssertNotCalled(t, "Get", mock.Anything)
+	})
+}
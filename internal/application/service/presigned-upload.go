@@ -0,0 +1,33 @@
+package service
+
+import (
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewPresignedUploadApplicationService(
+	signer domain.URLSigner,
+	uploads *AddFileApplicationService,
+) *PresignedUploadApplicationService {
+	return &PresignedUploadApplicationService{signer, uploads}
+}
+
+// PresignedUploadApplicationService adds a file for the holder of a URL minted by
+// CreatePresignedURLApplicationService, with the same checks as any other upload
+type PresignedUploadApplicationService struct {
+	signer  domain.URLSigner
+	uploads *AddFileApplicationService
+}
+
+func (s *PresignedUploadApplicationService) Do(req *v1.PresignedUploadRequest) (*v1.UploadFileResponse, error) {
+	if err := verifyPresignedURL(s.signer, model.PresignedUpload, &req.PresignedURLQuery, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.uploads.Do(&v1.UploadFileRequest{
+		UserID: req.UserID, File: req.File, Digest: req.Digest, FolderID: req.FolderID,
+	})
+}
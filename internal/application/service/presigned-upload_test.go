@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPresignedUploadApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	expires := time.Now().Add(time.Minute).Unix()
+	policy, _ := model.NewUploadPolicy(nil, nil, 1024, nil)
+	quota := &model.StorageQuota{Bytes: 4096, Files: 10}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewPresignedUploadApplicationService(mockSigner,
+			NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions))
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		fileHeader := newTestFileHeader(t, "hello.txt", []byte("hello"))
+		payload := (&model.PresignedURL{Action: "upload", UserID: userID, ExpiresAt: time.Unix(expires, 0)}).Payload()
+		mockSigner.On("Verify", payload, "c2lnbmF0dXJl").Return(true).Once()
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/hello.txt", helloDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.AnythingOfType("*model.File"), quota).Return(nil).Once()
+
+		res, err := service.Do(&v1.PresignedUploadRequest{
+			PresignedURLQuery: v1.PresignedURLQuery{UserID: userID, Expires: expires, Signature: "c2lnbmF0dXJl"},
+			File:              fileHeader,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hello.txt", res.File.Name)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+	})
+
+	t.Run("In Folder", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewPresignedUploadApplicationService(mockSigner,
+			NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions))
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		fileHeader := newTestFileHeader(t, "hello.txt", []byte("hello"))
+		mockSigner.On("Verify", mock.Anything, "c2lnbmF0dXJl").Return(true).Once()
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID}, nil).Once()
+		mockFileRepo.On("Upload", userID, mock.AnythingOfType("string"), fileHeader).Return("/uploads/hello.txt", helloDigest, nil).Once()
+		mockUserRepo.On("AddFile", mock.MatchedBy(func(f *model.File) bool { return f.FolderID == "photos" }), quota).Return(nil).Once()
+
+		res, err := service.Do(&v1.PresignedUploadRequest{
+			PresignedURLQuery: v1.PresignedURLQuery{UserID: userID, Expires: expires, Signature: "c2lnbmF0dXJl"},
+			File:              fileHeader,
+			FolderID:          "photos",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photos", res.File.FolderID)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Download URL", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		service := NewPresignedUploadApplicationService(mockSigner,
+			NewAddFileApplicationService(mockUserRepo, mockFileRepo, policy, quota, noFileScanner, noFileVersions))
+
+		// the signature of a download URL covers a payload with the download action
+		uploadPayload := (&model.PresignedURL{Action: "upload", UserID: userID, FileID: "file-123", ExpiresAt: time.Unix(expires, 0)}).Payload()
+		mockSigner.On("Verify", uploadPayload, "c2lnbmF0dXJl").Return(false).Once()
+
+		_, err := service.Do(&v1.PresignedUploadRequest{
+			PresignedURLQuery: v1.PresignedURLQuery{UserID: userID, FileID: "file-123", Expires: expires, Signature: "c2lnbmF0dXJl"},
+			File:              newTestFileHeader(t, "hello.txt", []byte("hello")),
+		})
+
+		assert.ErrorIs(t, err, model.ErrInvalidSignature)
+		mockUserRepo.AssertNotCalled(t, "Get", mock.Anything)
+		mockFileRepo.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+)
+
+func NewPurgeExportsApplicationService(exports domain.ExportRepository, archives domain.ArchiveRepository) *PurgeExportsApplicationService {
+	return &PurgeExportsApplicationService{exports, archives}
+}
+
+// PurgeExportsApplicationService deletes the archives of the expired exports with their records, so archives
+// that are never downloaded don't stay on disk
+type PurgeExportsApplicationService struct {
+	exports  domain.ExportRepository
+	archives domain.ArchiveRepository
+}
+
+// Do returns the number of exports purged. An export whose archive can't be deleted is kept for the next run.
+func (s *PurgeExportsApplicationService) Do() (int, error) {
+	expired, err := s.exports.ListExpired(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, export := range expired {
+		if err := s.archives.Delete(export.ArchiveName()); err != nil {
+			log.Printf("error deleting archive of expired export %s: %s", export.ID, err)
+			continue
+		}
+		if err := s.exports.Delete(export.UserID, export.ID); err != nil {
+			log.Printf("error deleting expired export %s: %s", export.ID, err)
+			continue
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		log.Printf("Purged %d expired exports", purged)
+	}
+	return purged, nil
+}
+
+// Run purges the expired exports every interval until the context is done
+func (s *PurgeExportsApplicationService) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "purging exports", func() error {
+		_, err := s.Do()
+		return err
+	})
+}
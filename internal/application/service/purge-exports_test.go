@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurgeExportsApplicationService_Do(t *testing.T) {
+	t.Run("Deletes Expired Exports", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		mockArchiveRepo := new(mocks.ArchiveRepository)
+		service := NewPurgeExportsApplicationService(mockExportRepo, mockArchiveRepo)
+
+		expired := []*model.Export{{ID: "export-1", UserID: "user-1"}, {ID: "export-2", UserID: "user-2"}}
+		mockExportRepo.On("ListExpired", mock.Anything).Return(expired, nil).Once()
+		mockArchiveRepo.On("Delete", "export-1.zip").Return(nil).Once()
+		mockExportRepo.On("Delete", "user-1", "export-1").Return(nil).Once()
+		mockArchiveRepo.On("Delete", "export-2.zip").Return(errors.New("permission denied")).Once()
+
+		purged, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		// kept for the next run, its archive is still there
+		mockExportRepo.AssertNotCalled(t, "Delete", "user-2", "export-2")
+		mockExportRepo.AssertExpectations(t)
+		mockArchiveRepo.AssertExpectations(t)
+	})
+
+	t.Run("List Fails", func(t *testing.T) {
+		mockExportRepo := new(mocks.ExportRepository)
+		service := NewPurgeExportsApplicationService(mockExportRepo, nil)
+		listErr := errors.New("db down")
+
+		mockExportRepo.On("ListExpired", mock.Anything).Return(nil, listErr).Once()
+
+		_, err := service.Do()
+
+		assert.ErrorIs(t, err, listErr)
+	})
+}
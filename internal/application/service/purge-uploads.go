@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+)
+
+func NewPurgeUploadsApplicationService(
+	uploads domain.UploadRepository,
+	partials domain.PartialUploadRepository,
+	locks *UploadLocks,
+) *PurgeUploadsApplicationService {
+	return &PurgeUploadsApplicationService{uploads, partials, locks}
+}
+
+// PurgeUploadsApplicationService discards the resumable uploads that weren't completed before they expired
+type PurgeUploadsApplicationService struct {
+	uploads  domain.UploadRepository
+	partials domain.PartialUploadRepository
+	locks    *UploadLocks
+}
+
+// Do returns the number of uploads discarded, the ones receiving a chunk are left for the next run
+func (s *PurgeUploadsApplicationService) Do() (int, error) {
+	expired, err := s.uploads.ListExpired(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, upload := range expired {
+		if !s.locks.TryLock(upload.ID) {
+			continue
+		}
+		err := discardUpload(s.uploads, s.partials, upload.ID)
+		s.locks.Unlock(upload.ID)
+		if err != nil {
+			log.Printf("error discarding expired upload %s: %s", upload.ID, err)
+			continue
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		log.Printf("Purged %d expired uploads", purged)
+	}
+	return purged, nil
+}
+
+// Run purges the expired uploads every interval until the context is done
+func (s *PurgeUploadsApplicationService) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "purging uploads", func() error {
+		_, err := s.Do()
+		return err
+	})
+}
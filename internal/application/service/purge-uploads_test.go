@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurgeUploadsApplicationService_Do(t *testing.T) {
+	t.Run("Discards Expired Uploads", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		locks := NewUploadLocks()
+		locks.TryLock("upload-busy")
+		service := NewPurgeUploadsApplicationService(mockUploadRepo, mockPartialRepo, locks)
+
+		expired := []*model.Upload{{ID: "upload-1"}, {ID: "upload-busy"}, {ID: "upload-2"}}
+		mockUploadRepo.On("ListExpired", mock.Anything).Return(expired, nil).Once()
+		mockPartialRepo.On("Delete", "upload-1").Return(nil).Once()
+		mockUploadRepo.On("Delete", "upload-1").Return(nil).Once()
+		mockPartialRepo.On("Delete", "upload-2").Return(errors.New("permission denied")).Once()
+
+		purged, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		mockPartialRepo.AssertNotCalled(t, "Delete", "upload-busy")
+		mockUploadRepo.AssertNotCalled(t, "Delete", "upload-2")
+		mockUploadRepo.AssertExpectations(t)
+	})
+
+	t.Run("List Fails", func(t *testing.T) {
+		mockUploadRepo := new(mocks.UploadRepository)
+		mockPartialRepo := new(mocks.PartialUploadRepository)
+		service := NewPurgeUploadsApplicationService(mockUploadRepo, mockPartialRepo, NewUploadLocks())
+		listErr := errors.New("db down")
+
+		mockUploadRepo.On("ListExpired", mock.Anything).Return(nil, listErr).Once()
+
+		_, err := service.Do()
+
+		assert.ErrorIs(t, err, listErr)
+	})
+}
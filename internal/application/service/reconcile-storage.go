@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"path"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+func NewReconcileStorageApplicationService(
+	repository domain.UserRepository,
+	storage domain.FileRepository,
+	policy *model.ReconcilePolicy,
+) *ReconcileStorageApplicationService {
+	return &ReconcileStorageApplicationService{repository, storage, policy}
+}
+
+// ReconcileStorageApplicationService compares the blobs in the file storage with the files and versions of the
+// users. Blobs nothing references are orphaned, e.g. left behind by a failed upload or a deleted user; files and
+// versions whose blob is gone are missing it. What is found is repaired according to the policy.
+type ReconcileStorageApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+	policy     *model.ReconcilePolicy
+}
+
+// Do reconciles the storage of every user, including the deleted ones whose blobs should be gone. A user whose
+// storage can't be listed is logged and skipped, nothing is repaired for it.
+func (s *ReconcileStorageApplicationService) Do() (*model.Reconciliation, error) {
+	users, err := s.repository.List(&domain.UserFilter{})
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := s.repository.ListDeletedIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := &model.Reconciliation{}
+	for _, user := range users {
+		if err := s.reconcile(reconciliation, user.ID, true); err != nil {
+			log.Printf("error reconciling storage of user %s: %s", user.ID, err)
+		}
+	}
+	for _, id := range deleted {
+		if err := s.reconcile(reconciliation, id, false); err != nil {
+			log.Printf("error reconciling storage of deleted user %s: %s", id, err)
+		}
+	}
+
+	log.Printf("Reconciled %d blobs of %d users: %d orphaned, %d missing, %d repaired", reconciliation.Blobs,
+		reconciliation.Users, len(reconciliation.Orphaned), len(reconciliation.Missing), reconciliation.Repaired())
+	return reconciliation, nil
+}
+
+// Run reconciles the storage every interval until the context is done
+func (s *ReconcileStorageApplicationService) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "reconciling storage", func() error {
+		_, err := s.Do()
+		return err
+	})
+}
+
+// reconcile compares the blobs of a user with the files and versions referencing them, a deleted user has none
+func (s *ReconcileStorageApplicationService) reconcile(reconciliation *model.Reconciliation, userID string, active bool) error {
+	blobs, err := s.storage.List(userID)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	stored := make(map[string]bool, len(blobs))
+	for _, location := range blobs {
+		stored[path.Base(location)] = true
+	}
+
+	var files []*model.File
+	var versions map[string][]*model.FileVersion
+	if active {
+		if files, versions, err = s.references(userID); err != nil {
+			return err
+		}
+	}
+	reconciliation.Users++
+	reconciliation.Blobs += len(blobs)
+
+	var orphaned []*model.OrphanedBlob
+	referenced := storageNames(files, versions)
+	for _, location := range blobs {
+		if !referenced[path.Base(location)] {
+			orphaned = append(orphaned, &model.OrphanedBlob{UserID: userID, Location: location})
+		}
+	}
+	var missing []*model.MissingBlob
+	for _, file := range files {
+		if !stored[file.StorageName()] {
+			missing = append(missing, &model.MissingBlob{UserID: userID, FileID: file.ID, Name: file.Name})
+		}
+		for _, version := range versions[file.ID] {
+			if !stored[version.StorageName()] {
+				missing = append(missing, &model.MissingBlob{
+					UserID: userID, FileID: file.ID, VersionID: version.ID, Name: file.Name,
+				})
+			}
+		}
+	}
+
+	if len(orphaned) > 0 && s.policy.OrphanedBlobs == model.ReconcileDelete {
+		s.deleteOrphaned(userID, active, orphaned)
+	}
+	if len(missing) > 0 && s.policy.MissingBlobs != model.ReconcileReport {
+		s.repairMissing(files, versions, missing)
+	}
+
+	for _, blob := range orphaned {
+		log.Printf("Found %s, repaired: %t", blob, blob.Repaired)
+	}
+	for _, blob := range missing {
+		log.Printf("Found %s, repaired: %t", blob, blob.Repaired)
+	}
+	reconciliation.Orphaned = append(reconciliation.Orphaned, orphaned...)
+	reconciliation.Missing = append(reconciliation.Missing, missing...)
+	return nil
+}
+
+// references returns the files of the user and their versions by file ID
+func (s *ReconcileStorageApplicationService) references(userID string) ([]*model.File, map[string][]*model.FileVersion, error) {
+	files, err := s.repository.GetFiles(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	versions := make(map[string][]*model.FileVersion, len(files))
+	for _, file := range files {
+		if versions[file.ID], err = s.repository.GetFileVersions(userID, file.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return files, versions, nil
+}
+
+// deleteOrphaned deletes the orphaned blobs. The references are read again first, so the blob of a file added
+// since by an upload that was in progress is kept, and the blobs younger than the minimum age are kept as they
+// can belong to an upload still in progress.
+func (s *ReconcileStorageApplicationService) deleteOrphaned(userID string, active bool, orphaned []*model.OrphanedBlob) {
+	var referenced map[string]bool
+	if active {
+		files, versions, err := s.references(userID)
+		if err != nil {
+			log.Printf("error reading files of user %s: %s", userID, err)
+			return
+		}
+		referenced = storageNames(files, versions)
+	}
+
+	for _, blob := range orphaned {
+		name := path.Base(blob.Location)
+		if referenced[name] {
+			continue
+		}
+		if s.policy.OrphanMinAge > 0 {
+			modified, err := s.storage.ModTime(userID, name)
+			if err != nil {
+				log.Printf("error reading modification time of %s: %s", blob, err)
+				continue
+			}
+			if time.Since(modified) < s.policy.OrphanMinAge {
+				continue
+			}
+		}
+		if err := s.storage.Delete(userID, name); err != nil {
+			log.Printf("error deleting %s: %s", blob, err)
+			continue
+		}
+		blob.Repaired = true
+	}
+}
+
+// repairMissing flags the files whose blob is missing as corrupted, or deletes them. Versions whose blob is
+// missing are deleted, they can't be flagged.
+func (s *ReconcileStorageApplicationService) repairMissing(
+	files []*model.File,
+	versions map[string][]*model.FileVersion,
+	missing []*model.MissingBlob,
+) {
+	byID := make(map[string]*model.File, len(files))
+	for _, file := range files {
+		byID[file.ID] = file
+	}
+
+	deleted := make(map[string]bool)
+	for _, blob := range missing {
+		if blob.VersionID != "" {
+			if deleted[blob.FileID] {
+				// deleted with its file
+				blob.Repaired = true
+			} else if s.policy.MissingBlobs == model.ReconcileDelete {
+				if err := s.repository.DeleteFileVersion(blob.UserID, blob.VersionID); err != nil {
+					log.Printf("error deleting version with %s: %s", blob, err)
+					continue
+				}
+				blob.Repaired = true
+			}
+			continue
+		}
+
+		file := byID[blob.FileID]
+		if s.policy.MissingBlobs == model.ReconcileFlag {
+			if !file.Corrupted {
+				file.Corrupted = true
+				if err := s.repository.UpdateFile(file); err != nil {
+					log.Printf("error flagging file with %s: %s", blob, err)
+					continue
+				}
+			}
+			blob.Repaired = true
+			continue
+		}
+
+		if err := s.repository.DeleteFile(blob.UserID, file.ID); err != nil {
+			log.Printf("error deleting file with %s: %s", blob, err)
+			continue
+		}
+		// the blobs of the versions are orphaned now
+		for _, version := range versions[file.ID] {
+			if err := s.storage.Delete(blob.UserID, version.StorageName()); err != nil {
+				log.Printf("error deleting content of version %s: %s", version.ID, err)
+			}
+		}
+		deleted[file.ID] = true
+		blob.Repaired = true
+	}
+}
+
+// storageNames are the names of the blobs the files and their versions are stored under
+func storageNames(files []*model.File, versions map[string][]*model.FileVersion) map[string]bool {
+	names := make(map[string]bool, len(files))
+	for _, file := range files {
+		names[file.StorageName()] = true
+		for _, version := range versions[file.ID] {
+			names[version.StorageName()] = true
+		}
+	}
+	return names
+}
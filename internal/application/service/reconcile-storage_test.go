@@ -0,0 +1,208 @@
+package service
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReconcileStorageApplicationService_Do(t *testing.T) {
+	user := &model.User{ID: "user-1"}
+	newFiles := func() []*model.File {
+		return []*model.File{
+			{ID: "file-1", UserID: "user-1", Name: "stored.txt", StorageKey: "file-1"},
+			{ID: "file-2", UserID: "user-1", Name: "missing.txt", StorageKey: "file-2"},
+		}
+	}
+	versions := []*model.FileVersion{
+		{ID: "version-1", FileID: "file-1", UserID: "user-1"},
+		{ID: "version-2", FileID: "file-1", UserID: "user-1"},
+	}
+	blobs := []string{"/data/user/user-1/files/file-1", "/data/user/user-1/files/.version-version-1", "/data/user/user-1/files/leftover"}
+
+	setup := func(policy *model.ReconcilePolicy, files []*model.File) (*mocks.UserRepository, *mocks.FileRepository, *ReconcileStorageApplicationService) {
+		mockRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockRepo.On("List", mock.Anything).Return([]*model.User{user}, nil).Once()
+		mockRepo.On("ListDeletedIDs").Return([]string{}, nil).Once()
+		mockFileRepo.On("List", "user-1").Return(blobs, nil).Once()
+		mockRepo.On("GetFiles", "user-1").Return(files, nil)
+		mockRepo.On("GetFileVersions", "user-1", "file-1").Return(versions, nil)
+		mockRepo.On("GetFileVersions", "user-1", "file-2").Return([]*model.FileVersion{}, nil)
+		return mockRepo, mockFileRepo, NewReconcileStorageApplicationService(mockRepo, mockFileRepo, policy)
+	}
+
+	t.Run("Report", func(t *testing.T) {
+		mockRepo, mockFileRepo, service := setup(&model.ReconcilePolicy{OrphanedBlobs: model.ReconcileReport, MissingBlobs: model.ReconcileReport}, newFiles())
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reconciliation.Users)
+		assert.Equal(t, 3, reconciliation.Blobs)
+		assert.Len(t, reconciliation.Orphaned, 1)
+		assert.Equal(t, "/data/user/user-1/files/leftover", reconciliation.Orphaned[0].Location)
+		assert.Len(t, reconciliation.Missing, 2)
+		assert.Equal(t, "version-2", reconciliation.Missing[0].VersionID)
+		assert.Equal(t, "file-2", reconciliation.Missing[1].FileID)
+		assert.Empty(t, reconciliation.Missing[1].VersionID)
+		assert.Zero(t, reconciliation.Repaired())
+		mockFileRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateFile", mock.Anything)
+		mockRepo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Delete Orphaned Blobs", func(t *testing.T) {
+		_, mockFileRepo, service := setup(&model.ReconcilePolicy{OrphanedBlobs: model.ReconcileDelete, MissingBlobs: model.ReconcileReport}, newFiles())
+		mockFileRepo.On("Delete", "user-1", "leftover").Return(nil).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.True(t, reconciliation.Orphaned[0].Repaired)
+		assert.Equal(t, 1, reconciliation.Repaired())
+		mockFileRepo.AssertExpectations(t)
+	})
+
+	t.Run("Keep Recent Orphaned Blobs", func(t *testing.T) {
+		policy := &model.ReconcilePolicy{OrphanedBlobs: model.ReconcileDelete, MissingBlobs: model.ReconcileReport, OrphanMinAge: time.Hour}
+		_, mockFileRepo, service := setup(policy, newFiles())
+		mockFileRepo.On("ModTime", "user-1", "leftover").Return(time.Now().Add(-time.Minute), nil).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Len(t, reconciliation.Orphaned, 1)
+		assert.False(t, reconciliation.Orphaned[0].Repaired)
+		mockFileRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Delete Old Orphaned Blobs", func(t *testing.T) {
+		policy := &model.ReconcilePolicy{OrphanedBlobs: model.ReconcileDelete, MissingBlobs: model.ReconcileReport, OrphanMinAge: time.Hour}
+		_, mockFileRepo, service := setup(policy, newFiles())
+		mockFileRepo.On("ModTime", "user-1", "leftover").Return(time.Now().Add(-2*time.Hour), nil).Once()
+		mockFileRepo.On("Delete", "user-1", "leftover").Return(nil).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.True(t, reconciliation.Orphaned[0].Repaired)
+		mockFileRepo.AssertExpectations(t)
+	})
+
+	t.Run("Keep Blob Referenced Since", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewReconcileStorageApplicationService(mockRepo, mockFileRepo, &model.ReconcilePolicy{OrphanedBlobs: model.ReconcileDelete, MissingBlobs: model.ReconcileReport})
+		uploaded := &model.File{ID: "leftover", UserID: "user-1", StorageKey: "leftover"}
+
+		mockRepo.On("List", mock.Anything).Return([]*model.User{user}, nil).Once()
+		mockRepo.On("ListDeletedIDs").Return([]string{}, nil).Once()
+		mockFileRepo.On("List", "user-1").Return([]string{"/data/user/user-1/files/leftover"}, nil).Once()
+		mockRepo.On("GetFiles", "user-1").Return([]*model.File{}, nil).Once()
+		mockRepo.On("GetFiles", "user-1").Return([]*model.File{uploaded}, nil).Once()
+		mockRepo.On("GetFileVersions", "user-1", "leftover").Return([]*model.FileVersion{}, nil).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Len(t, reconciliation.Orphaned, 1)
+		assert.False(t, reconciliation.Orphaned[0].Repaired)
+		mockFileRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Flag Missing Blobs", func(t *testing.T) {
+		files := newFiles()
+		mockRepo, _, service := setup(&model.ReconcilePolicy{OrphanedBlobs: model.ReconcileReport, MissingBlobs: model.ReconcileFlag}, files)
+		mockRepo.On("UpdateFile", files[1]).Return(nil).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.True(t, files[1].Corrupted)
+		assert.False(t, reconciliation.Missing[0].Repaired, "versions can't be flagged")
+		assert.True(t, reconciliation.Missing[1].Repaired)
+		mockRepo.AssertNotCalled(t, "DeleteFileVersion", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Delete Missing Blobs", func(t *testing.T) {
+		mockRepo, mockFileRepo, service := setup(&model.ReconcilePolicy{OrphanedBlobs: model.ReconcileReport, MissingBlobs: model.ReconcileDelete}, newFiles())
+		mockRepo.On("DeleteFileVersion", "user-1", "version-2").Return(nil).Once()
+		mockRepo.On("DeleteFile", "user-1", "file-2").Return(nil).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, reconciliation.Repaired())
+		mockFileRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Delete Fails", func(t *testing.T) {
+		mockRepo, _, service := setup(&model.ReconcilePolicy{OrphanedBlobs: model.ReconcileReport, MissingBlobs: model.ReconcileDelete}, newFiles())
+		mockRepo.On("DeleteFileVersion", "user-1", "version-2").Return(nil).Once()
+		mockRepo.On("DeleteFile", "user-1", "file-2").Return(errors.New("db down")).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.True(t, reconciliation.Missing[0].Repaired)
+		assert.False(t, reconciliation.Missing[1].Repaired)
+	})
+
+	t.Run("Deleted User", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewReconcileStorageApplicationService(mockRepo, mockFileRepo, &model.ReconcilePolicy{OrphanedBlobs: model.ReconcileDelete, MissingBlobs: model.ReconcileReport})
+
+		mockRepo.On("List", mock.Anything).Return([]*model.User{}, nil).Once()
+		mockRepo.On("ListDeletedIDs").Return([]string{"user-2", "user-3"}, nil).Once()
+		mockFileRepo.On("List", "user-2").Return([]string{"/data/user/user-2/files/file-9"}, nil).Once()
+		mockFileRepo.On("List", "user-3").Return(nil, fs.ErrNotExist).Once()
+		mockFileRepo.On("Delete", "user-2", "file-9").Return(nil).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, reconciliation.Users)
+		assert.Len(t, reconciliation.Orphaned, 1)
+		assert.True(t, reconciliation.Orphaned[0].Repaired)
+		mockRepo.AssertNotCalled(t, "GetFiles", mock.Anything)
+		mockFileRepo.AssertExpectations(t)
+	})
+
+	t.Run("Storage Fails", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewReconcileStorageApplicationService(mockRepo, mockFileRepo, &model.ReconcilePolicy{OrphanedBlobs: model.ReconcileDelete, MissingBlobs: model.ReconcileDelete})
+
+		mockRepo.On("List", mock.Anything).Return([]*model.User{user}, nil).Once()
+		mockRepo.On("ListDeletedIDs").Return([]string{}, nil).Once()
+		mockFileRepo.On("List", "user-1").Return(nil, errors.New("permission denied")).Once()
+
+		reconciliation, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Zero(t, reconciliation.Users)
+		mockRepo.AssertNotCalled(t, "GetFiles", mock.Anything)
+	})
+
+	t.Run("List Fails", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewReconcileStorageApplicationService(mockRepo, new(mocks.FileRepository), &model.ReconcilePolicy{})
+		listErr := errors.New("db down")
+
+		mockRepo.On("List", mock.Anything).Return(nil, listErr).Once()
+
+		_, err := service.Do()
+
+		assert.ErrorIs(t, err, listErr)
+	})
+}
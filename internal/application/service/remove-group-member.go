@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewRemoveGroupMemberApplicationService(groups domain.GroupRepository, users domain.UserRepository, publisher domain.EventPublisher) *RemoveGroupMemberApplicationService {
+	return &RemoveGroupMemberApplicationService{groups, users, publisher}
+}
+
+type RemoveGroupMemberApplicationService struct {
+	groups    domain.GroupRepository
+	users     domain.UserRepository
+	publisher domain.EventPublisher
+}
+
+func (s *RemoveGroupMemberApplicationService) Do(req *v1.GroupMemberRequest) (*v1.GroupMemberResponse, error) {
+	err := s.groups.RemoveMember(req.GroupID, req.UserID)
+	if err != nil {
+		return &v1.GroupMemberResponse{}, err
+	}
+
+	user, err := s.users.Get(req.UserID)
+	if err != nil {
+		return &v1.GroupMemberResponse{}, err
+	}
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return &v1.GroupMemberResponse{User: user.ToDTO()}, nil
+}
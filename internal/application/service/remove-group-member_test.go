@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRemoveGroupMemberApplicationService_Do(t *testing.T) {
+	req := &v1.GroupMemberRequest{GroupID: "group-123", UserID: "user-123"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewRemoveGroupMemberApplicationService(mockGroupRepo, mockUserRepo, mockEventPublisher)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = req.UserID
+
+		mockGroupRepo.On("RemoveMember", req.GroupID, req.UserID).Return(nil).Once()
+		mockUserRepo.On("Get", req.UserID).Return(user, nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.User.Roles)
+		mockGroupRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not A Member", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewRemoveGroupMemberApplicationService(mockGroupRepo, mockUserRepo, nil)
+
+		mockGroupRepo.On("RemoveMember", req.GroupID, req.UserID).Return(domain.ErrGroupMemberNotFound).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrGroupMemberNotFound)
+		assert.Equal(t, &v1.GroupMemberResponse{}, res)
+		mockUserRepo.AssertNotCalled(t, "Get", mock.Anything)
+	})
+}
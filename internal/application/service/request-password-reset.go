@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+const passwordResetEmailSubject = "Reset your password"
+
+func NewRequestPasswordResetApplicationService(
+	repository domain.UserRepository,
+	tokens domain.PasswordResetTokenRepository,
+	mailer domain.Mailer,
+	ttl time.Duration) *RequestPasswordResetApplicationService {
+	return &RequestPasswordResetApplicationService{repository, tokens, mailer, ttl}
+}
+
+// RequestPasswordResetApplicationService mails a single-use password reset token to a user
+type RequestPasswordResetApplicationService struct {
+	repository domain.UserRepository
+	tokens     domain.PasswordResetTokenRepository
+	mailer     domain.Mailer
+	ttl        time.Duration
+}
+
+// Do succeeds for unknown addresses too, so that it can't be used to find out who has an account
+func (s *RequestPasswordResetApplicationService) Do(req *v1.RequestPasswordResetRequest) error {
+	user, err := s.repository.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			log.Printf("password reset requested for an unknown address")
+			return nil
+		}
+		return err
+	}
+
+	if !user.CanLogin() {
+		log.Printf("password reset requested for disabled user %s", user.ID)
+		return nil
+	}
+
+	token, plain, err := model.NewPasswordResetToken(user.ID, s.ttl)
+	if err != nil {
+		return err
+	}
+
+	err = s.tokens.DeleteByUser(user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.tokens.Create(token)
+	if err != nil {
+		return err
+	}
+
+	dto := user.ToDTO()
+	body := fmt.Sprintf("Hello %s,\n\nuse the following token to choose a new password, it expires at %s.\n\n"+
+		"POST /v1/auth/password-reset/confirm\n{\"token\": \"%s\", \"password\": \"...\"}\n\n"+
+		"If you didn't ask for a password reset, you can ignore this email.\n",
+		dto.Name, token.ExpiresAt.Format(time.RFC1123), plain)
+
+	return s.mailer.Send(dto.Email, passwordResetEmailSubject, body)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRequestPasswordResetApplicationService_Do(t *testing.T) {
+	req := &v1.RequestPasswordResetRequest{Email: "test@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = "user-123"
+
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.PasswordResetTokenRepository)
+		mockMailer := new(mocks.Mailer)
+		service := NewRequestPasswordResetApplicationService(mockUserRepo, mockTokenRepo, mockMailer, time.Hour)
+
+		mockUserRepo.On("GetByEmail", req.Email).Return(user, nil).Once()
+		mockTokenRepo.On("DeleteByUser", "user-123").Return(nil).Once()
+		mockTokenRepo.On("Create", mock.AnythingOfType("*model.PasswordResetToken")).Return(nil).Once()
+		mockMailer.On("Send", req.Email, passwordResetEmailSubject, mock.Anything).Return(nil).Once()
+
+		err := service.Do(req)
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Address", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.PasswordResetTokenRepository)
+		mockMailer := new(mocks.Mailer)
+		service := NewRequestPasswordResetApplicationService(mockUserRepo, mockTokenRepo, mockMailer, time.Hour)
+
+		mockUserRepo.On("GetByEmail", req.Email).Return(nil, domain.ErrUserNotFound).Once()
+
+		err := service.Do(req)
+
+		assert.NoError(t, err, "unknown addresses must not be disclosed")
+		mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
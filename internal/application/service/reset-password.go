@@ -0,0 +1,44 @@
+package service
+
+import (
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewResetPasswordApplicationService(
+	credentials domain.CredentialRepository,
+	tokens domain.PasswordResetTokenRepository,
+	hasher domain.PasswordHasher) *ResetPasswordApplicationService {
+	return &ResetPasswordApplicationService{credentials, tokens, hasher}
+}
+
+// ResetPasswordApplicationService sets a new password with a password reset token
+type ResetPasswordApplicationService struct {
+	credentials domain.CredentialRepository
+	tokens      domain.PasswordResetTokenRepository
+	hasher      domain.PasswordHasher
+}
+
+// Do consumes the token before setting the password, so it can't be replayed. A password that is rejected doesn't
+// cost the token, it is validated first; a failure while storing it does, a new reset has to be requested.
+func (s *ResetPasswordApplicationService) Do(req *v1.ResetPasswordRequest) error {
+	err := model.ValidatePassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	// tokens are single-use
+	token, err := s.tokens.Consume(model.HashPasswordResetToken(req.Token))
+	if err != nil {
+		return err
+	}
+
+	if token.IsExpired(time.Now()) {
+		return model.ErrPasswordResetExpired
+	}
+
+	return setPassword(s.credentials, s.hasher, token.UserID, req.Password)
+}
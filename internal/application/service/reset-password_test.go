@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResetPasswordApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	req := &v1.ResetPasswordRequest{Token: "plain-token", Password: "correct horse battery staple"}
+	hash := model.HashPasswordResetToken(req.Token)
+
+	t.Run("Success", func(t *testing.T) {
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockTokenRepo := new(mocks.PasswordResetTokenRepository)
+		mockHasher := new(mocks.PasswordHasher)
+		service := NewResetPasswordApplicationService(mockCredentialRepo, mockTokenRepo, mockHasher)
+
+		mockTokenRepo.On("Consume", hash).Return(&model.PasswordResetToken{UserID: userID, Hash: hash, ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		mockHasher.On("Hash", req.Password).Return("new-hash", nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(nil, domain.ErrCredentialNotFound).Once()
+		mockCredentialRepo.On("Save", model.NewCredential(userID, "new-hash")).Return(nil).Once()
+
+		err := service.Do(req)
+
+		assert.NoError(t, err)
+		mockCredentialRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockTokenRepo := new(mocks.PasswordResetTokenRepository)
+		service := NewResetPasswordApplicationService(mockCredentialRepo, mockTokenRepo, nil)
+
+		mockTokenRepo.On("Consume", hash).Return(&model.PasswordResetToken{UserID: userID, Hash: hash, ExpiresAt: time.Now().Add(-time.Minute)}, nil).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrPasswordResetExpired)
+		mockCredentialRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		mockTokenRepo := new(mocks.PasswordResetTokenRepository)
+		service := NewResetPasswordApplicationService(nil, mockTokenRepo, nil)
+
+		mockTokenRepo.On("Consume", hash).Return(nil, model.ErrInvalidResetToken).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrInvalidResetToken)
+	})
+
+	t.Run("Invalid Password Keeps Token", func(t *testing.T) {
+		mockTokenRepo := new(mocks.PasswordResetTokenRepository)
+		service := NewResetPasswordApplicationService(nil, mockTokenRepo, nil)
+
+		err := service.Do(&v1.ResetPasswordRequest{Token: req.Token, Password: "short"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidPassword)
+		mockTokenRepo.AssertNotCalled(t, "Consume", mock.Anything)
+	})
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewRestoreFileVersionApplicationService(
+	repository domain.UserRepository,
+	versions *FileVersions,
+	quota *model.StorageQuota) *RestoreFileVersionApplicationService {
+	return &RestoreFileVersionApplicationService{repository, versions, quota}
+}
+
+// RestoreFileVersionApplicationService makes the content of a prior version the current content of the file.
+// The file is switched to the content of the version, which is no longer listed as a version, and the replaced
+// content becomes the newest version. No content is copied.
+type RestoreFileVersionApplicationService struct {
+	repository domain.UserRepository
+	versions   *FileVersions
+	quota      *model.StorageQuota
+}
+
+func (s *RestoreFileVersionApplicationService) Do(req *v1.RestoreFileVersionRequest) (*v1.RestoreFileVersionResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.RestoreFileVersionResponse{}, err
+	}
+
+	if !user.CanModifyFiles() {
+		return &v1.RestoreFileVersionResponse{}, model.ErrFilesReadOnly
+	}
+
+	file, err := user.GetFile(req.FileID)
+	if err != nil {
+		return &v1.RestoreFileVersionResponse{}, err
+	}
+
+	version, err := s.repository.GetFileVersion(user.ID, file.ID, req.VersionID)
+	if err != nil {
+		return &v1.RestoreFileVersionResponse{}, err
+	}
+
+	restored := *file
+	restored.Restore(version)
+	if err := s.versions.replace(&restored, s.versions.archive(file), version, s.quota); err != nil {
+		log.Printf("error restoring version %s of file %s: %s", version.ID, file.ID, err)
+		return &v1.RestoreFileVersionResponse{}, err
+	}
+	*file = restored
+
+	return &v1.RestoreFileVersionResponse{File: file.ToDTO()}, nil
+}
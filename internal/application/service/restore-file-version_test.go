@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestoreFileVersionApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	quota := &model.StorageQuota{Bytes: 4096}
+	req := &v1.RestoreFileVersionRequest{UserID: userID, FileID: "file-123", VersionID: "version-1"}
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "contract.pdf", Size: 7, Digest: "new", Version: 2})
+		return user
+	}
+	oldVersion := &model.FileVersion{ID: "version-1", FileID: "file-123", UserID: userID, Version: 1, Size: 5, Digest: helloDigest}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewRestoreFileVersionApplicationService(mockUserRepo, NewFileVersions(mockUserRepo, mockFileRepo, 10), quota)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFileVersion", userID, "file-123", "version-1").Return(oldVersion, nil).Once()
+		// the file is switched to the content of the version, nothing is copied
+		mockUserRepo.On("ReplaceFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.Version == 3 && f.Size == 5 && f.Digest == helloDigest && f.StorageName() == ".version-version-1"
+		}), mock.MatchedBy(func(v *model.FileVersion) bool {
+			return v.Version == 2 && v.Size == 7 && v.Digest == "new" && v.StorageName() == "contract.pdf"
+		}), oldVersion, quota).Return(nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-123").Return([]*model.FileVersion{oldVersion}, nil).Once()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, res.File.Version)
+		assert.Equal(t, helloDigest, res.File.Digest)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+		mockFileRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Version Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewRestoreFileVersionApplicationService(mockUserRepo, NewFileVersions(mockUserRepo, mockFileRepo, 10), quota)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetFileVersion", userID, "file-123", "version-1").Return(nil, model.ErrFileVersionNotFound).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFileVersionNotFound)
+		mockFileRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Replaced Meanwhile", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewRestoreFileVersionApplicationService(mockUserRepo, NewFileVersions(mockUserRepo, mockFileRepo, 10), quota)
+
+		user := newUser()
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("GetFileVersion", userID, "file-123", "version-1").Return(oldVersion, nil).Once()
+		mockUserRepo.On("ReplaceFile", mock.Anything, mock.Anything, oldVersion, quota).
+			Return(model.ErrFileContentChanged).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFileContentChanged)
+		file, _ := user.GetFile("file-123")
+		assert.Equal(t, 2, file.Version)
+		mockFileRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewRestoreFileVersionApplicationService(mockUserRepo, noFileVersions, quota)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+		mockUserRepo.AssertNotCalled(t, "GetFileVersion", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+// scanBatchSize is how many pending files are scanned at most per run
+const scanBatchSize = 100
+
+func NewScanPendingFilesApplicationService(
+	repository domain.UserRepository,
+	storage domain.FileRepository,
+	files *FileScanner,
+) *ScanPendingFilesApplicationService {
+	return &ScanPendingFilesApplicationService{repository, storage, files}
+}
+
+// ScanPendingFilesApplicationService scans the files added in async mode. Clean files become downloadable,
+// infected ones are quarantined and deleted, or replaced by their previous version.
+type ScanPendingFilesApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+	files      *FileScanner
+}
+
+// Do scans a batch of pending files and returns how many were infected. A file the scanner can't tell about is
+// logged and stays pending, it is scanned again on the next run.
+func (s *ScanPendingFilesApplicationService) Do() (int, error) {
+	pending, err := s.repository.ListFilesPendingScan(scanBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	infected := 0
+	for _, file := range pending {
+		open := func() (io.ReadCloser, error) {
+			return s.storage.Get(file.UserID, file.StorageName())
+		}
+		threat, err := s.files.scan(open)
+		if err != nil {
+			log.Printf("error scanning file %s of user %s: %s", file.ID, file.UserID, err)
+			continue
+		}
+
+		if threat == "" {
+			file.ScanStatus = model.ScanClean
+			if err := s.repository.UpdateFile(file); err != nil {
+				return infected, err
+			}
+			continue
+		}
+
+		infected++
+		s.files.quarantineContent(&model.Quarantine{
+			UserID: file.UserID, FileID: file.ID, Filename: file.Name, Size: file.Size, Threat: threat,
+		}, open)
+		if err := s.reject(file); err != nil {
+			return infected, err
+		}
+	}
+
+	if len(pending) > 0 {
+		log.Printf("Scanned %d pending files, %d infected", len(pending), infected)
+	}
+	return infected, nil
+}
+
+// reject takes the infected content away from the user. A re-upload of an existing name gets back the newest
+// clean content it replaced, the file and its other versions are kept; a new file, or one without a clean
+// version, is deleted.
+func (s *ScanPendingFilesApplicationService) reject(file *model.File) error {
+	versions, err := s.repository.GetFileVersions(file.UserID, file.ID)
+	if err != nil {
+		return err
+	}
+	// the versions are the newest first
+	i := slices.IndexFunc(versions, func(v *model.FileVersion) bool { return v.ScanStatus == model.ScanClean })
+	if i < 0 {
+		return deleteFile(s.repository, s.storage, file)
+	}
+
+	clean := versions[i]
+	// the file is switched to the content of the version, it was counted before the re-upload so no default
+	// quota applies to get it back
+	if err := s.repository.ReplaceFile(file.AtVersion(clean), nil, clean, &model.StorageQuota{}); err != nil {
+		return err
+	}
+	if err := s.storage.Delete(file.UserID, file.StorageName()); err != nil {
+		log.Printf("error deleting infected content of file %s: %s", file.ID, err)
+	}
+	return nil
+}
+
+// Run scans the pending files every interval until the context is done
+func (s *ScanPendingFilesApplicationService) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "scanning pending files", func() error {
+		_, err := s.Do()
+		return err
+	})
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScanPendingFilesApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newFiles := func() []*model.File {
+		return []*model.File{
+			{ID: "file-1", UserID: userID, Name: "clean.txt", Size: 5, ScanStatus: model.ScanPending},
+			{ID: "file-2", UserID: userID, Name: "eicar.txt", Size: 68, ScanStatus: model.ScanPending},
+			{ID: "file-3", UserID: userID, Name: "unknown.txt", Size: 5, ScanStatus: model.ScanPending},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		service := NewScanPendingFilesApplicationService(mockUserRepo, mockFileRepo,
+			NewFileScanner(mockScanner, mockQuarantine, mockPublisher, true))
+
+		mockUserRepo.On("ListFilesPendingScan", scanBatchSize).Return(newFiles(), nil).Once()
+		mockFileRepo.On("Get", userID, "clean.txt").Return(newTestBlob(t, "hello"), nil).Once()
+		mockFileRepo.On("Get", userID, "eicar.txt").Return(newTestBlob(t, "eicar"), nil).Once()
+		mockFileRepo.On("Get", userID, "eicar.txt").Return(newTestBlob(t, "eicar"), nil).Once()
+		mockFileRepo.On("Get", userID, "unknown.txt").Return(newTestBlob(t, "hello"), nil).Once()
+		mockScanner.On("Scan", mock.Anything).Return("", nil).Once()
+		mockScanner.On("Scan", mock.Anything).Return("Eicar-Test-Signature", nil).Once()
+		mockScanner.On("Scan", mock.Anything).Return("", domain.ErrScanFailed).Once()
+		mockUserRepo.On("UpdateFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.ID == "file-1" && f.ScanStatus == model.ScanClean
+		})).Return(nil).Once()
+		mockQuarantine.On("Save", userID, mock.Anything, mock.Anything).Return("/quarantine/q-123", nil).Once()
+		mockPublisher.On("Publish", mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == domain.FileQuarantinedEvent && e.Quarantine.FileID == "file-2"
+		})).Return(nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-2").Return([]*model.FileVersion{}, nil)
+		mockUserRepo.On("DeleteFile", userID, "file-2").Return(nil).Once()
+		mockFileRepo.On("Delete", userID, "eicar.txt").Return(nil).Once()
+
+		infected, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, infected)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+		// the file the scanner couldn't tell about stays pending
+		mockUserRepo.AssertNotCalled(t, "UpdateFile", mock.MatchedBy(func(f *model.File) bool { return f.ID == "file-3" }))
+	})
+
+	t.Run("Infected Re-upload", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		service := NewScanPendingFilesApplicationService(mockUserRepo, mockFileRepo,
+			NewFileScanner(mockScanner, mockQuarantine, mockPublisher, true))
+
+		file := &model.File{
+			ID: "file-2", UserID: userID, Name: "report.txt", Size: 68, Digest: "infected", Version: 3,
+			ScanStatus: model.ScanPending,
+		}
+		previous := &model.FileVersion{
+			ID: "version-2", FileID: "file-2", UserID: userID, Version: 2, Size: 5, Digest: "clean",
+			ScanStatus: model.ScanClean,
+		}
+		older := &model.FileVersion{ID: "version-1", FileID: "file-2", UserID: userID, Version: 1, Size: 4}
+
+		mockUserRepo.On("ListFilesPendingScan", scanBatchSize).Return([]*model.File{file}, nil).Once()
+		mockFileRepo.On("Get", userID, "report.txt").Return(newTestBlob(t, "eicar"), nil).Once()
+		mockFileRepo.On("Get", userID, "report.txt").Return(newTestBlob(t, "eicar"), nil).Once()
+		mockScanner.On("Scan", mock.Anything).Return("Eicar-Test-Signature", nil).Once()
+		mockQuarantine.On("Save", userID, mock.Anything, mock.Anything).Return("/quarantine/q-123", nil).Once()
+		mockPublisher.On("Publish", mock.Anything).Return(nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-2").Return([]*model.FileVersion{previous, older}, nil).Once()
+		// the file is switched back to the previous content, the infected one is deleted
+		mockUserRepo.On("ReplaceFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.ID == "file-2" && f.Version == 2 && f.Size == 5 && f.Digest == "clean" &&
+				f.ScanStatus == model.ScanClean && f.StorageName() == previous.StorageName()
+		}), (*model.FileVersion)(nil), previous, mock.Anything).Return(nil).Once()
+		mockFileRepo.On("Delete", userID, "report.txt").Return(nil).Once()
+
+		infected, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, infected)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		// the file and its older versions are kept
+		mockUserRepo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "DeleteFileVersion", userID, "version-1")
+	})
+
+	t.Run("Infected Re-upload Of Unscanned Versions", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		service := NewScanPendingFilesApplicationService(mockUserRepo, mockFileRepo,
+			NewFileScanner(mockScanner, mockQuarantine, mockPublisher, true))
+
+		file := &model.File{
+			ID: "file-2", UserID: userID, Name: "report.txt", StorageKey: "key-4", Size: 68, Digest: "infected",
+			Version: 4, ScanStatus: model.ScanPending,
+		}
+		pending := &model.FileVersion{
+			ID: "version-3", FileID: "file-2", UserID: userID, StorageKey: "key-3", Version: 3, Size: 6,
+			ScanStatus: model.ScanPending,
+		}
+		// stored while scanning was off
+		unscanned := &model.FileVersion{ID: "version-2", FileID: "file-2", UserID: userID, StorageKey: "key-2", Version: 2, Size: 7}
+		clean := &model.FileVersion{
+			ID: "version-1", FileID: "file-2", UserID: userID, StorageKey: "key-1", Version: 1, Size: 5,
+			Digest: "clean", ScanStatus: model.ScanClean,
+		}
+
+		mockUserRepo.On("ListFilesPendingScan", scanBatchSize).Return([]*model.File{file}, nil).Once()
+		mockFileRepo.On("Get", userID, "key-4").Return(newTestBlob(t, "eicar"), nil).Twice()
+		mockScanner.On("Scan", mock.Anything).Return("Eicar-Test-Signature", nil).Once()
+		mockQuarantine.On("Save", userID, mock.Anything, mock.Anything).Return("/quarantine/q-123", nil).Once()
+		mockPublisher.On("Publish", mock.Anything).Return(nil).Once()
+		mockUserRepo.On("GetFileVersions", userID, "file-2").
+			Return([]*model.FileVersion{pending, unscanned, clean}, nil).Once()
+		// the newest version known to be clean is restored
+		mockUserRepo.On("ReplaceFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.Version == 1 && f.Digest == "clean" && f.StorageName() == "key-1"
+		}), (*model.FileVersion)(nil), clean, mock.Anything).Return(nil).Once()
+		mockFileRepo.On("Delete", userID, "key-4").Return(nil).Once()
+
+		infected, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, infected)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Infected Re-upload Without Clean Version", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockScanner := new(mocks.Scanner)
+		mockQuarantine := new(mocks.FileRepository)
+		mockPublisher := new(mocks.EventPublisher)
+		service := NewScanPendingFilesApplicationService(mockUserRepo, mockFileRepo,
+			NewFileScanner(mockScanner, mockQuarantine, mockPublisher, true))
+
+		file := &model.File{
+			ID: "file-2", UserID: userID, Name: "report.txt", Size: 68, Digest: "infected", Version: 2,
+			ScanStatus: model.ScanPending,
+		}
+		pending := &model.FileVersion{ID: "version-1", FileID: "file-2", UserID: userID, Version: 1, Size: 6,
+			ScanStatus: model.ScanPending}
+
+		mockUserRepo.On("ListFilesPendingScan", scanBatchSize).Return([]*model.File{file}, nil).Once()
+		mockFileRepo.On("Get", userID, "report.txt").Return(newTestBlob(t, "eicar"), nil).Twice()
+		mockScanner.On("Scan", mock.Anything).Return("Eicar-Test-Signature", nil).Once()
+		mockQuarantine.On("Save", userID, mock.Anything, mock.Anything).Return("/quarantine/q-123", nil).Once()
+		mockPublisher.On("Publish", mock.Anything).Return(nil).Once()
+		// the file is deleted with its versions
+		mockUserRepo.On("GetFileVersions", userID, "file-2").Return([]*model.FileVersion{pending}, nil)
+		mockUserRepo.On("DeleteFile", userID, "file-2").Return(nil).Once()
+		mockFileRepo.On("Delete", userID, pending.StorageName()).Return(nil).Once()
+		mockFileRepo.On("Delete", userID, "report.txt").Return(nil).Once()
+
+		infected, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, infected)
+		mockUserRepo.AssertExpectations(t)
+		mockFileRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "ReplaceFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Nothing Pending", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockScanner := new(mocks.Scanner)
+		service := NewScanPendingFilesApplicationService(mockUserRepo, new(mocks.FileRepository),
+			NewFileScanner(mockScanner, new(mocks.FileRepository), new(mocks.EventPublisher), true))
+
+		mockUserRepo.On("ListFilesPendingScan", scanBatchSize).Return([]*model.File{}, nil).Once()
+
+		infected, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, infected)
+		mockScanner.AssertNotCalled(t, "Scan", mock.Anything)
+	})
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+)
+
+func NewScrubFilesApplicationService(repository domain.UserRepository, storage domain.FileRepository) *ScrubFilesApplicationService {
+	return &ScrubFilesApplicationService{repository, storage}
+}
+
+// ScrubFilesApplicationService verifies the stored content of the files of every user
+type ScrubFilesApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+}
+
+// Do verifies all the files and returns how many are corrupted. A file that can't be read is logged and skipped,
+// so one missing blob doesn't stop the scrub.
+func (s *ScrubFilesApplicationService) Do() (int, error) {
+	users, err := s.repository.List(&domain.UserFilter{})
+	if err != nil {
+		return 0, err
+	}
+
+	checked, corrupted := 0, 0
+	for _, user := range users {
+		changed := false
+		for _, file := range user.GetFiles() {
+			fileChanged, err := checkFileIntegrity(s.storage, file)
+			if err != nil {
+				log.Printf("error verifying file %s of user %s: %s", file.ID, user.ID, err)
+				continue
+			}
+			checked++
+			changed = changed || fileChanged
+			if file.Corrupted {
+				corrupted++
+			}
+		}
+		if changed {
+			if err := s.repository.Update(user.ID, user); err != nil {
+				return corrupted, err
+			}
+		}
+	}
+
+	log.Printf("Scrubbed %d files, %d corrupted", checked, corrupted)
+	return corrupted, nil
+}
+
+// Run scrubs the files every interval until the context is done
+func (s *ScrubFilesApplicationService) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "scrubbing files", func() error {
+		_, err := s.Do()
+		return err
+	})
+}
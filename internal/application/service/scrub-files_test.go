@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScrubFilesApplicationService_Do(t *testing.T) {
+	newUser := func(id string, files ...*model.File) *model.User {
+		user, _ := model.NewUser("Test User", id+"@example.com", "1990-01-01")
+		user.ID = id
+		for _, f := range files {
+			user.AddFile(f)
+		}
+		return user
+	}
+
+	t.Run("Flags Corrupted Files", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewScrubFilesApplicationService(mockUserRepo, mockFileRepo)
+
+		intact := newUser("user-1", &model.File{ID: "file-1", UserID: "user-1", Name: "a.txt", Digest: helloDigest})
+		damaged := newUser("user-2",
+			&model.File{ID: "file-2", UserID: "user-2", Name: "b.txt", Digest: helloDigest},
+			&model.File{ID: "file-3", UserID: "user-2", Name: "missing.txt", Digest: helloDigest})
+
+		mockUserRepo.On("List", &domain.UserFilter{}).Return([]*model.User{intact, damaged}, nil).Once()
+		mockFileRepo.On("Get", "user-1", "a.txt").Return(newTestBlob(t, "hello"), nil).Once()
+		mockFileRepo.On("Get", "user-2", "b.txt").Return(newTestBlob(t, "bit rot"), nil).Once()
+		mockFileRepo.On("Get", "user-2", "missing.txt").Return(nil, errors.New("no such file")).Once()
+		mockUserRepo.On("Update", "user-2", damaged).Return(nil).Once()
+
+		corrupted, err := service.Do()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, corrupted)
+		assert.True(t, damaged.GetFiles()[0].Corrupted)
+		assert.False(t, damaged.GetFiles()[1].Corrupted, "unreadable files are skipped")
+		mockUserRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "Update", "user-1", mock.Anything)
+	})
+
+	t.Run("List Fails", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewScrubFilesApplicationService(mockUserRepo, mockFileRepo)
+		listErr := errors.New("db down")
+
+		mockUserRepo.On("List", &domain.UserFilter{}).Return(nil, listErr).Once()
+
+		_, err := service.Do()
+
+		assert.ErrorIs(t, err, listErr)
+	})
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewSendContactPointVerificationApplicationService(repository domain.UserRepository, verifier *EmailVerifier) *SendContactPointVerificationApplicationService {
+	return &SendContactPointVerificationApplicationService{repository, verifier}
+}
+
+// SendContactPointVerificationApplicationService sends a new verification token for a secondary email address
+type SendContactPointVerificationApplicationService struct {
+	repository domain.UserRepository
+	verifier   *EmailVerifier
+}
+
+func (s *SendContactPointVerificationApplicationService) Do(req *v1.ContactPointRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	contact, err := user.GetContactPoint(req.ContactID)
+	if err != nil {
+		return err
+	}
+
+	if contact.Type != model.ContactEmail {
+		return model.ErrPhoneVerificationNotSent
+	}
+
+	if contact.Verified {
+		return model.ErrEmailAlreadyVerified
+	}
+
+	return s.verifier.SendTo(user, contact.Value)
+}
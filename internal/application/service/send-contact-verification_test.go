@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendContactPointVerificationApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "phone-1", Type: model.ContactPhone, Value: "+447911123456"})
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "email-1", Type: model.ContactEmail, Value: "work@example.com"})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		verifier, mockTokenRepo, mockMailer := newTestEmailVerifier()
+		service := NewSendContactPointVerificationApplicationService(mockRepo, verifier)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockTokenRepo.On("DeleteByUser", userID).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.Anything).Return(nil).Once()
+		mockMailer.On("Send", "work@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := service.Do(&v1.ContactPointRequest{UserID: userID, ContactID: "email-1"})
+
+		assert.NoError(t, err)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Phone Number", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		verifier, _, mockMailer := newTestEmailVerifier()
+		service := NewSendContactPointVerificationApplicationService(mockRepo, verifier)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		err := service.Do(&v1.ContactPointRequest{UserID: userID, ContactID: "phone-1"})
+
+		assert.ErrorIs(t, err, model.ErrPhoneVerificationNotSent)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewSendEmailVerificationApplicationService(repository domain.UserRepository, verifier *EmailVerifier) *SendEmailVerificationApplicationService {
+	return &SendEmailVerificationApplicationService{repository, verifier}
+}
+
+// SendEmailVerificationApplicationService sends a new verification token, e.g. when the previous one expired
+type SendEmailVerificationApplicationService struct {
+	repository domain.UserRepository
+	verifier   *EmailVerifier
+}
+
+func (s *SendEmailVerificationApplicationService) Do(req *v1.SendEmailVerificationRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return model.ErrEmailAlreadyVerified
+	}
+
+	return s.verifier.Send(user)
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendEmailVerificationApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	req := &v1.SendEmailVerificationRequest{UserID: userID}
+
+	t.Run("Success", func(t *testing.T) {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+
+		mockUserRepo := new(mocks.UserRepository)
+		verifier, mockTokenRepo, mockMailer := newTestEmailVerifier()
+		service := NewSendEmailVerificationApplicationService(mockUserRepo, verifier)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockTokenRepo.On("DeleteByUser", userID).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.Anything).Return(nil).Once()
+		mockMailer.On("Send", "test@example.com", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := service.Do(req)
+
+		assert.NoError(t, err)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Already Verified", func(t *testing.T) {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.VerifyEmail()
+
+		mockUserRepo := new(mocks.UserRepository)
+		verifier, mockTokenRepo, _ := newTestEmailVerifier()
+		service := NewSendEmailVerificationApplicationService(mockUserRepo, verifier)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrEmailAlreadyVerified)
+		mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewSendEmailVerificationApplicationService(mockUserRepo, verifier)
+
+		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		err := service.Do(req)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"io"
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/google/uuid"
+)
+
+func NewSetAvatarApplicationService(
+	repository domain.UserRepository,
+	avatars domain.FileRepository,
+	images domain.ImageProcessor,
+	publisher domain.EventPublisher,
+	maxAvatarSize int64) *SetAvatarApplicationService {
+	return &SetAvatarApplicationService{repository, avatars, images, publisher, maxAvatarSize}
+}
+
+// SetAvatarApplicationService replaces a user's avatar with thumbnails of the uploaded image
+type SetAvatarApplicationService struct {
+	repository    domain.UserRepository
+	avatars       domain.FileRepository
+	images        domain.ImageProcessor
+	publisher     domain.EventPublisher
+	maxAvatarSize int64
+}
+
+func (s *SetAvatarApplicationService) Do(req *v1.SetAvatarRequest) (*v1.SetAvatarResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.SetAvatarResponse{}, err
+	}
+
+	if !user.CanModifyFiles() {
+		return &v1.SetAvatarResponse{}, model.ErrFilesReadOnly
+	}
+
+	content, err := s.read(req)
+	if err != nil {
+		return &v1.SetAvatarResponse{}, err
+	}
+
+	thumbnails, err := s.images.Thumbnails(content, model.AvatarSizes)
+	if err != nil {
+		return &v1.SetAvatarResponse{}, err
+	}
+
+	avatar := &model.Avatar{ID: uuid.NewString(), ContentType: thumbnails[0].ContentType}
+	for _, thumbnail := range thumbnails {
+		filename, err := avatar.Filename(thumbnail.Size)
+		if err != nil {
+			return &v1.SetAvatarResponse{}, err
+		}
+		_, err = s.avatars.Save(user.ID, filename, bytes.NewReader(thumbnail.Content))
+		if err != nil {
+			return &v1.SetAvatarResponse{}, err
+		}
+	}
+
+	previous := user.SetAvatar(avatar)
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return &v1.SetAvatarResponse{}, err
+	}
+
+	if previous != nil {
+		deleteAvatar(s.avatars, user.ID, previous)
+	}
+
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return &v1.SetAvatarResponse{User: user.ToDTO()}, nil
+}
+
+// read reads the uploaded image, the declared size can't be trusted
+func (s *SetAvatarApplicationService) read(req *v1.SetAvatarRequest) ([]byte, error) {
+	if req.File.Size > s.maxAvatarSize {
+		return nil, model.ErrFileTooLarge
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, s.maxAvatarSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > s.maxAvatarSize {
+		return nil, model.ErrFileTooLarge
+	}
+	return content, nil
+}
+
+// deleteAvatar deletes the thumbnails of a replaced avatar, a failure only leaves unreferenced files behind
+func deleteAvatar(avatars domain.FileRepository, userID string, avatar *model.Avatar) {
+	for _, filename := range avatar.Filenames() {
+		if err := avatars.Delete(userID, filename); err != nil {
+			log.Printf("error deleting avatar thumbnail %s of user %s: %s", filename, userID, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"mime/multipart"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestFileHeader builds a file header as parsed from a multipart form, so that it can be opened
+func newTestFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+	return form.File["file"][0]
+}
+
+func TestSetAvatarApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	maxSize := int64(1024)
+	content := []byte("image content")
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		return user
+	}
+	newThumbnails := func() []*domain.Thumbnail {
+		var thumbnails []*domain.Thumbnail
+		for _, size := range model.AvatarSizes {
+			thumbnails = append(thumbnails, &domain.Thumbnail{Size: size, ContentType: "image/png", Content: []byte("thumbnail")})
+		}
+		return thumbnails
+	}
+
+	t.Run("Success replaces the previous avatar", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockImages := new(mocks.ImageProcessor)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewSetAvatarApplicationService(mockUserRepo, mockAvatarRepo, mockImages, mockEventPublisher, maxSize)
+
+		user := newUser()
+		previous := &model.Avatar{ID: "previous", ContentType: "image/jpeg"}
+		user.SetAvatar(previous)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockImages.On("Thumbnails", content, model.AvatarSizes).Return(newThumbnails(), nil).Once()
+		mockAvatarRepo.On("Save", userID, mock.AnythingOfType("string"), mock.Anything).Return("path", nil).Times(len(model.AvatarSizes))
+		mockUserRepo.On("Update", userID, mock.Anything).Return(nil).Once()
+		for _, filename := range previous.Filenames() {
+			mockAvatarRepo.On("Delete", userID, filename).Return(nil).Once()
+		}
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(&v1.SetAvatarRequest{UserID: userID, File: newTestFileHeader(t, "me.png", content)})
+
+		assert.NoError(t, err)
+		avatar, _ := user.GetAvatar()
+		assert.Equal(t, "image/png", avatar.ContentType)
+		assert.Equal(t, "/v1/users/user-123/avatar?v="+avatar.ID, res.User.AvatarURL)
+		mockUserRepo.AssertExpectations(t)
+		mockAvatarRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unsupported Image", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockAvatarRepo := new(mocks.FileRepository)
+		mockImages := new(mocks.ImageProcessor)
+		service := NewSetAvatarApplicationService(mockUserRepo, mockAvatarRepo, mockImages, nil, maxSize)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockImages.On("Thumbnails", content, model.AvatarSizes).Return(nil, domain.ErrUnsupportedImageType).Once()
+
+		res, err := service.Do(&v1.SetAvatarRequest{UserID: userID, File: newTestFileHeader(t, "me.png", content)})
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedImageType)
+		assert.Equal(t, &v1.SetAvatarResponse{}, res)
+		mockAvatarRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("File Too Large", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockImages := new(mocks.ImageProcessor)
+		service := NewSetAvatarApplicationService(mockUserRepo, nil, mockImages, nil, maxSize)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.SetAvatarRequest{UserID: userID, File: newTestFileHeader(t, "me.png", make([]byte, maxSize+1))})
+
+		assert.ErrorIs(t, err, model.ErrFileTooLarge)
+		mockImages.AssertNotCalled(t, "Thumbnails", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Files Read Only", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewSetAvatarApplicationService(mockUserRepo, nil, nil, nil, maxSize)
+
+		user := newUser()
+		user.RestoreStatus(model.UserDeactivated, "")
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, err := service.Do(&v1.SetAvatarRequest{UserID: userID, File: newTestFileHeader(t, "me.png", content)})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+	})
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewSetPasswordApplicationService(
+	repository domain.UserRepository,
+	credentials domain.CredentialRepository,
+	hasher domain.PasswordHasher) *SetPasswordApplicationService {
+	return &SetPasswordApplicationService{repository, credentials, hasher}
+}
+
+// SetPasswordApplicationService sets a user's password, whether the user already had one or not
+type SetPasswordApplicationService struct {
+	repository  domain.UserRepository
+	credentials domain.CredentialRepository
+	hasher      domain.PasswordHasher
+}
+
+func (s *SetPasswordApplicationService) Do(req *v1.SetPasswordRequest) error {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	return setPassword(s.credentials, s.hasher, user.ID, req.Password)
+}
+
+// setPassword validates, hashes and stores a new password, creating the credential when missing
+func setPassword(credentials domain.CredentialRepository, hasher domain.PasswordHasher, userID, password string) error {
+	err := model.ValidatePassword(password)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	credential, err := credentials.Get(userID)
+	if errors.Is(err, domain.ErrCredentialNotFound) {
+		credential = model.NewCredential(userID, hash)
+	} else if err != nil {
+		return err
+	} else {
+		credential.SetHash(hash)
+	}
+
+	return credentials.Save(credential)
+}
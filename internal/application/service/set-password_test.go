@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetPasswordApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	password := "correct horse battery staple"
+
+	user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+	user.ID = userID
+
+	t.Run("First Password", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockHasher := new(mocks.PasswordHasher)
+		service := NewSetPasswordApplicationService(mockUserRepo, mockCredentialRepo, mockHasher)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockHasher.On("Hash", password).Return("hash", nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(nil, domain.ErrCredentialNotFound).Once()
+		mockCredentialRepo.On("Save", model.NewCredential(userID, "hash")).Return(nil).Once()
+
+		err := service.Do(&v1.SetPasswordRequest{UserID: userID, Password: password})
+
+		assert.NoError(t, err)
+		mockCredentialRepo.AssertExpectations(t)
+	})
+
+	t.Run("Replace Password", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockHasher := new(mocks.PasswordHasher)
+		service := NewSetPasswordApplicationService(mockUserRepo, mockCredentialRepo, mockHasher)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockHasher.On("Hash", password).Return("new-hash", nil).Once()
+		mockCredentialRepo.On("Get", userID).Return(&model.Credential{UserID: userID, Hash: "old-hash", FailedAttempts: 2}, nil).Once()
+		mockCredentialRepo.On("Save", model.NewCredential(userID, "new-hash")).Return(nil).Once()
+
+		err := service.Do(&v1.SetPasswordRequest{UserID: userID, Password: password})
+
+		assert.NoError(t, err)
+		mockCredentialRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Password", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockCredentialRepo := new(mocks.CredentialRepository)
+		mockHasher := new(mocks.PasswordHasher)
+		service := NewSetPasswordApplicationService(mockUserRepo, mockCredentialRepo, mockHasher)
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		err := service.Do(&v1.SetPasswordRequest{UserID: userID, Password: "short"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidPassword)
+		mockCredentialRepo.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewSetStorageQuotaApplicationService(
+	repository domain.UserRepository,
+	quota *model.StorageQuota) *SetStorageQuotaApplicationService {
+	return &SetStorageQuotaApplicationService{repository, quota}
+}
+
+// SetStorageQuotaApplicationService overrides the default quota of a user. Files already stored are kept even if
+// they exceed the new quota, the user can't add more until enough are deleted.
+type SetStorageQuotaApplicationService struct {
+	repository domain.UserRepository
+	quota      *model.StorageQuota
+}
+
+func (s *SetStorageQuotaApplicationService) Do(req *v1.SetStorageQuotaRequest) (*v1.SetStorageQuotaResponse, error) {
+	quota, err := model.NewStorageQuota(*req.Bytes, *req.Files)
+	if err != nil {
+		return &v1.SetStorageQuotaResponse{}, err
+	}
+
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.SetStorageQuotaResponse{}, err
+	}
+
+	return setStorageQuota(s.repository, user.ID, quota, s.quota)
+}
+
+// setStorageQuota overrides the quota of a user, nil restores the default one
+func setStorageQuota(
+	repository domain.UserRepository,
+	userID string,
+	quota, defaultQuota *model.StorageQuota) (*v1.SetStorageQuotaResponse, error) {
+	if err := repository.SetStorageQuota(userID, quota); err != nil {
+		return &v1.SetStorageQuotaResponse{}, err
+	}
+
+	usage, err := repository.GetStorageUsage(userID)
+	if err != nil {
+		return &v1.SetStorageQuotaResponse{}, err
+	}
+
+	return &v1.SetStorageQuotaResponse{Usage: usage.ToDTO(defaultQuota)}, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetStorageQuotaApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	defaultQuota := &model.StorageQuota{Bytes: 1024}
+	bytes, files := int64(4096), int64(0)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewSetStorageQuotaApplicationService(mockUserRepo, defaultQuota)
+
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		quota := &model.StorageQuota{Bytes: bytes, Files: files}
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockUserRepo.On("SetStorageQuota", userID, quota).Return(nil).Once()
+		mockUserRepo.On("GetStorageUsage", userID).Return(&model.StorageUsage{UserID: userID, Quota: quota}, nil).Once()
+
+		res, err := service.Do(&v1.SetStorageQuotaRequest{UserID: userID, Bytes: &bytes, Files: &files})
+
+		assert.NoError(t, err)
+		assert.Equal(t, bytes, res.Usage.QuotaBytes)
+		assert.True(t, res.Usage.QuotaOverridden)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Quota", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewSetStorageQuotaApplicationService(mockUserRepo, defaultQuota)
+		negative := int64(-1)
+
+		_, err := service.Do(&v1.SetStorageQuotaRequest{UserID: userID, Bytes: &negative, Files: &files})
+
+		assert.ErrorIs(t, err, model.ErrInvalidStorageQuota)
+		mockUserRepo.AssertNotCalled(t, "SetStorageQuota", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/event"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewTransitionUserStatusApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *TransitionUserStatusApplicationService {
+	return &TransitionUserStatusApplicationService{repository, publisher}
+}
+
+// TransitionUserStatusApplicationService moves a user through the account lifecycle
+type TransitionUserStatusApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *TransitionUserStatusApplicationService) Do(req *v1.TransitionUserStatusRequest) (*v1.TransitionUserStatusResponse, error) {
+	user, err := s.repository.Get(req.ID)
+	if err != nil {
+		return &v1.TransitionUserStatusResponse{}, err
+	}
+
+	change, err := user.Transition(model.StatusAction(req.Action), req.Reason)
+	if err != nil {
+		return &v1.TransitionUserStatusResponse{}, err
+	}
+
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return &v1.TransitionUserStatusResponse{}, err
+	}
+
+	go func() {
+		err := s.publisher.Publish(event.NewUserStatusChangedEvent(user, change))
+		if err != nil {
+			log.Printf("Failed to publish user status changed event: %v", err)
+		}
+	}()
+
+	return &v1.TransitionUserStatusResponse{User: user.ToDTO()}, nil
+}
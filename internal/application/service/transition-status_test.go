@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransitionUserStatusApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+
+	newUser := func(status model.UserStatus) *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.RestoreStatus(status, "")
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewTransitionUserStatusApplicationService(mockRepo, mockEventPublisher)
+
+		published := make(chan *domain.Event, 1)
+		mockRepo.On("Get", userID).Return(newUser(model.UserActive), nil).Once()
+		mockRepo.On("Update", userID, mock.AnythingOfType("*model.User")).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once().
+			Run(func(args mock.Arguments) { published <- args.Get(0).(*domain.Event) })
+
+		res, err := service.Do(&v1.TransitionUserStatusRequest{ID: userID, Action: "suspend", Reason: "abuse"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, string(model.UserSuspended), res.User.Status)
+		assert.Equal(t, "abuse", res.User.StatusReason)
+
+		e := <-published
+		assert.Equal(t, domain.UserStatusChangedEvent, e.Type)
+		assert.Equal(t, model.UserActive, e.StatusChange.From)
+		assert.Equal(t, model.UserSuspended, e.StatusChange.To)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Transition", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewTransitionUserStatusApplicationService(mockRepo, mockEventPublisher)
+
+		mockRepo.On("Get", userID).Return(newUser(model.UserPending), nil).Once()
+
+		res, err := service.Do(&v1.TransitionUserStatusRequest{ID: userID, Action: "unlock", Reason: "why not"})
+
+		assert.ErrorIs(t, err, model.ErrInvalidStatusTransition)
+		assert.Equal(t, &v1.TransitionUserStatusResponse{}, res)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockEventPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewTransitionUserStatusApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(&v1.TransitionUserStatusRequest{ID: userID, Action: "suspend", Reason: "abuse"})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
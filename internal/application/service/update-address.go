@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewUpdateAddressApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *UpdateAddressApplicationService {
+	return &UpdateAddressApplicationService{repository, publisher}
+}
+
+type UpdateAddressApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *UpdateAddressApplicationService) Do(req *v1.UpdateAddressRequest) (*v1.UpdateAddressResponse, error) {
+	address, err := model.NewAddress(req.Type, req.Lines, req.Locality, req.Region, req.PostalCode, req.Country)
+	if err != nil {
+		return &v1.UpdateAddressResponse{}, err
+	}
+	address.ID = req.AddressID
+
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.UpdateAddressResponse{}, err
+	}
+
+	err = user.ReplaceAddress(address)
+	if err != nil {
+		return &v1.UpdateAddressResponse{}, err
+	}
+
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return &v1.UpdateAddressResponse{}, err
+	}
+
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return &v1.UpdateAddressResponse{Address: address.ToDTO()}, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateAddressApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddAddress(&model.Address{ID: "address-1", Type: model.AddressHome, Lines: []string{"Street 1"}, Locality: "Berlin", PostalCode: "10117", Country: "DE"})
+		return user
+	}
+	req := &v1.UpdateAddressRequest{
+		UserID:     userID,
+		AddressID:  "address-1",
+		Type:       "home",
+		Lines:      []string{"Marienplatz 8"},
+		Locality:   "Munich",
+		PostalCode: "80331",
+		Country:    "DE",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateAddressApplicationService(mockRepo, mockEventPublisher)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("Update", userID, mock.Anything).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &v1.Address{ID: "address-1", Type: "home", Lines: []string{"Marienplatz 8"}, Locality: "Munich", PostalCode: "80331", Country: "DE"},
+			res.Address)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Address Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateAddressApplicationService(mockRepo, nil)
+
+		missing := *req
+		missing.AddressID = "missing"
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		res, err := service.Do(&missing)
+
+		assert.ErrorIs(t, err, model.ErrAddressNotFound)
+		assert.Equal(t, &v1.UpdateAddressResponse{}, res)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewUpdateContactPointApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *UpdateContactPointApplicationService {
+	return &UpdateContactPointApplicationService{repository, publisher}
+}
+
+type UpdateContactPointApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *UpdateContactPointApplicationService) Do(req *v1.UpdateContactPointRequest) (*v1.UpdateContactPointResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.UpdateContactPointResponse{}, err
+	}
+
+	contact, err := user.GetContactPoint(req.ContactID)
+	if err != nil {
+		return &v1.UpdateContactPointResponse{}, err
+	}
+
+	if req.Label != nil {
+		contact.Label = *req.Label
+	}
+
+	// phone numbers are verified by the caller, e.g. with a one-time code sent by SMS
+	if req.Verified != nil && *req.Verified && !contact.Verified {
+		if contact.Type == model.ContactEmail {
+			return &v1.UpdateContactPointResponse{}, model.ErrEmailVerificationRequired
+		}
+		err = user.VerifyContactPoint(contact.ID)
+		if err != nil {
+			return &v1.UpdateContactPointResponse{}, err
+		}
+	}
+
+	if req.Primary != nil && *req.Primary && !contact.Primary {
+		err = user.PromoteContactPoint(contact.ID)
+		if err != nil {
+			return &v1.UpdateContactPointResponse{}, err
+		}
+	}
+
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return &v1.UpdateContactPointResponse{}, err
+	}
+
+	publishUsersUpdated(s.publisher, []*model.User{user})
+
+	return &v1.UpdateContactPointResponse{User: user.ToDTO()}, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateContactPointApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "phone-1", Type: model.ContactPhone, Value: "+447911123456"})
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "phone-2", Type: model.ContactPhone, Value: "+447911654321"})
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "email-1", Type: model.ContactEmail, Value: "work@example.com"})
+		return user
+	}
+	yes := true
+
+	t.Run("Verify and promote a phone number", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateContactPointApplicationService(mockRepo, mockEventPublisher)
+
+		label := "work"
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("Update", userID, mock.Anything).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(&v1.UpdateContactPointRequest{UserID: userID, ContactID: "phone-2", Label: &label, Primary: &yes, Verified: &yes})
+
+		assert.NoError(t, err)
+		assert.False(t, res.User.Contacts[0].Primary)
+		assert.Equal(t, &v1.ContactPoint{ID: "phone-2", Type: "phone", Value: "+447911654321", Label: "work", Primary: true, Verified: true},
+			res.User.Contacts[1])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Email address can't be verified by the caller", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateContactPointApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		res, err := service.Do(&v1.UpdateContactPointRequest{UserID: userID, ContactID: "email-1", Verified: &yes})
+
+		assert.ErrorIs(t, err, model.ErrEmailVerificationRequired)
+		assert.Equal(t, &v1.UpdateContactPointResponse{}, res)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unverified email address can't be promoted", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateContactPointApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.UpdateContactPointRequest{UserID: userID, ContactID: "email-1", Primary: &yes})
+
+		assert.ErrorIs(t, err, model.ErrContactPointNotVerified)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Contact Point Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateContactPointApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.UpdateContactPointRequest{UserID: userID, ContactID: "missing"})
+
+		assert.ErrorIs(t, err, model.ErrContactPointNotFound)
+	})
+}
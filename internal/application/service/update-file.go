@@ -0,0 +1,80 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/event"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewUpdateFileApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *UpdateFileApplicationService {
+	return &UpdateFileApplicationService{repository, publisher}
+}
+
+// UpdateFileApplicationService edits the metadata of a file: its name, description, tags, labels and folder. The
+// content is left as is, a FileUpdated event is published.
+type UpdateFileApplicationService struct {
+	repository domain.UserRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *UpdateFileApplicationService) Do(req *v1.UpdateFileRequest) (*v1.UpdateFileResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.UpdateFileResponse{}, err
+	}
+	if !user.CanModifyFiles() {
+		return &v1.UpdateFileResponse{}, model.ErrFilesReadOnly
+	}
+
+	file, err := user.GetFile(req.FileID)
+	if err != nil {
+		return &v1.UpdateFileResponse{}, err
+	}
+
+	// renaming or moving the file only changes its name and folder, the content stays where it is stored
+	if req.Name != nil || req.FolderID != nil {
+		folderID, name := file.FolderID, file.Name
+		if req.FolderID != nil {
+			folderID = *req.FolderID
+			if err := checkFolder(s.repository, user.ID, folderID); err != nil {
+				return &v1.UpdateFileResponse{}, err
+			}
+		}
+		if req.Name != nil {
+			name = *req.Name
+		}
+		if err := user.MoveFile(file, folderID, name); err != nil {
+			return &v1.UpdateFileResponse{}, err
+		}
+	}
+	if req.Description != nil {
+		if err := file.SetDescription(*req.Description); err != nil {
+			return &v1.UpdateFileResponse{}, err
+		}
+	}
+	if req.Tags != nil {
+		if err := file.SetTags(req.Tags); err != nil {
+			return &v1.UpdateFileResponse{}, err
+		}
+	}
+	if req.Labels != nil {
+		if err := file.SetLabels(req.Labels); err != nil {
+			return &v1.UpdateFileResponse{}, err
+		}
+	}
+
+	if err := s.repository.UpdateFile(file); err != nil {
+		return &v1.UpdateFileResponse{}, err
+	}
+
+	go func() {
+		if err := s.publisher.Publish(event.NewFileUpdatedEvent(file)); err != nil {
+			log.Printf("Failed to publish file updated event: %v", err)
+		}
+	}()
+
+	return &v1.UpdateFileResponse{File: file.ToDTO()}, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateFileApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-1", UserID: userID, Name: "draft.txt", Tags: []string{"old"}})
+		user.AddFile(&model.File{ID: "file-2", UserID: userID, Name: "notes.txt"})
+		return user
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateFileApplicationService(mockRepo, mockEventPublisher)
+
+		published := make(chan *domain.Event, 1)
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		// the file was stored by name, its content stays under the old one
+		mockRepo.On("UpdateFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.Name == "report.txt" && f.StorageKey == "draft.txt"
+		})).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once().
+			Run(func(args mock.Arguments) { published <- args.Get(0).(*domain.Event) })
+
+		name, description := " report.txt ", "Quarterly report"
+		res, err := service.Do(&v1.UpdateFileRequest{
+			UserID: userID, FileID: "file-1", Name: &name, Description: &description,
+			Tags: []string{"Work", "work", "q3"}, Labels: map[string]string{"project": "apollo"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "report.txt", res.File.Name)
+		assert.Equal(t, "Quarterly report", res.File.Description)
+		assert.Equal(t, []string{"work", "q3"}, res.File.Tags)
+		assert.Equal(t, map[string]string{"project": "apollo"}, res.File.Labels)
+
+		e := <-published
+		assert.Equal(t, domain.FileUpdatedEvent, e.Type)
+		assert.Equal(t, userID, e.UserID)
+		assert.Equal(t, "file-1", e.File.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unset Fields Are Kept", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateFileApplicationService(mockRepo, mockEventPublisher)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("UpdateFile", mock.AnythingOfType("*model.File")).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		description := "Kept tags"
+		res, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", Description: &description})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "draft.txt", res.File.Name)
+		assert.Equal(t, []string{"old"}, res.File.Tags)
+	})
+
+	t.Run("Move To Folder", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateFileApplicationService(mockRepo, mockEventPublisher)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockRepo.On("UpdateFile", mock.MatchedBy(func(f *model.File) bool { return f.FolderID == "2024" })).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		folderID := "2024"
+		res, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", FolderID: &folderID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2024", res.File.FolderID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Folder Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFileApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		folderID := "unknown"
+		_, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", FolderID: &folderID})
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+		mockRepo.AssertNotCalled(t, "UpdateFile", mock.Anything)
+	})
+
+	t.Run("Name Taken", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFileApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		name := "notes.txt"
+		_, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", Name: &name})
+
+		assert.ErrorIs(t, err, model.ErrFileNameTaken)
+		mockRepo.AssertNotCalled(t, "UpdateFile", mock.Anything)
+	})
+
+	t.Run("Name Taken In Folder", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFileApplicationService(mockRepo, nil)
+
+		user := newUser()
+		user.AddFile(&model.File{ID: "file-3", UserID: userID, Name: "draft.txt", FolderID: "2024"})
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		folderID := "2024"
+		_, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", FolderID: &folderID})
+
+		assert.ErrorIs(t, err, model.ErrFileNameTaken)
+		mockRepo.AssertNotCalled(t, "UpdateFile", mock.Anything)
+	})
+
+	t.Run("Rename And Move", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateFileApplicationService(mockRepo, mockEventPublisher)
+
+		// the name is only taken at the root, where the file leaves
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockRepo.On("UpdateFile", mock.MatchedBy(func(f *model.File) bool {
+			return f.FolderID == "2024" && f.Name == "notes.txt"
+		})).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		name, folderID := "notes.txt", "2024"
+		res, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", Name: &name, FolderID: &folderID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "notes.txt", res.File.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Label", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFileApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", Labels: map[string]string{"a b": "c"}})
+
+		assert.ErrorIs(t, err, model.ErrInvalidFileLabel)
+		mockRepo.AssertNotCalled(t, "UpdateFile", mock.Anything)
+	})
+
+	t.Run("Files Read-Only", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFileApplicationService(mockRepo, nil)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		description := "Nope"
+		_, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", Description: &description})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+	})
+
+	t.Run("File Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFileApplicationService(mockRepo, nil)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+
+		_, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-9"})
+
+		assert.ErrorIs(t, err, model.ErrFileNotFound)
+	})
+
+	t.Run("Update Fails", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateFileApplicationService(mockRepo, mockEventPublisher)
+		updateErr := errors.New("db down")
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("UpdateFile", mock.AnythingOfType("*model.File")).Return(updateErr).Once()
+
+		_, err := service.Do(&v1.UpdateFileRequest{UserID: userID, FileID: "file-1", Tags: []string{}})
+
+		assert.ErrorIs(t, err, updateErr)
+		mockEventPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewUpdateFolderApplicationService(repository domain.UserRepository) *UpdateFolderApplicationService {
+	return &UpdateFolderApplicationService{repository}
+}
+
+// UpdateFolderApplicationService renames a folder or moves it with everything in it to another parent. Only the
+// folder is updated, the files and subfolders in it follow.
+type UpdateFolderApplicationService struct {
+	repository domain.UserRepository
+}
+
+func (s *UpdateFolderApplicationService) Do(req *v1.UpdateFolderRequest) (*v1.UpdateFolderResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.UpdateFolderResponse{}, err
+	}
+	if !user.CanModifyFiles() {
+		return &v1.UpdateFolderResponse{}, model.ErrFilesReadOnly
+	}
+
+	folders, err := s.repository.GetFolders(user.ID)
+	if err != nil {
+		return &v1.UpdateFolderResponse{}, err
+	}
+	tree := model.NewFolderTree(folders)
+	folder, err := tree.Get(req.FolderID)
+	if err != nil {
+		return &v1.UpdateFolderResponse{}, err
+	}
+
+	if req.Name != nil {
+		if err := tree.Rename(folder, *req.Name); err != nil {
+			return &v1.UpdateFolderResponse{}, err
+		}
+	}
+	if req.ParentID != nil {
+		if err := tree.Move(folder, *req.ParentID); err != nil {
+			return &v1.UpdateFolderResponse{}, err
+		}
+	}
+
+	if err := s.repository.UpdateFolder(folder); err != nil {
+		return &v1.UpdateFolderResponse{}, err
+	}
+
+	return &v1.UpdateFolderResponse{Folder: tree.ToDTO(folder)}, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateFolderApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		return user
+	}
+
+	t.Run("Rename", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockRepo.On("UpdateFolder", mock.MatchedBy(func(f *model.Folder) bool {
+			return f.ID == "documents" && f.Name == "papers" && f.ParentID == ""
+		})).Return(nil).Once()
+
+		name := "papers"
+		res, err := service.Do(&v1.UpdateFolderRequest{UserID: userID, FolderID: "documents", Name: &name})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/papers", res.Folder.Path)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+		mockRepo.On("UpdateFolder", mock.MatchedBy(func(f *model.Folder) bool {
+			return f.ID == "2024" && f.ParentID == "photos"
+		})).Return(nil).Once()
+
+		parentID := "photos"
+		res, err := service.Do(&v1.UpdateFolderRequest{UserID: userID, FolderID: "2024", ParentID: &parentID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/photos/2024", res.Folder.Path)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Move Into Subfolder", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		parentID := "2024"
+		_, err := service.Do(&v1.UpdateFolderRequest{UserID: userID, FolderID: "documents", ParentID: &parentID})
+
+		assert.ErrorIs(t, err, model.ErrInvalidFolderMove)
+		mockRepo.AssertNotCalled(t, "UpdateFolder", mock.Anything)
+	})
+
+	t.Run("Name Taken", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		name := "Photos"
+		_, err := service.Do(&v1.UpdateFolderRequest{UserID: userID, FolderID: "documents", Name: &name})
+
+		assert.ErrorIs(t, err, model.ErrFolderNameTaken)
+		mockRepo.AssertNotCalled(t, "UpdateFolder", mock.Anything)
+	})
+
+	t.Run("Folder Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFolderApplicationService(mockRepo)
+
+		mockRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockRepo.On("GetFolders", userID).Return(newTestFolders(), nil).Once()
+
+		name := "papers"
+		_, err := service.Do(&v1.UpdateFolderRequest{UserID: userID, FolderID: "unknown", Name: &name})
+
+		assert.ErrorIs(t, err, model.ErrFolderNotFound)
+	})
+
+	t.Run("Suspended User", func(t *testing.T) {
+		mockRepo := new(mocks.UserRepository)
+		service := NewUpdateFolderApplicationService(mockRepo)
+
+		user := newUser()
+		user.RestoreStatus(model.UserSuspended, "abuse")
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+
+		name := "papers"
+		_, err := service.Do(&v1.UpdateFolderRequest{UserID: userID, FolderID: "documents", Name: &name})
+
+		assert.ErrorIs(t, err, model.ErrFilesReadOnly)
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewUpdateGroupApplicationService(
+	groups domain.GroupRepository,
+	roles domain.RoleRepository,
+	users domain.UserRepository,
+	publisher domain.EventPublisher,
+) *UpdateGroupApplicationService {
+	return &UpdateGroupApplicationService{groups, roles, users, publisher}
+}
+
+// UpdateGroupApplicationService updates groups, members are notified when the group's roles change
+type UpdateGroupApplicationService struct {
+	groups    domain.GroupRepository
+	roles     domain.RoleRepository
+	users     domain.UserRepository
+	publisher domain.EventPublisher
+}
+
+func (s *UpdateGroupApplicationService) Do(req *v1.UpdateGroupRequest) (*v1.UpdateGroupResponse, error) {
+	group, err := s.groups.Get(req.ID)
+	if err != nil {
+		return &v1.UpdateGroupResponse{}, err
+	}
+
+	if req.Name != "" {
+		err = group.SetName(req.Name)
+		if err != nil {
+			return &v1.UpdateGroupResponse{}, err
+		}
+	}
+
+	if req.Description != nil {
+		group.SetDescription(*req.Description)
+	}
+
+	rolesChanged := false
+	if req.Roles != nil {
+		previousRoles := group.GetRoles()
+		err = group.SetRoles(req.Roles)
+		if err != nil {
+			return &v1.UpdateGroupResponse{}, err
+		}
+		err = checkRolesExist(s.roles, group.GetRoles())
+		if err != nil {
+			return &v1.UpdateGroupResponse{}, err
+		}
+		rolesChanged = !slices.Equal(previousRoles, group.GetRoles())
+	}
+
+	err = s.groups.Update(group)
+	if err != nil {
+		return &v1.UpdateGroupResponse{}, err
+	}
+
+	if rolesChanged {
+		members, err := s.users.List(&domain.UserFilter{GroupID: group.ID})
+		if err != nil {
+			return &v1.UpdateGroupResponse{}, err
+		}
+		publishUsersUpdated(s.publisher, members)
+	}
+
+	return &v1.UpdateGroupResponse{Group: group.ToDTO()}, nil
+}
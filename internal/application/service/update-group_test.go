@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateGroupApplicationService_Do(t *testing.T) {
+	groupID := "group-123"
+
+	newGroup := func() *model.Group {
+		group, _ := model.NewGroup("Editors", "Content editors", []string{"editor"})
+		group.ID = groupID
+		return group
+	}
+
+	t.Run("Roles Changed", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockRoleRepo := new(mocks.RoleRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewUpdateGroupApplicationService(mockGroupRepo, mockRoleRepo, mockUserRepo, mockEventPublisher)
+
+		member, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		member.ID = "user-123"
+
+		mockGroupRepo.On("Get", groupID).Return(newGroup(), nil).Once()
+		mockRoleRepo.On("Get", "editor").Return(&model.Role{Name: "editor"}, nil).Once()
+		mockRoleRepo.On("Get", "publisher").Return(&model.Role{Name: "publisher"}, nil).Once()
+		mockGroupRepo.On("Update", mock.AnythingOfType("*model.Group")).Return(nil).Once()
+		mockUserRepo.On("List", &domain.UserFilter{GroupID: groupID}).Return([]*model.User{member}, nil).Once()
+		mockEventPublisher.On("Publish", mock.MatchedBy(func(e *domain.Event) bool {
+			return e.Type == domain.UserUpdatedEvent && e.UserID == "user-123"
+		})).Return(nil).Once()
+
+		res, err := service.Do(&v1.UpdateGroupRequest{ID: groupID, Roles: []string{"editor", "publisher"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"editor", "publisher"}, res.Group.Roles)
+		assert.Equal(t, "Content editors", res.Group.Description)
+		assert.Eventually(t, func() bool {
+			return len(mockEventPublisher.Calls) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Roles Unchanged", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		service := NewUpdateGroupApplicationService(mockGroupRepo, nil, mockUserRepo, nil)
+
+		description := ""
+		mockGroupRepo.On("Get", groupID).Return(newGroup(), nil).Once()
+		mockGroupRepo.On("Update", mock.AnythingOfType("*model.Group")).Return(nil).Once()
+
+		res, err := service.Do(&v1.UpdateGroupRequest{ID: groupID, Name: "Writers", Description: &description})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &v1.Group{ID: groupID, Name: "Writers", Roles: []string{"editor"}}, res.Group)
+		mockUserRepo.AssertNotCalled(t, "List", mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockGroupRepo := new(mocks.GroupRepository)
+		service := NewUpdateGroupApplicationService(mockGroupRepo, nil, nil, nil)
+
+		mockGroupRepo.On("Get", groupID).Return(nil, domain.ErrGroupNotFound).Once()
+
+		res, err := service.Do(&v1.UpdateGroupRequest{ID: groupID, Name: "Writers"})
+
+		assert.ErrorIs(t, err, domain.ErrGroupNotFound)
+		assert.Equal(t, &v1.UpdateGroupResponse{}, res)
+	})
+}
@@ -1,20 +1,29 @@
 package service
 
 import (
+	"errors"
 	"log"
 
 	"github.com/bizio/abc-user-service/internal/domain"
 	"github.com/bizio/abc-user-service/internal/domain/event"
+	"github.com/bizio/abc-user-service/internal/domain/model"
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
 )
 
-func NewUpdateUserApplicationService(repository domain.UserRepository, publisher domain.EventPublisher) *UpdateUserApplicationService {
-	return &UpdateUserApplicationService{repository, publisher}
+func NewUpdateUserApplicationService(
+	repository domain.UserRepository,
+	publisher domain.EventPublisher,
+	verifier *EmailVerifier,
+	schema domain.AttributeSchema,
+) *UpdateUserApplicationService {
+	return &UpdateUserApplicationService{repository, publisher, verifier, schema}
 }
 
 type UpdateUserApplicationService struct {
 	repository domain.UserRepository
 	publisher  domain.EventPublisher
+	verifier   *EmailVerifier
+	schema     domain.AttributeSchema
 }
 
 func (s *UpdateUserApplicationService) Do(req *v1.UpdateUserRequest) (*v1.UpdateUserResponse, error) {
@@ -28,11 +37,25 @@ func (s *UpdateUserApplicationService) Do(req *v1.UpdateUserRequest) (*v1.Update
 		user.SetName(req.Name)
 	}
 
+	emailChanged := false
 	if req.Email != "" {
+		previousEmail := user.ToDTO().Email
 		err = user.SetEmail(req.Email)
 		if err != nil {
 			return &v1.UpdateUserResponse{}, err
 		}
+		emailChanged = user.ToDTO().Email != previousEmail
+	}
+
+	// an email address identifies a single user, including as a verified contact point
+	if emailChanged {
+		owner, err := s.repository.GetByEmail(user.ToDTO().Email)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return &v1.UpdateUserResponse{}, err
+		}
+		if owner != nil && owner.ID != user.ID {
+			return &v1.UpdateUserResponse{}, model.ErrContactPointAlreadyExists
+		}
 	}
 
 	if req.DOB != "" {
@@ -42,11 +65,29 @@ func (s *UpdateUserApplicationService) Do(req *v1.UpdateUserRequest) (*v1.Update
 		}
 	}
 
+	if req.Attributes != nil {
+		err = user.SetAttributes(req.Attributes)
+		if err != nil {
+			return &v1.UpdateUserResponse{}, err
+		}
+		err = s.schema.Validate(user.GetAttributes())
+		if err != nil {
+			return &v1.UpdateUserResponse{}, err
+		}
+	}
+
 	err = s.repository.Update(req.ID, user)
 	if err != nil {
 		return &v1.UpdateUserResponse{}, err
 	}
 
+	if emailChanged {
+		err = s.verifier.Send(user)
+		if err != nil {
+			log.Printf("Failed to send verification email: %v", err)
+		}
+	}
+
 	go func() {
 		err = s.publisher.Publish(event.NewUserUpdatedEvent(user))
 		if err != nil {
@@ -27,7 +27,8 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, mockTokenRepo, mockMailer := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.UpdateUserRequest{
 			ID:    userID,
@@ -37,8 +38,12 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 		}
 
 		mockRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockRepo.On("GetByEmail", req.Email).Return(nil, domain.ErrUserNotFound).Once()
 		mockRepo.On("Update", userID, mock.Anything).Return(nil).Once()
 		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once()
+		mockTokenRepo.On("DeleteByUser", userID).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.Anything).Return(nil).Once()
+		mockMailer.On("Send", req.Email, mock.Anything, mock.Anything).Return(nil).Once()
 
 		res, err := service.Do(req)
 
@@ -47,13 +52,36 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 		assert.Equal(t, req.Name, res.User.Name)
 		assert.Equal(t, req.Email, res.User.Email)
 		assert.Equal(t, req.DOB, res.User.DOB)
+		assert.False(t, res.User.EmailVerified)
 		mockRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Email Of Another User", func(t *testing.T) {
+		userCopy, _ := model.NewUser("Old Name", "old.email@example.com", "1990-01-01")
+		userCopy.ID = userID
+		other, _ := model.NewUser("Other", "other@example.com", "1990-01-01")
+		other.ID = "user-456"
+
+		mockRepo := new(mocks.UserRepository)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, nil, verifier, newTestAttributeSchema())
+
+		// the address is a verified contact point of the other user
+		mockRepo.On("Get", userID).Return(userCopy, nil).Once()
+		mockRepo.On("GetByEmail", "work@example.com").Return(other, nil).Once()
+
+		_, err := service.Do(&v1.UpdateUserRequest{ID: userID, Email: "work@example.com"})
+
+		assert.ErrorIs(t, err, model.ErrContactPointAlreadyExists)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	})
 
 	t.Run("User Not Found", func(t *testing.T) {
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.UpdateUserRequest{ID: "not-found-id"}
 
@@ -72,7 +100,8 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.UpdateUserRequest{
 			ID:    userID,
@@ -94,7 +123,8 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.UpdateUserRequest{
 			ID:  userID,
@@ -118,7 +148,8 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.UpdateUserRequest{
 			ID:  userID,
@@ -140,7 +171,8 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 
 		mockRepo := new(mocks.UserRepository)
 		mockEventPublisher := new(mocks.EventPublisher)
-		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher, verifier, newTestAttributeSchema())
 
 		req := &v1.UpdateUserRequest{
 			ID:   userID,
@@ -158,4 +190,48 @@ func TestUpdateUserApplicationService_Do(t *testing.T) {
 		assert.Equal(t, &v1.UpdateUserResponse{}, res)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Replace Attributes", func(t *testing.T) {
+		user, _ := model.NewUser("Old Name", "old.email@example.com", "1990-01-01")
+		user.ID = userID
+		_ = user.SetAttributes(map[string]any{"department": "sales", "floor": 2.0})
+
+		mockRepo := new(mocks.UserRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		mockSchema := new(mocks.AttributeSchema)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, mockEventPublisher, verifier, mockSchema)
+
+		attributes := map[string]any{"department": "support"}
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+		mockSchema.On("Validate", attributes).Return(nil).Once()
+		mockRepo.On("Update", userID, user).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Maybe()
+
+		res, err := service.Do(&v1.UpdateUserRequest{ID: userID, Attributes: attributes})
+
+		assert.NoError(t, err)
+		assert.Equal(t, attributes, res.User.Attributes)
+		mockSchema.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Attributes", func(t *testing.T) {
+		user, _ := model.NewUser("Old Name", "old.email@example.com", "1990-01-01")
+		user.ID = userID
+
+		mockRepo := new(mocks.UserRepository)
+		mockSchema := new(mocks.AttributeSchema)
+		verifier, _, _ := newTestEmailVerifier()
+		service := NewUpdateUserApplicationService(mockRepo, nil, verifier, mockSchema)
+
+		attributes := map[string]any{"floor": "second"}
+		mockRepo.On("Get", userID).Return(user, nil).Once()
+		mockSchema.On("Validate", attributes).Return(domain.ErrInvalidAttributes).Once()
+
+		res, err := service.Do(&v1.UpdateUserRequest{ID: userID, Attributes: attributes})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAttributes)
+		assert.Equal(t, &v1.UpdateUserResponse{}, res)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
 }
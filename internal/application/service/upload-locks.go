@@ -0,0 +1,30 @@
+package service
+
+import "sync"
+
+// UploadLocks makes sure a resumable upload receives one chunk at a time and isn't deleted while receiving one
+type UploadLocks struct {
+	mu   sync.Mutex
+	held map[string]struct{}
+}
+
+func NewUploadLocks() *UploadLocks {
+	return &UploadLocks{held: make(map[string]struct{})}
+}
+
+// TryLock locks the upload unless it's already locked
+func (l *UploadLocks) TryLock(uploadID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.held[uploadID]; ok {
+		return false
+	}
+	l.held[uploadID] = struct{}{}
+	return true
+}
+
+func (l *UploadLocks) Unlock(uploadID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.held, uploadID)
+}
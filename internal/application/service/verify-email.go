@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/event"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewVerifyEmailApplicationService(
+	repository domain.UserRepository,
+	tokens domain.VerificationTokenRepository,
+	publisher domain.EventPublisher) *VerifyEmailApplicationService {
+	return &VerifyEmailApplicationService{repository, tokens, publisher}
+}
+
+type VerifyEmailApplicationService struct {
+	repository domain.UserRepository
+	tokens     domain.VerificationTokenRepository
+	publisher  domain.EventPublisher
+}
+
+func (s *VerifyEmailApplicationService) Do(req *v1.VerifyEmailRequest) (*v1.VerifyEmailResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.VerifyEmailResponse{}, err
+	}
+
+	token, err := s.tokens.GetByHash(model.HashVerificationToken(req.Token))
+	if err != nil {
+		return &v1.VerifyEmailResponse{}, err
+	}
+
+	// a token issued for a previous address can't verify the current one,
+	// a token issued for a secondary address verifies its contact point
+	contact := user.FindContactPoint(model.ContactEmail, token.Email)
+	if token.UserID != user.ID || (token.Email != user.ToDTO().Email && contact == nil) {
+		return &v1.VerifyEmailResponse{}, model.ErrInvalidVerificationToken
+	}
+
+	if token.IsExpired(time.Now()) {
+		return &v1.VerifyEmailResponse{}, model.ErrVerificationTokenExpired
+	}
+
+	if contact != nil {
+		// another user may have verified the address in the meantime
+		owner, err := s.repository.GetByEmail(contact.Value)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return &v1.VerifyEmailResponse{}, err
+		}
+		if owner != nil && owner.ID != user.ID {
+			return &v1.VerifyEmailResponse{}, model.ErrContactPointAlreadyExists
+		}
+		contact.Verified = true
+	} else {
+		user.VerifyEmail()
+	}
+	err = s.repository.Update(user.ID, user)
+	if err != nil {
+		return &v1.VerifyEmailResponse{}, err
+	}
+
+	// tokens are single-use
+	err = s.tokens.DeleteByUser(user.ID)
+	if err != nil {
+		log.Printf("error deleting verification tokens of user %s: %s", user.ID, err)
+	}
+
+	if contact != nil {
+		publishUsersUpdated(s.publisher, []*model.User{user})
+		return &v1.VerifyEmailResponse{User: user.ToDTO()}, nil
+	}
+
+	go func() {
+		err := s.publisher.Publish(event.NewUserEmailVerifiedEvent(user))
+		if err != nil {
+			log.Printf("Failed to publish user email verified event: %v", err)
+		}
+	}()
+
+	return &v1.VerifyEmailResponse{User: user.ToDTO()}, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVerifyEmailApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	req := &v1.VerifyEmailRequest{UserID: userID, Token: "plain-token"}
+
+	newUser := func() *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		return user
+	}
+	newToken := func(email string, expiresAt time.Time) *model.VerificationToken {
+		return &model.VerificationToken{ID: "token-1", UserID: userID, Email: email, Hash: model.HashVerificationToken(req.Token), ExpiresAt: expiresAt}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.VerificationTokenRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewVerifyEmailApplicationService(mockUserRepo, mockTokenRepo, mockEventPublisher)
+
+		published := make(chan *domain.Event, 1)
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockTokenRepo.On("GetByHash", model.HashVerificationToken(req.Token)).Return(newToken("test@example.com", time.Now().Add(time.Hour)), nil).Once()
+		mockUserRepo.On("Update", userID, mock.MatchedBy(func(u *model.User) bool { return u.IsEmailVerified() })).Return(nil).Once()
+		mockTokenRepo.On("DeleteByUser", userID).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once().
+			Run(func(args mock.Arguments) { published <- args.Get(0).(*domain.Event) })
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.True(t, res.User.EmailVerified)
+		assert.Equal(t, domain.UserEmailVerifiedEvent, (<-published).Type)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.VerificationTokenRepository)
+		service := NewVerifyEmailApplicationService(mockUserRepo, mockTokenRepo, nil)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockTokenRepo.On("GetByHash", mock.Anything).Return(nil, model.ErrInvalidVerificationToken).Once()
+
+		res, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrInvalidVerificationToken)
+		assert.Equal(t, &v1.VerifyEmailResponse{}, res)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Token For Previous Address", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.VerificationTokenRepository)
+		service := NewVerifyEmailApplicationService(mockUserRepo, mockTokenRepo, nil)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockTokenRepo.On("GetByHash", mock.Anything).Return(newToken("old@example.com", time.Now().Add(time.Hour)), nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrInvalidVerificationToken)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.VerificationTokenRepository)
+		service := NewVerifyEmailApplicationService(mockUserRepo, mockTokenRepo, nil)
+
+		mockUserRepo.On("Get", userID).Return(newUser(), nil).Once()
+		mockTokenRepo.On("GetByHash", mock.Anything).Return(newToken("test@example.com", time.Now().Add(-time.Minute)), nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrVerificationTokenExpired)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Token For Secondary Address", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.VerificationTokenRepository)
+		mockEventPublisher := new(mocks.EventPublisher)
+		service := NewVerifyEmailApplicationService(mockUserRepo, mockTokenRepo, mockEventPublisher)
+
+		user := newUser()
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "email-1", Type: model.ContactEmail, Value: "work@example.com"})
+
+		published := make(chan *domain.Event, 1)
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockTokenRepo.On("GetByHash", mock.Anything).Return(newToken("work@example.com", time.Now().Add(time.Hour)), nil).Once()
+		mockUserRepo.On("GetByEmail", "work@example.com").Return(nil, domain.ErrUserNotFound).Once()
+		mockUserRepo.On("Update", userID, mock.Anything).Return(nil).Once()
+		mockTokenRepo.On("DeleteByUser", userID).Return(nil).Once()
+		mockEventPublisher.On("Publish", mock.Anything).Return(nil).Once().
+			Run(func(args mock.Arguments) { published <- args.Get(0).(*domain.Event) })
+
+		res, err := service.Do(req)
+
+		assert.NoError(t, err)
+		assert.False(t, res.User.EmailVerified)
+		assert.True(t, res.User.Contacts[0].Verified)
+		assert.Equal(t, domain.UserUpdatedEvent, (<-published).Type)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Secondary Address Verified By Another User", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.VerificationTokenRepository)
+		service := NewVerifyEmailApplicationService(mockUserRepo, mockTokenRepo, nil)
+
+		user := newUser()
+		_ = user.AddContactPoint(&model.ContactPoint{ID: "email-1", Type: model.ContactEmail, Value: "work@example.com"})
+		other, _ := model.NewUser("Other User", "work@example.com", "1990-01-01")
+		other.ID = "user-456"
+
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockTokenRepo.On("GetByHash", mock.Anything).Return(newToken("work@example.com", time.Now().Add(time.Hour)), nil).Once()
+		mockUserRepo.On("GetByEmail", "work@example.com").Return(other, nil).Once()
+
+		_, err := service.Do(req)
+
+		assert.ErrorIs(t, err, model.ErrContactPointAlreadyExists)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
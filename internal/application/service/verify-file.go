@@ -0,0 +1,66 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+func NewVerifyFileApplicationService(repository domain.UserRepository, storage domain.FileRepository) *VerifyFileApplicationService {
+	return &VerifyFileApplicationService{repository, storage}
+}
+
+// VerifyFileApplicationService re-hashes the stored content of a file and flags it if it no longer matches its digest
+type VerifyFileApplicationService struct {
+	repository domain.UserRepository
+	storage    domain.FileRepository
+}
+
+func (s *VerifyFileApplicationService) Do(req *v1.VerifyFileRequest) (*v1.VerifyFileResponse, error) {
+	user, err := s.repository.Get(req.UserID)
+	if err != nil {
+		return &v1.VerifyFileResponse{}, err
+	}
+
+	file, err := user.GetFile(req.FileID)
+	if err != nil {
+		return &v1.VerifyFileResponse{}, err
+	}
+
+	changed, err := checkFileIntegrity(s.storage, file)
+	if err != nil {
+		return &v1.VerifyFileResponse{}, err
+	}
+	if changed {
+		if err := s.repository.Update(user.ID, user); err != nil {
+			return &v1.VerifyFileResponse{}, err
+		}
+	}
+
+	return &v1.VerifyFileResponse{File: file.ToDTO()}, nil
+}
+
+// checkFileIntegrity hashes the stored content of the file and tells whether its digest or corruption flag changed
+func checkFileIntegrity(storage domain.FileRepository, file *model.File) (bool, error) {
+	content, err := storage.Get(file.UserID, file.StorageName())
+	if err != nil {
+		return false, err
+	}
+	defer content.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return false, err
+	}
+
+	digest, corrupted := file.Digest, file.Corrupted
+	if !file.CheckIntegrity(hex.EncodeToString(hash.Sum(nil))) {
+		log.Printf("file %s of user %s is corrupted", file.ID, file.UserID)
+	}
+	return digest != file.Digest || corrupted != file.Corrupted, nil
+}
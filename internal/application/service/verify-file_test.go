@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// helloDigest is the SHA-256 of "hello"
+const helloDigest = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+// newTestBlob opens a temporary file with the content, as returned by a FileRepository
+func newTestBlob(t *testing.T, content string) *os.File {
+	name := filepath.Join(t.TempDir(), "blob")
+	assert.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	f, err := os.Open(name)
+	assert.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestVerifyFileApplicationService_Do(t *testing.T) {
+	userID := "user-123"
+	newUser := func(file *model.File) *model.User {
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(file)
+		return user
+	}
+
+	t.Run("Intact", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewVerifyFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Digest: helloDigest})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "hello.txt").Return(newTestBlob(t, "hello"), nil).Once()
+
+		res, err := service.Do(&v1.VerifyFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.NoError(t, err)
+		assert.False(t, res.File.Corrupted)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewVerifyFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Digest: helloDigest})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "hello.txt").Return(newTestBlob(t, "hellO"), nil).Once()
+		mockUserRepo.On("Update", userID, mock.MatchedBy(func(u *model.User) bool {
+			return u.GetFiles()[0].Corrupted
+		})).Return(nil).Once()
+
+		res, err := service.Do(&v1.VerifyFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.NoError(t, err)
+		assert.True(t, res.File.Corrupted)
+		assert.Equal(t, helloDigest, res.File.Digest)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Digest Recorded", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewVerifyFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt"})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "hello.txt").Return(newTestBlob(t, "hello"), nil).Once()
+		mockUserRepo.On("Update", userID, mock.Anything).Return(nil).Once()
+
+		res, err := service.Do(&v1.VerifyFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, helloDigest, res.File.Digest)
+		assert.False(t, res.File.Corrupted)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("File Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewVerifyFileApplicationService(mockUserRepo, mockFileRepo)
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt"})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+
+		_, err := service.Do(&v1.VerifyFileRequest{UserID: userID, FileID: "missing"})
+
+		assert.ErrorIs(t, err, model.ErrFileNotFound)
+	})
+
+	t.Run("Storage Fails", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewVerifyFileApplicationService(mockUserRepo, mockFileRepo)
+		storageErr := errors.New("disk failure")
+
+		user := newUser(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Digest: helloDigest})
+		mockUserRepo.On("Get", userID).Return(user, nil).Once()
+		mockFileRepo.On("Get", userID, "hello.txt").Return(nil, storageErr).Once()
+
+		_, err := service.Do(&v1.VerifyFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.ErrorIs(t, err, storageErr)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		service := NewVerifyFileApplicationService(mockUserRepo, mockFileRepo)
+
+		mockUserRepo.On("Get", userID).Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := service.Do(&v1.VerifyFileRequest{UserID: userID, FileID: "file-123"})
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "io"
+
+// ArchiveRepository stores generated archives, such as data subject exports
+//
+//go:generate mockery --name ArchiveRepository --output ../../mocks --outpkg mocks
+type ArchiveRepository interface {
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	Delete(name string) error
+}
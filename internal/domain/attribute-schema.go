@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidAttributes           = errors.New("invalid attributes")
+	ErrAttributeSchemaIncompatible = errors.New("attribute schema doesn't match existing users")
+)
+
+// AttributeSchema validates the custom attributes of users against the deployment's schema
+//
+//go:generate mockery --name AttributeSchema --output ../../mocks --outpkg mocks
+type AttributeSchema interface {
+	// Validate returns an error wrapping ErrInvalidAttributes that describes the violations
+	Validate(attributes map[string]any) error
+	// Digest identifies the content of the schema
+	Digest() string
+}
+
+// AttributeSchemaRepository keeps track of the schema that existing users have been validated against
+//
+//go:generate mockery --name AttributeSchemaRepository --output ../../mocks --outpkg mocks
+type AttributeSchemaRepository interface {
+	// GetAcceptedDigest returns the digest of the last accepted schema, empty if none was accepted yet
+	GetAcceptedDigest() (string, error)
+	Accept(digest string, acceptedAt time.Time) error
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBlobReferenceNotFound = errors.New("blob reference not found")
+
+// BlobRepository reference-counts the blobs of content-addressed storage. Files with the same content, of any
+// user, share a blob referenced once by each of them.
+//
+//go:generate mockery --name BlobRepository --output ../../mocks --outpkg mocks
+type BlobRepository interface {
+	// Reference points the file of the user to the blob with the digest, creating the blob if it's new. It returns
+	// whether the content of the blob is stored already and the digest the file pointed to before, empty if none.
+	Reference(userID, filename, digest string, size int64) (stored bool, previous string, err error)
+	// MarkStored records that the content of the blob is stored
+	MarkStored(digest string) error
+	// Dereference removes the reference of the file and returns the digest it pointed to
+	Dereference(userID, filename string) (string, error)
+	// DereferenceAll removes the references of all the files of the user and returns the digests they pointed to
+	DereferenceAll(userID string) ([]string, error)
+	GetReference(userID, filename string) (string, error)
+	// ReferencedAt returns when the file was last pointed to a blob
+	ReferencedAt(userID, filename string) (time.Time, error)
+	// ListReferences returns the names of the files of the user
+	ListReferences(userID string) ([]string, error)
+	// ListUnreferenced returns the digests of the blobs no file points to
+	ListUnreferenced() ([]string, error)
+	// Collect forgets the blob if no file points to it, or if it's unknown, and calls remove to delete its content.
+	// The blob is locked meanwhile, so the same content can't be referenced while it's being deleted.
+	Collect(digest string, remove func() error) (bool, error)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+var ErrCredentialNotFound = errors.New("credential not found")
+
+//go:generate mockery --name CredentialRepository --output ../../mocks --outpkg mocks
+type CredentialRepository interface {
+	Get(userID string) (*model.Credential, error)
+	Save(credential *model.Credential) error
+	// RecordFailure counts a failed login without touching the password, locking the credential for the lockout
+	// once maxAttempts consecutive failures are reached
+	RecordFailure(userID string, now time.Time, maxAttempts int, lockout time.Duration) error
+	// RecordSuccess resets the failed logins without touching the password
+	RecordSuccess(userID string) error
+	Delete(userID string) error
+}
+
+//go:generate mockery --name PasswordResetTokenRepository --output ../../mocks --outpkg mocks
+type PasswordResetTokenRepository interface {
+	Create(token *model.PasswordResetToken) error
+	// Consume returns the token matching the hash and deletes all the tokens of its user, so a token is used
+	// once even by concurrent requests. It returns model.ErrInvalidResetToken when no token matches.
+	Consume(hash string) (*model.PasswordResetToken, error)
+	DeleteByUser(userID string) error
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+var ErrDataKeyNotFound = errors.New("data key not found")
+
+// DataKeyRepository stores the wrapped data keys of encrypted files, by user and file name
+//
+//go:generate mockery --name DataKeyRepository --output ../../mocks --outpkg mocks
+type DataKeyRepository interface {
+	// Save creates the data key of the file or replaces it
+	Save(key *model.DataKey) error
+	Get(userID, filename string) (*model.DataKey, error)
+	Delete(userID, filename string) error
+	DeleteAll(userID string) error
+	// ListWrappedWithOtherKey returns up to limit data keys wrapped with a master key other than the one with the ID
+	ListWrappedWithOtherKey(keyID string, limit int) ([]*model.DataKey, error)
+}
@@ -8,10 +8,20 @@ const (
 	UserCreatedEvent EventType = "UserCreated"
 	UserUpdatedEvent EventType = "UserUpdated"
 	UserDeletedEvent EventType = "UserDeleted"
+	UserErasedEvent  EventType = "UserErased"
+
+	UserEmailVerifiedEvent EventType = "UserEmailVerified"
+	UserStatusChangedEvent EventType = "UserStatusChanged"
+
+	FileQuarantinedEvent EventType = "FileQuarantined"
+	FileUpdatedEvent     EventType = "FileUpdated"
 )
 
 type Event struct {
-	Type   EventType
-	UserID string
-	User   *model.User
+	Type         EventType
+	UserID       string
+	User         *model.User
+	StatusChange *model.StatusChange `json:",omitempty"`
+	Quarantine   *model.Quarantine   `json:",omitempty"`
+	File         *model.File         `json:",omitempty"`
 }
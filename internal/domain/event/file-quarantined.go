@@ -0,0 +1,10 @@
+package event
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+func NewFileQuarantinedEvent(quarantine *model.Quarantine) *domain.Event {
+	return &domain.Event{Type: domain.FileQuarantinedEvent, UserID: quarantine.UserID, Quarantine: quarantine}
+}
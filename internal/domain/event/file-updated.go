@@ -0,0 +1,10 @@
+package event
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+func NewFileUpdatedEvent(file *model.File) *domain.Event {
+	return &domain.Event{Type: domain.FileUpdatedEvent, UserID: file.UserID, File: file}
+}
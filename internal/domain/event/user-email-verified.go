@@ -0,0 +1,10 @@
+package event
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+func NewUserEmailVerifiedEvent(user *model.User) *domain.Event {
+	return &domain.Event{Type: domain.UserEmailVerifiedEvent, UserID: user.ToDTO().ID, User: user}
+}
@@ -0,0 +1,7 @@
+package event
+
+import "github.com/bizio/abc-user-service/internal/domain"
+
+func NewUserErasedEvent(userID string) *domain.Event {
+	return &domain.Event{UserID: userID, Type: domain.UserErasedEvent}
+}
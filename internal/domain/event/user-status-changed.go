@@ -0,0 +1,10 @@
+package event
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+func NewUserStatusChangedEvent(user *model.User, change *model.StatusChange) *domain.Event {
+	return &domain.Event{Type: domain.UserStatusChangedEvent, UserID: user.ToDTO().ID, User: user, StatusChange: change}
+}
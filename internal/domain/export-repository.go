@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+var (
+	ErrExportNotFound = errors.New("export not found")
+	ErrExportNotReady = errors.New("export is not ready yet")
+	ErrExportExpired  = errors.New("export has expired")
+)
+
+//go:generate mockery --name ExportRepository --output ../../mocks --outpkg mocks
+type ExportRepository interface {
+	Create(export *model.Export) (string, error)
+	Get(userID, exportID string) (*model.Export, error)
+	Update(export *model.Export) error
+	List(userID string) ([]*model.Export, error)
+	// ListExpired returns the completed exports expired at the given time
+	ListExpired(now time.Time) ([]*model.Export, error)
+	Delete(userID, exportID string) error
+}
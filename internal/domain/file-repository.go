@@ -1,15 +1,37 @@
 package domain
 
 import (
+	"errors"
+	"io"
 	"mime/multipart"
-	"os"
+	"strings"
+	"time"
 )
 
+// ErrUnsafePath is returned for a user ID or file name that would resolve outside the storage of the user
+var ErrUnsafePath = errors.New("unsafe storage path")
+
+// IsPathElement tells whether a user ID or file name is a single path element, it can't name a parent or another
+// directory
+func IsPathElement(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\\x00")
+}
+
+// FileRepository stores the content of the files of the users. The file names are the storage names the service
+// generates, never the names sent by the clients.
+//
 //go:generate mockery --name FileRepository --output ../../mocks --outpkg mocks
 type FileRepository interface {
-	Upload(userID string, file *multipart.FileHeader) (string, error)
-	Get(userID, filename string) (*os.File, error)
+	// Upload stores the file under the name and returns its path and the hex SHA-256 of the content, computed
+	// while it's written
+	Upload(userID, filename string, file *multipart.FileHeader) (string, string, error)
+	Save(userID, filename string, content io.Reader) (string, error)
+	// Get returns the content of the file, an error matching fs.ErrNotExist if there isn't one
+	Get(userID, filename string) (io.ReadCloser, error)
 	List(userID string) ([]string, error)
+	// ModTime returns when the file was last written, an error matching fs.ErrNotExist if there isn't one
+	ModTime(userID, filename string) (time.Time, error)
+	// Delete deletes the file, a file that isn't there is deleted already and isn't an error
 	Delete(userID, filename string) error
 	DeleteFiles(userID string) error
 }
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+var (
+	ErrGroupNotFound       = errors.New("group not found")
+	ErrGroupAlreadyExists  = errors.New("group already exists")
+	ErrGroupMemberNotFound = errors.New("user is not a member of the group")
+)
+
+//go:generate mockery --name GroupRepository --output ../../mocks --outpkg mocks
+type GroupRepository interface {
+	Create(group *model.Group) (string, error)
+	Get(id string) (*model.Group, error)
+	List() ([]*model.Group, error)
+	Update(group *model.Group) error
+	// Delete removes the group and its memberships
+	Delete(id string) error
+	// AddMember adds the user to the group, adding an existing member is a no-op
+	AddMember(groupID, userID string) error
+	RemoveMember(groupID, userID string) error
+}
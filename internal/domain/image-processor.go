@@ -0,0 +1,23 @@
+package domain
+
+import "errors"
+
+var (
+	ErrUnsupportedImageType = errors.New("unsupported image type: use JPEG, PNG or WebP")
+	ErrImageTooLarge        = errors.New("image dimensions are too large")
+	ErrInvalidImage         = errors.New("invalid image")
+)
+
+// Thumbnail is a square image encoded without any metadata of the original
+type Thumbnail struct {
+	Size        int
+	ContentType string
+	Content     []byte
+}
+
+//go:generate mockery --name ImageProcessor --output ../../mocks --outpkg mocks
+type ImageProcessor interface {
+	// Thumbnails checks the real type and the dimensions of an image before decoding it,
+	// then encodes a thumbnail for each size
+	Thumbnails(content []byte, sizes []int) ([]*Thumbnail, error)
+}
@@ -0,0 +1,6 @@
+package domain
+
+//go:generate mockery --name Mailer --output ../../mocks --outpkg mocks
+type Mailer interface {
+	Send(to, subject, body string) error
+}
@@ -0,0 +1,200 @@
+package model
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var (
+	ErrInvalidAddressType  = errors.New("invalid address type: use home, work, billing, shipping or other")
+	ErrInvalidAddressLines = errors.New("invalid address lines: use 1 to 3 non-empty lines of at most 255 characters")
+	ErrInvalidLocality     = errors.New("invalid locality: it is required and at most 255 characters")
+	ErrInvalidRegion       = errors.New("invalid region: use at most 255 characters")
+	ErrInvalidCountry      = errors.New("invalid country: use an ISO 3166-1 alpha-2 code, e.g. GB")
+	ErrInvalidPostalCode   = errors.New("invalid postal code for the country")
+	ErrAddressNotFound     = errors.New("address not found")
+)
+
+type AddressType string
+
+const (
+	AddressHome     AddressType = "home"
+	AddressWork     AddressType = "work"
+	AddressBilling  AddressType = "billing"
+	AddressShipping AddressType = "shipping"
+	AddressOther    AddressType = "other"
+)
+
+const maxAddressLines = 3
+
+// countriesData maps every ISO 3166-1 alpha-2 code to the format of its postal codes, if the country has them
+//
+//go:embed countries.json
+var countriesData []byte
+
+type postalCodeRule struct {
+	pattern  *regexp.Regexp
+	required bool
+}
+
+var postalCodeRules = loadPostalCodeRules(countriesData)
+
+func loadPostalCodeRules(data []byte) map[string]postalCodeRule {
+	var countries map[string]struct {
+		PostalCode         string `json:"postalCode"`
+		PostalCodeRequired bool   `json:"postalCodeRequired"`
+	}
+	if err := json.Unmarshal(data, &countries); err != nil {
+		panic(err)
+	}
+
+	rules := make(map[string]postalCodeRule, len(countries))
+	for code, country := range countries {
+		rule := postalCodeRule{required: country.PostalCodeRequired}
+		if country.PostalCode != "" {
+			rule.pattern = regexp.MustCompile("^(?:" + country.PostalCode + ")$")
+		}
+		rules[code] = rule
+	}
+	return rules
+}
+
+// Address is a postal address of a user
+type Address struct {
+	ID         string
+	Type       AddressType
+	Lines      []string
+	Locality   string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// NewAddress validates and normalises an address. Postal codes are checked against the format of the country,
+// a country without postal codes accepts any.
+func NewAddress(addressType string, lines []string, locality, region, postalCode, country string) (*Address, error) {
+	switch AddressType(addressType) {
+	case AddressHome, AddressWork, AddressBilling, AddressShipping, AddressOther:
+	default:
+		return nil, ErrInvalidAddressType
+	}
+
+	normalizedLines := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || len(line) > 255 || strings.ContainsAny(line, "\r\n") {
+			return nil, ErrInvalidAddressLines
+		}
+		normalizedLines = append(normalizedLines, line)
+	}
+	if len(normalizedLines) == 0 || len(normalizedLines) > maxAddressLines {
+		return nil, ErrInvalidAddressLines
+	}
+
+	locality = strings.TrimSpace(locality)
+	if locality == "" || len(locality) > 255 {
+		return nil, ErrInvalidLocality
+	}
+
+	region = strings.TrimSpace(region)
+	if len(region) > 255 {
+		return nil, ErrInvalidRegion
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	postalCode, err := NormalizePostalCode(postalCode, country)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Address{
+		Type:       AddressType(addressType),
+		Lines:      normalizedLines,
+		Locality:   locality,
+		Region:     region,
+		PostalCode: postalCode,
+		Country:    country,
+	}, nil
+}
+
+// NormalizePostalCode uppercases a postal code, collapses its spaces and checks it against the country's format
+func NormalizePostalCode(postalCode, country string) (string, error) {
+	rule, ok := postalCodeRules[country]
+	if !ok {
+		return "", ErrInvalidCountry
+	}
+
+	postalCode = strings.ToUpper(strings.Join(strings.Fields(postalCode), " "))
+	if postalCode == "" {
+		if rule.required {
+			return "", ErrInvalidPostalCode
+		}
+		return "", nil
+	}
+	if len(postalCode) > 16 || (rule.pattern != nil && !rule.pattern.MatchString(postalCode)) {
+		return "", ErrInvalidPostalCode
+	}
+	return postalCode, nil
+}
+
+func (a *Address) ToDTO() *v1.Address {
+	return &v1.Address{
+		ID:         a.ID,
+		Type:       string(a.Type),
+		Lines:      slices.Clone(a.Lines),
+		Locality:   a.Locality,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+// SetAddresses restores the addresses of a stored user
+func (u *User) SetAddresses(addresses []*Address) {
+	u.addresses = slices.Clone(addresses)
+	if u.addresses == nil {
+		u.addresses = make([]*Address, 0)
+	}
+}
+
+func (u *User) GetAddresses() []*Address {
+	return u.addresses
+}
+
+func (u *User) GetAddress(id string) (*Address, error) {
+	for _, address := range u.addresses {
+		if address.ID == id {
+			return address, nil
+		}
+	}
+	return nil, ErrAddressNotFound
+}
+
+func (u *User) AddAddress(address *Address) {
+	u.addresses = append(u.addresses, address)
+}
+
+// ReplaceAddress replaces the address with the same ID
+func (u *User) ReplaceAddress(address *Address) error {
+	for i, a := range u.addresses {
+		if a.ID == address.ID {
+			u.addresses[i] = address
+			return nil
+		}
+	}
+	return ErrAddressNotFound
+}
+
+func (u *User) RemoveAddress(id string) error {
+	if _, err := u.GetAddress(id); err != nil {
+		return err
+	}
+	u.addresses = slices.DeleteFunc(u.addresses, func(a *Address) bool { return a.ID == id })
+	return nil
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAddress(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		address, err := NewAddress("home", []string{" 10 Downing Street "}, "London", "", "sw1a  2aa", "gb")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Address{
+			Type:       AddressHome,
+			Lines:      []string{"10 Downing Street"},
+			Locality:   "London",
+			PostalCode: "SW1A 2AA",
+			Country:    "GB",
+		}, address)
+	})
+
+	t.Run("Postal codes follow the country's format", func(t *testing.T) {
+		valid := map[string]string{"US": "94043-1351", "DE": "10117", "NL": "1012 JS", "CA": "K1A 0B1", "JP": "100-0001", "PT": "1000-001"}
+		for country, postalCode := range valid {
+			_, err := NormalizePostalCode(postalCode, country)
+			assert.NoError(t, err, country)
+		}
+
+		invalid := map[string]string{"US": "9404", "DE": "1011", "NL": "JS 1012", "CA": "12345", "GB": "12345"}
+		for country, postalCode := range invalid {
+			_, err := NormalizePostalCode(postalCode, country)
+			assert.ErrorIs(t, err, ErrInvalidPostalCode, country)
+		}
+	})
+
+	t.Run("Postal code requirement", func(t *testing.T) {
+		_, err := NormalizePostalCode("", "US")
+		assert.ErrorIs(t, err, ErrInvalidPostalCode)
+
+		// Ireland has optional postal codes, Hong Kong has none
+		for _, country := range []string{"IE", "HK"} {
+			postalCode, err := NormalizePostalCode("", country)
+			assert.NoError(t, err)
+			assert.Empty(t, postalCode)
+		}
+	})
+
+	t.Run("Unknown country", func(t *testing.T) {
+		_, err := NewAddress("home", []string{"Street 1"}, "City", "", "12345", "XX")
+		assert.ErrorIs(t, err, ErrInvalidCountry)
+
+		_, err = NewAddress("home", []string{"Street 1"}, "City", "", "12345", "DEU")
+		assert.ErrorIs(t, err, ErrInvalidCountry)
+	})
+
+	t.Run("Invalid fields", func(t *testing.T) {
+		_, err := NewAddress("summer", []string{"Street 1"}, "Berlin", "", "10117", "DE")
+		assert.ErrorIs(t, err, ErrInvalidAddressType)
+
+		_, err = NewAddress("home", nil, "Berlin", "", "10117", "DE")
+		assert.ErrorIs(t, err, ErrInvalidAddressLines)
+
+		_, err = NewAddress("home", []string{"1", "2", "3", "4"}, "Berlin", "", "10117", "DE")
+		assert.ErrorIs(t, err, ErrInvalidAddressLines)
+
+		_, err = NewAddress("home", []string{"Street 1\nBerlin"}, "Berlin", "", "10117", "DE")
+		assert.ErrorIs(t, err, ErrInvalidAddressLines)
+
+		_, err = NewAddress("home", []string{"Street 1"}, " ", "", "10117", "DE")
+		assert.ErrorIs(t, err, ErrInvalidLocality)
+	})
+}
+
+func TestUser_Addresses(t *testing.T) {
+	user, _ := NewUser("Test User", "test@example.com", "1990-01-01")
+	user.AddAddress(&Address{ID: "a1", Type: AddressHome, Lines: []string{"Street 1"}, Locality: "Berlin", PostalCode: "10117", Country: "DE"})
+	user.AddAddress(&Address{ID: "a2", Type: AddressWork, Lines: []string{"Street 2"}, Locality: "Berlin", PostalCode: "10117", Country: "DE"})
+
+	err := user.ReplaceAddress(&Address{ID: "a1", Type: AddressBilling, Lines: []string{"Street 3"}, Locality: "Munich", PostalCode: "80331", Country: "DE"})
+	assert.NoError(t, err)
+	address, _ := user.GetAddress("a1")
+	assert.Equal(t, "Munich", address.Locality)
+
+	assert.NoError(t, user.RemoveAddress("a2"))
+	assert.Len(t, user.ToDTO().Addresses, 1)
+	assert.ErrorIs(t, user.RemoveAddress("a2"), ErrAddressNotFound)
+	assert.ErrorIs(t, user.ReplaceAddress(&Address{ID: "a2"}), ErrAddressNotFound)
+
+	user.Erase()
+	assert.Empty(t, user.GetAddresses())
+}
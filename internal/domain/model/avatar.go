@@ -0,0 +1,64 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrAvatarNotFound    = errors.New("avatar not found")
+	ErrInvalidAvatarSize = errors.New("invalid avatar size: use 64, 128, 256 or 512")
+)
+
+// AvatarSizes are the thumbnail sizes in pixels stored for every avatar, largest first
+var AvatarSizes = []int{512, 256, 128, 64}
+
+// AvatarURLTemplate is the path serving the avatar of a user, the avatar ID changes with every upload
+const AvatarURLTemplate = "/v1/users/%s/avatar?v=%s"
+
+// Avatar is a profile picture, stored as a thumbnail for each of the AvatarSizes
+type Avatar struct {
+	ID          string
+	ContentType string
+}
+
+// Filename is the name of the thumbnail of the given size, 0 means the largest one
+func (a *Avatar) Filename(size int) (string, error) {
+	if size == 0 {
+		size = AvatarSizes[0]
+	}
+	if !slices.Contains(AvatarSizes, size) {
+		return "", ErrInvalidAvatarSize
+	}
+
+	extension := ".png"
+	if a.ContentType == "image/jpeg" {
+		extension = ".jpg"
+	}
+	return fmt.Sprintf("avatar-%s-%d%s", a.ID, size, extension), nil
+}
+
+// Filenames are the names of all the thumbnails of the avatar
+func (a *Avatar) Filenames() []string {
+	filenames := make([]string, 0, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		filename, _ := a.Filename(size)
+		filenames = append(filenames, filename)
+	}
+	return filenames
+}
+
+// SetAvatar replaces the avatar of the user, the previous one is returned so its thumbnails can be deleted
+func (u *User) SetAvatar(avatar *Avatar) *Avatar {
+	previous := u.avatar
+	u.avatar = avatar
+	return previous
+}
+
+func (u *User) GetAvatar() (*Avatar, error) {
+	if u.avatar == nil {
+		return nil, ErrAvatarNotFound
+	}
+	return u.avatar, nil
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvatar_Filename(t *testing.T) {
+	avatar := &Avatar{ID: "avatar-1", ContentType: "image/jpeg"}
+
+	largest, err := avatar.Filename(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "avatar-avatar-1-512.jpg", largest)
+
+	_, err = avatar.Filename(100)
+	assert.ErrorIs(t, err, ErrInvalidAvatarSize)
+
+	png := &Avatar{ID: "avatar-2", ContentType: "image/png"}
+	assert.Equal(t, []string{"avatar-avatar-2-512.png", "avatar-avatar-2-256.png", "avatar-avatar-2-128.png", "avatar-avatar-2-64.png"},
+		png.Filenames())
+}
+
+func TestUser_SetAvatar(t *testing.T) {
+	user, _ := NewUser("Test User", "test@example.com", "1990-01-01")
+	user.ID = "user-123"
+
+	_, err := user.GetAvatar()
+	assert.ErrorIs(t, err, ErrAvatarNotFound)
+	assert.Empty(t, user.ToDTO().AvatarURL)
+
+	first := &Avatar{ID: "avatar-1", ContentType: "image/png"}
+	assert.Nil(t, user.SetAvatar(first))
+	assert.Equal(t, first, user.SetAvatar(&Avatar{ID: "avatar-2", ContentType: "image/png"}))
+	assert.Equal(t, "/v1/users/user-123/avatar?v=avatar-2", user.ToDTO().AvatarURL)
+
+	user.Erase()
+	_, err = user.GetAvatar()
+	assert.ErrorIs(t, err, ErrAvatarNotFound)
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var (
+	ErrInvalidContactType        = errors.New("invalid contact type: use email or phone")
+	ErrInvalidPhoneNumber        = errors.New("invalid phone number: use the E.164 format, e.g. +447911123456")
+	ErrContactPointNotFound      = errors.New("contact point not found")
+	ErrContactPointAlreadyExists = errors.New("contact point already exists")
+	ErrContactPointNotVerified   = errors.New("contact point must be verified first")
+	ErrEmailVerificationRequired = errors.New("email addresses can only be verified with a token")
+	ErrPhoneVerificationNotSent  = errors.New("phone numbers are verified by the caller, set the verified flag instead")
+)
+
+type ContactType string
+
+const (
+	ContactEmail ContactType = "email"
+	ContactPhone ContactType = "phone"
+)
+
+// e164Pattern matches a + followed by a country code and at most 15 digits in total
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// phoneSeparators are the characters commonly used to format phone numbers
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// ContactPoint is a secondary email address or a phone number of a user. The primary email address is the user's email,
+// among phone numbers one is primary.
+type ContactPoint struct {
+	ID       string
+	Type     ContactType
+	Value    string
+	Label    string
+	Primary  bool
+	Verified bool
+}
+
+// NewContactPoint validates and normalises the value of a contact point
+func NewContactPoint(contactType, value, label string) (*ContactPoint, error) {
+	var err error
+	switch ContactType(contactType) {
+	case ContactEmail:
+		value, err = NormalizeEmailAddress(value)
+	case ContactPhone:
+		value, err = NormalizePhoneNumber(value)
+	default:
+		err = ErrInvalidContactType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContactPoint{Type: ContactType(contactType), Value: value, Label: label}, nil
+}
+
+func NormalizeEmailAddress(email string) (string, error) {
+	parsed, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", ErrInvalidEmailAddress
+	}
+	return parsed.Address, nil
+}
+
+// NormalizePhoneNumber strips formatting characters and checks that the number is in the E.164 format
+func NormalizePhoneNumber(phone string) (string, error) {
+	normalized := phoneSeparators.Replace(strings.TrimSpace(phone))
+	if !e164Pattern.MatchString(normalized) {
+		return "", ErrInvalidPhoneNumber
+	}
+	return normalized, nil
+}
+
+func (c *ContactPoint) ToDTO() *v1.ContactPoint {
+	return &v1.ContactPoint{
+		ID:       c.ID,
+		Type:     string(c.Type),
+		Value:    c.Value,
+		Label:    c.Label,
+		Primary:  c.Primary,
+		Verified: c.Verified,
+	}
+}
+
+func (u *User) GetContactPoints() []*ContactPoint {
+	return u.contacts
+}
+
+// SetContactPoints restores the contact points of a stored user
+func (u *User) SetContactPoints(contacts []*ContactPoint) {
+	u.contacts = slices.Clone(contacts)
+	if u.contacts == nil {
+		u.contacts = make([]*ContactPoint, 0)
+	}
+}
+
+func (u *User) GetContactPoint(id string) (*ContactPoint, error) {
+	for _, contact := range u.contacts {
+		if contact.ID == id {
+			return contact, nil
+		}
+	}
+	return nil, ErrContactPointNotFound
+}
+
+// AddContactPoint adds a contact point, the first phone number becomes the primary one
+func (u *User) AddContactPoint(contact *ContactPoint) error {
+	if u.FindContactPoint(contact.Type, contact.Value) != nil || (contact.Type == ContactEmail && contact.Value == u.email) {
+		return ErrContactPointAlreadyExists
+	}
+
+	contact.Primary = contact.Type == ContactPhone && u.primaryPhone() == nil
+	u.contacts = append(u.contacts, contact)
+	return nil
+}
+
+// RemoveContactPoint removes a contact point, the oldest remaining phone number replaces a removed primary one
+func (u *User) RemoveContactPoint(id string) error {
+	contact, err := u.GetContactPoint(id)
+	if err != nil {
+		return err
+	}
+
+	u.contacts = slices.DeleteFunc(u.contacts, func(c *ContactPoint) bool { return c.ID == id })
+	if contact.Primary {
+		for _, c := range u.contacts {
+			if c.Type == ContactPhone {
+				c.Primary = true
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// PromoteContactPoint makes a contact point the primary one of its type. A promoted email address must be verified,
+// it becomes the user's email and the contact point holds the previous email instead.
+func (u *User) PromoteContactPoint(id string) error {
+	contact, err := u.GetContactPoint(id)
+	if err != nil {
+		return err
+	}
+
+	switch contact.Type {
+	case ContactEmail:
+		if !contact.Verified {
+			return ErrContactPointNotVerified
+		}
+		contact.Value, u.email = u.email, contact.Value
+		contact.Verified, u.emailVerified = u.emailVerified, true
+	case ContactPhone:
+		if primary := u.primaryPhone(); primary != nil {
+			primary.Primary = false
+		}
+		contact.Primary = true
+	}
+	return nil
+}
+
+// VerifyContactPoint marks a contact point as verified, the caller is responsible for the proof of control
+func (u *User) VerifyContactPoint(id string) error {
+	contact, err := u.GetContactPoint(id)
+	if err != nil {
+		return err
+	}
+	contact.Verified = true
+	return nil
+}
+
+// FindContactPoint returns the contact point with the given type and value, nil if there is none
+func (u *User) FindContactPoint(contactType ContactType, value string) *ContactPoint {
+	for _, contact := range u.contacts {
+		if contact.Type == contactType && contact.Value == value {
+			return contact
+		}
+	}
+	return nil
+}
+
+func (u *User) primaryPhone() *ContactPoint {
+	for _, contact := range u.contacts {
+		if contact.Type == ContactPhone && contact.Primary {
+			return contact
+		}
+	}
+	return nil
+}
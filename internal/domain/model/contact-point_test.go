@@ -0,0 +1,134 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContactPoint(t *testing.T) {
+	t.Run("Phone number is normalised", func(t *testing.T) {
+		contact, err := NewContactPoint("phone", "+44 (7911) 123-456", "mobile")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "+447911123456", contact.Value)
+		assert.Equal(t, ContactPhone, contact.Type)
+		assert.Equal(t, "mobile", contact.Label)
+	})
+
+	t.Run("Phone number not in E.164 format", func(t *testing.T) {
+		for _, phone := range []string{"07911123456", "+0123456", "+1234567890123456", "+44abc"} {
+			_, err := NewContactPoint("phone", phone, "")
+			assert.ErrorIs(t, err, ErrInvalidPhoneNumber, phone)
+		}
+	})
+
+	t.Run("Email address", func(t *testing.T) {
+		contact, err := NewContactPoint("email", "Work <work@example.com>", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "work@example.com", contact.Value)
+
+		_, err = NewContactPoint("email", "not-an-email", "")
+		assert.ErrorIs(t, err, ErrInvalidEmailAddress)
+	})
+
+	t.Run("Unknown type", func(t *testing.T) {
+		_, err := NewContactPoint("fax", "+447911123456", "")
+		assert.ErrorIs(t, err, ErrInvalidContactType)
+	})
+}
+
+func newTestContactUser(t *testing.T) *User {
+	user, err := NewUser("Test User", "test@example.com", "1990-01-01")
+	assert.NoError(t, err)
+	return user
+}
+
+func TestUser_AddContactPoint(t *testing.T) {
+	t.Run("First phone number is primary", func(t *testing.T) {
+		user := newTestContactUser(t)
+
+		assert.NoError(t, user.AddContactPoint(&ContactPoint{ID: "c1", Type: ContactPhone, Value: "+447911123456"}))
+		assert.NoError(t, user.AddContactPoint(&ContactPoint{ID: "c2", Type: ContactPhone, Value: "+447911654321"}))
+		assert.NoError(t, user.AddContactPoint(&ContactPoint{ID: "c3", Type: ContactEmail, Value: "work@example.com"}))
+
+		contacts := user.GetContactPoints()
+		assert.True(t, contacts[0].Primary)
+		assert.False(t, contacts[1].Primary)
+		assert.False(t, contacts[2].Primary)
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		user := newTestContactUser(t)
+		assert.NoError(t, user.AddContactPoint(&ContactPoint{ID: "c1", Type: ContactPhone, Value: "+447911123456"}))
+
+		err := user.AddContactPoint(&ContactPoint{ID: "c2", Type: ContactPhone, Value: "+447911123456"})
+		assert.ErrorIs(t, err, ErrContactPointAlreadyExists)
+
+		err = user.AddContactPoint(&ContactPoint{ID: "c3", Type: ContactEmail, Value: "test@example.com"})
+		assert.ErrorIs(t, err, ErrContactPointAlreadyExists)
+		assert.Len(t, user.GetContactPoints(), 1)
+	})
+}
+
+func TestUser_RemoveContactPoint(t *testing.T) {
+	user := newTestContactUser(t)
+	_ = user.AddContactPoint(&ContactPoint{ID: "c1", Type: ContactPhone, Value: "+447911123456"})
+	_ = user.AddContactPoint(&ContactPoint{ID: "c2", Type: ContactEmail, Value: "work@example.com"})
+	_ = user.AddContactPoint(&ContactPoint{ID: "c3", Type: ContactPhone, Value: "+447911654321"})
+
+	assert.NoError(t, user.RemoveContactPoint("c1"))
+
+	next, err := user.GetContactPoint("c3")
+	assert.NoError(t, err)
+	assert.True(t, next.Primary)
+	assert.ErrorIs(t, user.RemoveContactPoint("c1"), ErrContactPointNotFound)
+}
+
+func TestUser_PromoteContactPoint(t *testing.T) {
+	t.Run("Phone number", func(t *testing.T) {
+		user := newTestContactUser(t)
+		_ = user.AddContactPoint(&ContactPoint{ID: "c1", Type: ContactPhone, Value: "+447911123456"})
+		_ = user.AddContactPoint(&ContactPoint{ID: "c2", Type: ContactPhone, Value: "+447911654321"})
+
+		assert.NoError(t, user.PromoteContactPoint("c2"))
+
+		first, _ := user.GetContactPoint("c1")
+		second, _ := user.GetContactPoint("c2")
+		assert.False(t, first.Primary)
+		assert.True(t, second.Primary)
+	})
+
+	t.Run("Verified email address is swapped with the user's email", func(t *testing.T) {
+		user := newTestContactUser(t)
+		_ = user.AddContactPoint(&ContactPoint{ID: "c1", Type: ContactEmail, Value: "work@example.com"})
+		assert.NoError(t, user.VerifyContactPoint("c1"))
+
+		assert.NoError(t, user.PromoteContactPoint("c1"))
+
+		contact, _ := user.GetContactPoint("c1")
+		assert.Equal(t, "work@example.com", user.ToDTO().Email)
+		assert.True(t, user.IsEmailVerified())
+		assert.Equal(t, "test@example.com", contact.Value)
+		assert.False(t, contact.Verified)
+	})
+
+	t.Run("Unverified email address", func(t *testing.T) {
+		user := newTestContactUser(t)
+		_ = user.AddContactPoint(&ContactPoint{ID: "c1", Type: ContactEmail, Value: "work@example.com"})
+
+		assert.ErrorIs(t, user.PromoteContactPoint("c1"), ErrContactPointNotVerified)
+		assert.Equal(t, "test@example.com", user.ToDTO().Email)
+	})
+}
+
+func TestUser_SetEmailToContactPoint(t *testing.T) {
+	user := newTestContactUser(t)
+	_ = user.AddContactPoint(&ContactPoint{ID: "c1", Type: ContactEmail, Value: "work@example.com", Verified: true})
+
+	assert.NoError(t, user.SetEmail("work@example.com"))
+
+	assert.True(t, user.IsEmailVerified())
+	assert.Empty(t, user.GetContactPoints())
+}
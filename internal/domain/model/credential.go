@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MinPasswordLength = 12
+	MaxPasswordLength = 128
+)
+
+var (
+	ErrInvalidPassword        = fmt.Errorf("password must be between %d and %d characters long", MinPasswordLength, MaxPasswordLength)
+	ErrInvalidCredentials     = errors.New("invalid email or password")
+	ErrAccountDisabled        = errors.New("user account is disabled")
+	ErrInvalidResetToken      = errors.New("invalid password reset token")
+	ErrPasswordResetExpired   = errors.New("password reset token has expired")
+	ErrPasswordNotSet         = errors.New("user has no password")
+	ErrCurrentPasswordInvalid = errors.New("current password is invalid")
+)
+
+// Credential is a user's local password, only its Argon2id hash is kept
+type Credential struct {
+	UserID         string
+	Hash           string
+	FailedAttempts int
+	LockedUntil    time.Time
+}
+
+func NewCredential(userID, hash string) *Credential {
+	return &Credential{UserID: userID, Hash: hash}
+}
+
+func ValidatePassword(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < MinPasswordLength || length > MaxPasswordLength {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// SetHash replaces the password, which also lifts any lockout
+func (c *Credential) SetHash(hash string) {
+	c.Hash = hash
+	c.FailedAttempts = 0
+	c.LockedUntil = time.Time{}
+}
+
+func (c *Credential) IsLocked(now time.Time) bool {
+	return now.Before(c.LockedUntil)
+}
+
+// CanLogin tells whether the user status allows logging in
+func (u *User) CanLogin() bool {
+	return u.status == UserPending || u.status == UserActive
+}
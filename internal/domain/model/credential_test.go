@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePassword(t *testing.T) {
+	assert.ErrorIs(t, ValidatePassword("short"), ErrInvalidPassword)
+	assert.ErrorIs(t, ValidatePassword(strings.Repeat("a", MaxPasswordLength+1)), ErrInvalidPassword)
+	assert.NoError(t, ValidatePassword("correct horse battery staple"))
+	assert.NoError(t, ValidatePassword("ééééééééééé€"), "length is counted in characters")
+}
+
+func TestCredential_IsLocked(t *testing.T) {
+	now := time.Now()
+	credential := &Credential{UserID: "user-123", Hash: "hash", LockedUntil: now.Add(time.Minute)}
+
+	assert.True(t, credential.IsLocked(now))
+	assert.False(t, credential.IsLocked(now.Add(time.Minute)))
+	assert.False(t, NewCredential("user-123", "hash").IsLocked(now))
+}
+
+func TestCredential_SetHash(t *testing.T) {
+	now := time.Now()
+	credential := &Credential{UserID: "user-123", Hash: "old", FailedAttempts: 2, LockedUntil: now.Add(time.Hour)}
+
+	credential.SetHash("new")
+
+	assert.Equal(t, "new", credential.Hash)
+	assert.Equal(t, 0, credential.FailedAttempts)
+	assert.False(t, credential.IsLocked(now))
+}
+
+func TestUser_CanLogin(t *testing.T) {
+	assert.True(t, (&User{status: UserActive}).CanLogin())
+	assert.True(t, (&User{status: UserPending}).CanLogin())
+	assert.False(t, (&User{status: UserLocked}).CanLogin())
+	assert.False(t, (&User{status: UserSuspended}).CanLogin())
+	assert.False(t, (&User{status: UserDeactivated}).CanLogin())
+}
@@ -0,0 +1,9 @@
+package model
+
+// DataKey is the key a stored file is encrypted with, wrapped by a master key
+type DataKey struct {
+	UserID     string
+	Filename   string
+	KeyID      string // ID of the master key that wraps the data key
+	WrappedKey []byte
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+// Tombstone values overwriting the personal data of an erased user
+const (
+	ErasedName = "erased"
+	ErasedDob  = "1900-01-01"
+)
+
+// ErasedEmail is unique per user, since email addresses are unique
+func ErasedEmail(userID string) string {
+	return fmt.Sprintf("erased+%s@erased.invalid", userID)
+}
+
+// Erasure is the non-identifying record proving that a user's personal data was erased
+type Erasure struct {
+	ID          string
+	UserID      string
+	FilesErased int
+	ErasedAt    time.Time
+}
+
+func NewErasure(userID string, filesErased int) *Erasure {
+	return &Erasure{UserID: userID, FilesErased: filesErased, ErasedAt: time.Now()}
+}
+
+func (e *Erasure) ToDTO() *v1.Erasure {
+	return &v1.Erasure{
+		ID:          e.ID,
+		UserID:      e.UserID,
+		FilesErased: e.FilesErased,
+		ErasedAt:    e.ErasedAt,
+	}
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+type ExportStatus string
+
+const (
+	ExportPending   ExportStatus = "pending"
+	ExportCompleted ExportStatus = "completed"
+	ExportFailed    ExportStatus = "failed"
+)
+
+const exportTokenLength = 32
+
+// Export is a data subject export of all the data held about a user
+type Export struct {
+	ID        string
+	UserID    string
+	Status    ExportStatus
+	Token     string
+	Error     string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func NewExport(userID string) (*Export, error) {
+	token := make([]byte, exportTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	return &Export{
+		UserID:    userID,
+		Status:    ExportPending,
+		Token:     hex.EncodeToString(token),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// ArchiveName is the name of the archive holding the exported data
+func (e *Export) ArchiveName() string {
+	return e.ID + ".zip"
+}
+
+func (e *Export) Complete(expiresAt time.Time) {
+	e.Status = ExportCompleted
+	e.ExpiresAt = expiresAt
+}
+
+func (e *Export) Fail(err error) {
+	e.Status = ExportFailed
+	e.Error = err.Error()
+}
+
+func (e *Export) IsExpired(now time.Time) bool {
+	return e.Status == ExportCompleted && !now.Before(e.ExpiresAt)
+}
+
+func (e *Export) ToDTO() *v1.Export {
+	dto := &v1.Export{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Status:    string(e.Status),
+		Error:     e.Error,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.Status == ExportCompleted {
+		dto.ExpiresAt = &e.ExpiresAt
+		dto.DownloadURL = fmt.Sprintf("/v1/users/%s/exports/%s/download?token=%s", e.UserID, e.ID, e.Token)
+	}
+	return dto
+}
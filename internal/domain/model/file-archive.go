@@ -0,0 +1,40 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ArchiveEntryNames gives each file a unique name in an archive, in the order of the files. The names are the
+// sanitized names the files are served with; a name taken already, by another file or a reserved entry, gets a
+// " (n)" suffix before its extension. Names are compared ignoring case, as some file systems extract them.
+func ArchiveEntryNames(files []*File, reserved ...string) []string {
+	taken := make(map[string]bool, len(files)+len(reserved))
+	for _, name := range reserved {
+		taken[strings.ToLower(name)] = true
+	}
+
+	names := make([]string, len(files))
+	for i, file := range files {
+		name := file.AttachmentName()
+		for n := 1; taken[strings.ToLower(name)]; n++ {
+			name = numberedName(file.AttachmentName(), n)
+		}
+		taken[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+// numberedName adds " (n)" to the name before its extension, the name is shortened to keep it under the
+// longest name a file is served with
+func numberedName(name string, n int) string {
+	ext := path.Ext(name)
+	if ext == name || len(ext) > maxAttachmentName/2 {
+		// a dot file, or an extension too long to be one
+		ext = ""
+	}
+	suffix := fmt.Sprintf(" (%d)", n) + ext
+	return truncateName(strings.TrimSuffix(name, ext), maxAttachmentName-len(suffix)) + suffix
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveEntryNames(t *testing.T) {
+	t.Run("Unique", func(t *testing.T) {
+		files := []*File{{Name: "report.pdf"}, {Name: "notes"}}
+
+		assert.Equal(t, []string{"report.pdf", "notes"}, ArchiveEntryNames(files))
+	})
+
+	t.Run("Colliding", func(t *testing.T) {
+		files := []*File{
+			{Name: "report.pdf"}, {Name: "Report.PDF"}, {Name: "report (1).pdf"}, {Name: "a/b"}, {Name: "a_b"}, {Name: ".env"}, {Name: ".env"},
+		}
+
+		assert.Equal(t, []string{
+			"report.pdf", "Report (1).PDF", "report (1) (1).pdf", "a_b", "a_b (1)", "env", "env (1)",
+		}, ArchiveEntryNames(files))
+	})
+
+	t.Run("Reserved", func(t *testing.T) {
+		files := []*File{{Name: "manifest.json"}}
+
+		assert.Equal(t, []string{"manifest (1).json"}, ArchiveEntryNames(files, "manifest.json"))
+	})
+
+	t.Run("Longest Name", func(t *testing.T) {
+		name := strings.Repeat("é", 124) + ".txt"
+		files := []*File{{Name: name}, {Name: name}}
+
+		names := ArchiveEntryNames(files)
+
+		assert.LessOrEqual(t, len(names[1]), maxAttachmentName)
+		assert.True(t, strings.HasSuffix(names[1], "é (1).txt"))
+		assert.NotEqual(t, names[0], names[1])
+	})
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidFileDescription = errors.New("invalid file description: it is at most 1024 characters")
+	ErrInvalidFileTag         = errors.New("invalid file tag: use at most 32 tags of 1 to 64 characters, without control characters")
+	ErrInvalidFileLabel       = errors.New("invalid file label: use at most 32 labels, named with letters, digits, _, - and . (max 64), " +
+		"with values of at most 256 characters")
+	ErrFileNameTaken = errors.New("the folder has another file with this name")
+)
+
+const (
+	maxFileDescription = 1024
+	maxFileTags        = 32
+	maxFileTag         = 64
+	maxFileLabels      = 32
+	maxFileLabelValue  = 256
+)
+
+var fileLabelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// SetDescription replaces the description of the file, an empty one removes it
+func (f *File) SetDescription(description string) error {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > maxFileDescription || !utf8.ValidString(description) {
+		return ErrInvalidFileDescription
+	}
+	f.Description = description
+	return nil
+}
+
+// SetTags replaces the tags of the file. Tags are trimmed and lowercased, duplicates are dropped.
+func (f *File) SetTags(tags []string) error {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !isFileTag(tag) {
+			return ErrInvalidFileTag
+		}
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > maxFileTags {
+		return ErrInvalidFileTag
+	}
+	f.Tags = normalized
+	return nil
+}
+
+// SetLabels replaces the key/value labels of the file
+func (f *File) SetLabels(labels map[string]string) error {
+	if len(labels) > maxFileLabels {
+		return ErrInvalidFileLabel
+	}
+	for name, value := range labels {
+		if !fileLabelPattern.MatchString(name) || utf8.RuneCountInString(value) > maxFileLabelValue || !isPrintable(value) {
+			return ErrInvalidFileLabel
+		}
+	}
+	f.Labels = make(map[string]string, len(labels))
+	for name, value := range labels {
+		f.Labels[name] = value
+	}
+	return nil
+}
+
+// HasTag tells whether the file is tagged, ignoring case
+func (f *File) HasTag(tag string) bool {
+	return slices.Contains(f.Tags, strings.ToLower(strings.TrimSpace(tag)))
+}
+
+// MoveFile puts the file in the folder, the empty ID is the root, under the name it is displayed and served with.
+// The names of the files in a folder are unique, as uploading a file under the name of another one in the same
+// folder replaces its content. The folder must exist.
+func (u *User) MoveFile(file *File, folderID, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxAttachmentName || !utf8.ValidString(name) {
+		return ErrInvalidFilename
+	}
+	if other, err := u.GetFileByName(folderID, name); err == nil && other.ID != file.ID {
+		return ErrFileNameTaken
+	}
+	// a file stored by name keeps its content under the old name
+	if file.StorageKey == "" && file.Name != name {
+		file.StorageKey = file.Name
+	}
+	file.Name = name
+	file.FolderID = folderID
+	return nil
+}
+
+// FileFilter selects files by tags and labels, a file matches if it has all of them
+type FileFilter struct {
+	Tags   []string
+	Labels map[string]string
+}
+
+func (f *FileFilter) Matches(file *File) bool {
+	for _, tag := range f.Tags {
+		if !file.HasTag(tag) {
+			return false
+		}
+	}
+	for name, value := range f.Labels {
+		if labelValue, ok := file.Labels[name]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterFiles returns the files of the user matching the filter
+func (u *User) FilterFiles(filter *FileFilter) []*File {
+	var files []*File
+	for _, file := range u.files {
+		if filter.Matches(file) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func isFileTag(tag string) bool {
+	return tag != "" && utf8.RuneCountInString(tag) <= maxFileTag && isPrintable(tag)
+}
+
+// isPrintable tells whether the text is valid UTF-8 without control and formatting characters
+func isPrintable(text string) bool {
+	return utf8.ValidString(text) && !strings.ContainsFunc(text, func(r rune) bool {
+		return unicode.IsControl(r) || unicode.Is(unicode.Cf, r)
+	})
+}
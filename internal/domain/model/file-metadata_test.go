@@ -0,0 +1,92 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_SetTags(t *testing.T) {
+	t.Run("Normalized", func(t *testing.T) {
+		file := &File{}
+
+		assert.NoError(t, file.SetTags([]string{" Holiday ", "holiday", "2024"}))
+		assert.Equal(t, []string{"holiday", "2024"}, file.Tags)
+		assert.True(t, file.HasTag("HOLIDAY"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		file := &File{Tags: []string{"kept"}}
+
+		assert.ErrorIs(t, file.SetTags([]string{"ok", " "}), ErrInvalidFileTag)
+		assert.ErrorIs(t, file.SetTags([]string{strings.Repeat("a", 65)}), ErrInvalidFileTag)
+		assert.ErrorIs(t, file.SetTags([]string{"new\nline"}), ErrInvalidFileTag)
+		assert.Equal(t, []string{"kept"}, file.Tags)
+	})
+
+	t.Run("Too Many", func(t *testing.T) {
+		tags := make([]string, maxFileTags+1)
+		for i := range tags {
+			tags[i] = strings.Repeat("t", i+1)
+		}
+
+		assert.ErrorIs(t, (&File{}).SetTags(tags), ErrInvalidFileTag)
+	})
+}
+
+func TestFile_SetLabels(t *testing.T) {
+	file := &File{}
+
+	assert.NoError(t, file.SetLabels(map[string]string{"project": "apollo", "cost-center.eu": ""}))
+	assert.Equal(t, map[string]string{"project": "apollo", "cost-center.eu": ""}, file.Labels)
+	assert.ErrorIs(t, file.SetLabels(map[string]string{"-project": "apollo"}), ErrInvalidFileLabel)
+	assert.ErrorIs(t, file.SetLabels(map[string]string{"project": strings.Repeat("a", 257)}), ErrInvalidFileLabel)
+}
+
+func TestFile_SetDescription(t *testing.T) {
+	file := &File{}
+
+	assert.NoError(t, file.SetDescription("  Quarterly report \n"))
+	assert.Equal(t, "Quarterly report", file.Description)
+	assert.ErrorIs(t, file.SetDescription(strings.Repeat("é", 1025)), ErrInvalidFileDescription)
+}
+
+func TestUser_MoveFile(t *testing.T) {
+	user := &User{}
+	draft := &File{ID: "file-1", Name: "draft.txt"}
+	user.AddFile(draft)
+	user.AddFile(&File{ID: "file-2", Name: "notes.txt"})
+	user.AddFile(&File{ID: "file-3", Name: "notes.txt", FolderID: "2024"})
+
+	assert.NoError(t, user.MoveFile(draft, "", " report.txt "))
+	assert.Equal(t, "report.txt", draft.Name)
+	assert.NoError(t, user.MoveFile(draft, "", "report.txt"))
+	assert.ErrorIs(t, user.MoveFile(draft, "", "notes.txt"), ErrFileNameTaken)
+	assert.ErrorIs(t, user.MoveFile(draft, "", "  "), ErrInvalidFilename)
+
+	// names are unique per folder
+	assert.ErrorIs(t, user.MoveFile(draft, "2024", "notes.txt"), ErrFileNameTaken)
+	assert.NoError(t, user.MoveFile(draft, "2025", "notes.txt"))
+	assert.Equal(t, "2025", draft.FolderID)
+	assert.Equal(t, "notes.txt", draft.Name)
+
+	// the content of a file stored by name stays where it is
+	legacy := &File{ID: "file-4", Name: "old.txt"}
+	user.AddFile(legacy)
+	assert.NoError(t, user.MoveFile(legacy, "", "new.txt"))
+	assert.Equal(t, "new.txt", legacy.Name)
+	assert.Equal(t, "old.txt", legacy.StorageName())
+	assert.NoError(t, user.MoveFile(legacy, "", "newer.txt"))
+	assert.Equal(t, "old.txt", legacy.StorageName())
+}
+
+func TestFileFilter_Matches(t *testing.T) {
+	file := &File{Tags: []string{"holiday", "2024"}, Labels: map[string]string{"place": "rome"}}
+
+	assert.True(t, (&FileFilter{}).Matches(file))
+	assert.True(t, (&FileFilter{Tags: []string{"Holiday", "2024"}, Labels: map[string]string{"place": "rome"}}).Matches(file))
+	assert.False(t, (&FileFilter{Tags: []string{"holiday", "work"}}).Matches(file))
+	assert.False(t, (&FileFilter{Labels: map[string]string{"place": "paris"}}).Matches(file))
+	assert.False(t, (&FileFilter{Labels: map[string]string{"year": ""}}).Matches(file))
+}
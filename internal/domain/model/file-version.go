@@ -0,0 +1,131 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var (
+	ErrFileVersionNotFound = errors.New("file version not found")
+	ErrFileContentChanged  = errors.New("the content of the file was replaced meanwhile, try again")
+)
+
+// versionStoragePrefix starts the storage names of the versions archived by copying their content, before
+// versions kept the storage key of the content they replaced
+const versionStoragePrefix = ".version-"
+
+// FileVersion is the prior content of a file that was re-uploaded under the same name or restored. The content
+// stays where it was stored, new content is stored under a new key.
+type FileVersion struct {
+	ID           string
+	FileID       string
+	UserID       string
+	StorageKey   string // name the content is stored under, empty for the versions archived by copying it
+	Path         string
+	Version      int
+	Size         int64
+	ContentType  string
+	DeclaredType string
+	Digest       string
+	ScanStatus   string
+	// ReplacedAt is when a newer version replaced this content
+	ReplacedAt time.Time
+}
+
+// StorageName is the name the content of the version is stored under, next to the files of the user
+func (v *FileVersion) StorageName() string {
+	if v.StorageKey != "" {
+		return v.StorageKey
+	}
+	return versionStoragePrefix + v.ID
+}
+
+func (v *FileVersion) IsPendingScan() bool {
+	return v.ScanStatus == ScanPending
+}
+
+func (v *FileVersion) ToDTO() *v1.FileVersion {
+	return &v1.FileVersion{
+		ID:           v.ID,
+		FileID:       v.FileID,
+		Version:      v.Version,
+		Size:         v.Size,
+		ContentType:  v.ContentType,
+		DeclaredType: v.DeclaredType,
+		Digest:       v.Digest,
+		ScanStatus:   v.ScanStatus,
+		ReplacedAt:   v.ReplacedAt,
+	}
+}
+
+// Snapshot records the current content of the file as a version, before it is replaced. The version keeps the
+// content where it is stored.
+func (f *File) Snapshot(id string, at time.Time) *FileVersion {
+	return &FileVersion{
+		ID:           id,
+		FileID:       f.ID,
+		UserID:       f.UserID,
+		StorageKey:   f.StorageName(),
+		Path:         f.Path,
+		Version:      max(f.Version, 1),
+		Size:         f.Size,
+		ContentType:  f.ContentType,
+		DeclaredType: f.DeclaredType,
+		Digest:       f.Digest,
+		ScanStatus:   f.ScanStatus,
+		ReplacedAt:   at,
+	}
+}
+
+// Replace gives the file the content of another upload of the same name, as its next version
+func (f *File) Replace(upload *File) {
+	f.StorageKey = upload.StorageKey
+	f.Path = upload.Path
+	f.Size = upload.Size
+	f.ContentType = upload.ContentType
+	f.DeclaredType = upload.DeclaredType
+	f.Digest = upload.Digest
+	f.Corrupted = false
+	f.ScanStatus = upload.ScanStatus
+	f.Version = max(f.Version, 1) + 1
+}
+
+// Restore gives the file the content of one of its versions, as its next version
+func (f *File) Restore(version *FileVersion) {
+	f.StorageKey = version.StorageName()
+	f.Path = version.Path
+	f.Size = version.Size
+	f.ContentType = version.ContentType
+	f.DeclaredType = version.DeclaredType
+	f.Digest = version.Digest
+	f.Corrupted = false
+	f.ScanStatus = version.ScanStatus
+	f.Version = max(f.Version, 1) + 1
+}
+
+// AtVersion is the file as it was at the version, to serve its content
+func (f *File) AtVersion(version *FileVersion) *File {
+	file := *f
+	file.StorageKey = version.StorageName()
+	file.Path = version.Path
+	file.Size = version.Size
+	file.ContentType = version.ContentType
+	file.DeclaredType = version.DeclaredType
+	file.Digest = version.Digest
+	file.Corrupted = false
+	file.ScanStatus = version.ScanStatus
+	file.Version = version.Version
+	return &file
+}
+
+// GetFileByName returns the current file of the user with the name in the folder, the empty ID is the root
+func (u *User) GetFileByName(folderID, name string) (*File, error) {
+	for _, file := range u.files {
+		if file.FolderID == folderID && file.Name == name {
+			return file, nil
+		}
+	}
+	return nil, ErrFileNotFound
+}
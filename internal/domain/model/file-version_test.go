@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_Replace(t *testing.T) {
+	now := time.Now()
+	file := &File{ID: "file-123", UserID: "user-123", Name: "contract.pdf", StorageKey: "key-1", Path: "/files/key-1",
+		Size: 10, Digest: helloDigest, Corrupted: true}
+
+	version := file.Snapshot("version-123", now)
+	file.Replace(&File{Name: "contract.pdf", StorageKey: "key-2", Size: 20, ContentType: "application/pdf", Digest: "abc",
+		ScanStatus: ScanPending})
+
+	assert.Equal(t, &FileVersion{ID: "version-123", FileID: "file-123", UserID: "user-123", StorageKey: "key-1",
+		Path: "/files/key-1", Version: 1, Size: 10, Digest: helloDigest, ReplacedAt: now}, version)
+	assert.Equal(t, "key-1", version.StorageName(), "the version keeps the replaced content")
+	assert.Equal(t, "key-2", file.StorageName())
+	assert.Equal(t, 2, file.Version, "files stored before versioning are version 1")
+	assert.Equal(t, int64(20), file.Size)
+	assert.Equal(t, "abc", file.Digest)
+	assert.False(t, file.Corrupted)
+	assert.True(t, file.IsPendingScan())
+}
+
+func TestFile_Restore(t *testing.T) {
+	file := &File{ID: "file-123", Name: "contract.pdf", Size: 20, Digest: "abc", Version: 3}
+	version := &FileVersion{ID: "version-123", Version: 1, Size: 10, Digest: helloDigest, ScanStatus: ScanClean}
+	assert.Equal(t, ".version-version-123", version.StorageName(), "versions archived by copying")
+
+	at := file.AtVersion(version)
+	assert.Equal(t, "contract.pdf", at.Name)
+	assert.Equal(t, int64(10), at.Size)
+	assert.Equal(t, 1, at.Version)
+	assert.Equal(t, 3, file.Version, "the file itself doesn't change")
+
+	file.Restore(version)
+	assert.Equal(t, 4, file.Version)
+	assert.Equal(t, ".version-version-123", file.StorageName())
+	assert.Equal(t, int64(10), file.Size)
+	assert.Equal(t, helloDigest, file.Digest)
+	assert.Equal(t, ScanClean, file.ScanStatus)
+}
+
+func TestUser_GetFileByName(t *testing.T) {
+	user := &User{}
+	user.AddFile(&File{ID: "file-123", Name: "contract.pdf"})
+	user.AddFile(&File{ID: "file-456", Name: "contract.pdf", FolderID: "2024"})
+
+	file, err := user.GetFileByName("", "contract.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "file-123", file.ID)
+	file, err = user.GetFileByName("2024", "contract.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "file-456", file.ID)
+	_, err = user.GetFileByName("", "other.pdf")
+	assert.ErrorIs(t, err, ErrFileNotFound)
+	_, err = user.GetFileByName("2025", "contract.pdf")
+	assert.ErrorIs(t, err, ErrFileNotFound)
+}
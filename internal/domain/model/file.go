@@ -1,27 +1,189 @@
 package model
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"golang.org/x/text/unicode/norm"
 )
 
-var ErrFileTooLarge = errors.New("file is too large")
+var (
+	ErrFileTooLarge    = errors.New("file is too large")
+	ErrFileNotFound    = errors.New("file not found")
+	ErrFileCorrupted   = errors.New("file is corrupted: its content doesn't match its digest")
+	ErrInvalidDigest   = errors.New("invalid digest: use a hex SHA-256 or sha-256=<base64>")
+	ErrDigestMismatch  = errors.New("file content doesn't match the digest sent")
+	ErrFileInfected    = errors.New("file is infected and was quarantined")
+	ErrFilePendingScan = errors.New("file is being scanned for malware, try again later")
+)
+
+// the scan statuses of a file, files stored while scanning was off have none
+const (
+	ScanPending = "pending_scan"
+	ScanClean   = "clean"
+)
+
+const digestHeaderPrefix = "sha-256="
+
+// maxAttachmentName is the longest name a file is served with, in bytes
+const maxAttachmentName = 255
+
+// defaultAttachmentName is the name a file is served with if nothing is left of its name once sanitized
+const defaultAttachmentName = "download"
 
 type File struct {
-	ID     string
-	UserID string
-	Name   string
-	Path   string
-	Size   int64
+	ID           string
+	UserID       string
+	Name         string // sent by the client, only displayed and never used to store the content
+	StorageKey   string // generated name the content is stored under, empty for files stored by name
+	Path         string
+	Size         int64
+	ContentType  string // detected from the content
+	DeclaredType string // sent by the client or guessed from the name
+	Digest       string // hex SHA-256 of the content, empty for files stored before digests were recorded
+	Corrupted    bool   // the stored content no longer matches the digest
+	ScanStatus   string // pending_scan until the content is found clean, infected files are quarantined
+	Version      int    // number of the current content, the prior ones are kept as FileVersion
+	Description  string
+	Tags         []string          // lowercase, see SetTags
+	Labels       map[string]string // custom key/value metadata, see SetLabels
+	FolderID     string            // empty for the files at the root
+}
+
+// StorageName is the name the content is stored under. Files stored before storage keys were generated are
+// stored under their name.
+func (f *File) StorageName() string {
+	if f.StorageKey != "" {
+		return f.StorageKey
+	}
+	return f.Name
+}
+
+// AttachmentName is the name the file is served with. It is normalized to NFC, and the characters that could
+// hide its extension or name another directory, control and formatting ones and separators, are replaced.
+func (f *File) AttachmentName() string {
+	return SanitizeFilename(f.Name)
+}
+
+// SanitizeFilename makes a name sent by a client safe to name a file sent back to a client
+func SanitizeFilename(name string) string {
+	name = norm.NFC.String(strings.ToValidUTF8(name, ""))
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\' || r == '"':
+			return '_'
+		case unicode.IsControl(r) || unicode.Is(unicode.Cf, r):
+			return -1
+		}
+		return r
+	}, name)
+	name = truncateName(name, maxAttachmentName)
+	// leading dots hide files, trailing ones and spaces are dropped by some file systems
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return defaultAttachmentName
+	}
+	return name
+}
+
+// truncateName cuts the name to at most max bytes, on a rune boundary
+func truncateName(name string, max int) string {
+	if len(name) <= max {
+		return name
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(name[cut]) {
+		cut--
+	}
+	return name[:cut]
+}
+
+// IsPendingScan tells whether the content is still to be scanned, it isn't served until then
+func (f *File) IsPendingScan() bool {
+	return f.ScanStatus == ScanPending
+}
+
+// ParseDigest reads a SHA-256 digest sent as hex or in the sha-256=<base64> format of the Digest header.
+// It returns the digest as lowercase hex.
+func ParseDigest(digest string) (string, error) {
+	digest = strings.TrimSpace(digest)
+	if len(digest) > len(digestHeaderPrefix) && strings.EqualFold(digest[:len(digestHeaderPrefix)], digestHeaderPrefix) {
+		sum, err := base64.StdEncoding.DecodeString(digest[len(digestHeaderPrefix):])
+		if err != nil || len(sum) != 32 {
+			return "", ErrInvalidDigest
+		}
+		return hex.EncodeToString(sum), nil
+	}
+
+	sum, err := hex.DecodeString(digest)
+	if err != nil || len(sum) != 32 {
+		return "", ErrInvalidDigest
+	}
+	return hex.EncodeToString(sum), nil
+}
+
+// DigestHeader is the value of the Digest header of the file's content
+func (f *File) DigestHeader() string {
+	sum, _ := hex.DecodeString(f.Digest)
+	return digestHeaderPrefix + base64.StdEncoding.EncodeToString(sum)
+}
+
+// ETag is a strong entity tag of the file's content
+func (f *File) ETag() string {
+	return `"` + f.Digest + `"`
+}
+
+// CheckIntegrity compares the digest of the stored content with the recorded one and flags the file as corrupted
+// if they differ. A file stored before digests were recorded adopts the digest.
+func (f *File) CheckIntegrity(digest string) bool {
+	if f.Digest == "" {
+		f.Digest = digest
+	}
+	f.Corrupted = f.Digest != digest
+	return !f.Corrupted
 }
 
 func (f *File) ToDTO() *v1.File {
 	return &v1.File{
-		ID:     f.ID,
-		UserID: f.UserID,
-		Name:   f.Name,
-		Path:   f.Path,
-		Size:   f.Size,
+		ID:           f.ID,
+		UserID:       f.UserID,
+		Name:         f.Name,
+		Path:         f.Path,
+		Size:         f.Size,
+		ContentType:  f.ContentType,
+		DeclaredType: f.DeclaredType,
+		Digest:       f.Digest,
+		Corrupted:    f.Corrupted,
+		ScanStatus:   f.ScanStatus,
+		Version:      f.Version,
+		Description:  f.Description,
+		Tags:         f.Tags,
+		Labels:       f.Labels,
+		FolderID:     f.FolderID,
+	}
+}
+
+func (u *User) GetFile(id string) (*File, error) {
+	for _, file := range u.files {
+		if file.ID == id {
+			return file, nil
+		}
+	}
+	return nil, ErrFileNotFound
+}
+
+// FindDuplicateFiles returns the other files of the user with the same content as the file
+func (u *User) FindDuplicateFiles(file *File) []*File {
+	var duplicates []*File
+	for _, f := range u.files {
+		if f.ID != file.ID && f.Digest != "" && f.Digest == file.Digest {
+			duplicates = append(duplicates, f)
+		}
 	}
+	return duplicates
 }
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// helloDigest is the SHA-256 of "hello"
+const helloDigest = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestParseDigest(t *testing.T) {
+	tests := []struct {
+		name        string
+		digest      string
+		expected    string
+		expectedErr error
+	}{
+		{name: "Hex", digest: helloDigest, expected: helloDigest},
+		{name: "Uppercase hex", digest: "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824", expected: helloDigest},
+		{name: "Digest header", digest: "sha-256=LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=", expected: helloDigest},
+		{name: "Digest header uppercase algorithm", digest: "SHA-256=LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=", expected: helloDigest},
+		{name: "Short hex", digest: "2cf24dba", expectedErr: ErrInvalidDigest},
+		{name: "Other algorithm", digest: "md5=XUFAKrxLKna5cZ2REBfFkg==", expectedErr: ErrInvalidDigest},
+		{name: "Invalid base64", digest: "sha-256=not base64", expectedErr: ErrInvalidDigest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest, err := ParseDigest(tt.digest)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, digest)
+			}
+		})
+	}
+}
+
+func TestFile_Headers(t *testing.T) {
+	file := &File{Digest: helloDigest}
+
+	assert.Equal(t, "sha-256=LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=", file.DigestHeader())
+	assert.Equal(t, `"`+helloDigest+`"`, file.ETag())
+}
+
+func TestFile_CheckIntegrity(t *testing.T) {
+	file := &File{}
+	assert.True(t, file.CheckIntegrity(helloDigest), "a file without digest adopts it")
+	assert.Equal(t, helloDigest, file.Digest)
+
+	assert.False(t, file.CheckIntegrity("other"))
+	assert.True(t, file.Corrupted)
+	assert.Equal(t, helloDigest, file.Digest, "the recorded digest is kept")
+
+	assert.True(t, file.CheckIntegrity(helloDigest))
+	assert.False(t, file.Corrupted, "a restored file is no longer corrupted")
+}
+
+func TestFile_StorageName(t *testing.T) {
+	assert.Equal(t, "file-123", (&File{Name: "contract.pdf", StorageKey: "file-123"}).StorageName())
+	assert.Equal(t, "contract.pdf", (&File{Name: "contract.pdf"}).StorageName(), "files stored by name")
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		expected string
+	}{
+		{name: "Plain", filename: "contract.pdf", expected: "contract.pdf"},
+		{name: "Path", filename: "../../etc/passwd", expected: "_.._etc_passwd"},
+		{name: "Backslash", filename: `C:\Users\me\report.pdf`, expected: "C:_Users_me_report.pdf"},
+		{name: "Quote", filename: `say "hi".txt`, expected: "say _hi_.txt"},
+		{name: "Control characters", filename: "evil\r\nSet-Cookie: x.txt", expected: "evilSet-Cookie: x.txt"},
+		{name: "Right-to-left override", filename: "invoice\u202Efdp.exe", expected: "invoicefdp.exe"},
+		{name: "Decomposed", filename: "cafe\u0301.txt", expected: "caf\u00e9.txt"},
+		{name: "Invalid UTF-8", filename: "bad\xff.txt", expected: "bad.txt"},
+		{name: "Hidden", filename: "..htaccess", expected: "htaccess"},
+		{name: "Trailing dots and spaces", filename: "report.pdf. . ", expected: "report.pdf"},
+		{name: "Empty", filename: " . ", expected: "download"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SanitizeFilename(tt.filename))
+		})
+	}
+
+	t.Run("Long", func(t *testing.T) {
+		name := SanitizeFilename(strings.Repeat("é", 200))
+		assert.LessOrEqual(t, len(name), maxAttachmentName)
+		assert.True(t, utf8.ValidString(name))
+	})
+}
+
+func TestUser_FindDuplicateFiles(t *testing.T) {
+	user := &User{ID: "user-123"}
+	original := &File{ID: "file-1", Digest: helloDigest}
+	unknown := &File{ID: "file-2"}
+	other := &File{ID: "file-3", Digest: "other"}
+	copied := &File{ID: "file-4", Digest: helloDigest}
+	for _, f := range []*File{original, unknown, other, copied} {
+		user.AddFile(f)
+	}
+
+	assert.Equal(t, []*File{copied}, user.FindDuplicateFiles(original))
+	assert.Empty(t, user.FindDuplicateFiles(unknown), "files without digest are never duplicates")
+
+	file, err := user.GetFile("file-3")
+	assert.NoError(t, err)
+	assert.Equal(t, other, file)
+	_, err = user.GetFile("missing")
+	assert.ErrorIs(t, err, ErrFileNotFound)
+}
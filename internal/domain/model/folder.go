@@ -0,0 +1,260 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var (
+	ErrFolderNotFound    = errors.New("folder not found")
+	ErrInvalidFolderName = errors.New("invalid folder name: it is required and at most 255 characters, without / and control characters")
+	ErrFolderNameTaken   = errors.New("the parent folder has another folder with this name")
+	ErrFolderNotEmpty    = errors.New("folder is not empty: empty it first or delete it recursively")
+	ErrInvalidFolderMove = errors.New("a folder can't be moved into itself or one of its subfolders")
+)
+
+// RootFolderPath is the path of the root of a user's files, which isn't a folder itself
+const RootFolderPath = "/"
+
+const maxFolderName = 255
+
+// Folder organises the files of a user. Folders form a tree per user, the names of the folders with the same
+// parent are unique ignoring case. Files are only referenced by their folder, moving them doesn't touch
+// their content.
+type Folder struct {
+	ID        string
+	UserID    string
+	ParentID  string // empty for the folders at the root
+	Name      string
+	CreatedAt time.Time
+}
+
+func NewFolder(id, userID, parentID, name string, createdAt time.Time) (*Folder, error) {
+	name, err := folderName(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Folder{ID: id, UserID: userID, ParentID: parentID, Name: name, CreatedAt: createdAt}, nil
+}
+
+// folderName trims the name and checks it can be an element of a folder path
+func folderName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || len(name) > maxFolderName || strings.Contains(name, "/") ||
+		!isPrintable(name) {
+		return "", ErrInvalidFolderName
+	}
+	return name, nil
+}
+
+// FolderTree is the hierarchy of the folders of a user. Folders whose parent is gone are at the root.
+type FolderTree struct {
+	folders  map[string]*Folder
+	children map[string][]*Folder
+}
+
+func NewFolderTree(folders []*Folder) *FolderTree {
+	t := &FolderTree{folders: make(map[string]*Folder, len(folders)), children: make(map[string][]*Folder)}
+	for _, folder := range folders {
+		t.folders[folder.ID] = folder
+	}
+	for _, folder := range folders {
+		parentID := t.parentID(folder)
+		t.children[parentID] = append(t.children[parentID], folder)
+	}
+	for _, children := range t.children {
+		sortFolders(children)
+	}
+	return t
+}
+
+// Get returns a folder of the user
+func (t *FolderTree) Get(id string) (*Folder, error) {
+	folder, ok := t.folders[id]
+	if !ok {
+		return nil, ErrFolderNotFound
+	}
+	return folder, nil
+}
+
+// Check tells whether the folder exists, the empty ID of the root does
+func (t *FolderTree) Check(id string) error {
+	if id == "" {
+		return nil
+	}
+	_, err := t.Get(id)
+	return err
+}
+
+// Children returns the folders in the folder, sorted by name; the empty ID is the root
+func (t *FolderTree) Children(id string) []*Folder {
+	return t.children[id]
+}
+
+// Subtree returns the folder and all the folders under it, each folder before its subfolders
+func (t *FolderTree) Subtree(id string) []*Folder {
+	folder, ok := t.folders[id]
+	if !ok {
+		return nil
+	}
+	subtree := []*Folder{folder}
+	// visited stops at a cycle of stored parents
+	visited := map[string]bool{folder.ID: true}
+	for i := 0; i < len(subtree); i++ {
+		for _, child := range t.children[subtree[i].ID] {
+			if !visited[child.ID] {
+				visited[child.ID] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+	return subtree
+}
+
+// Path is the path of the folder from the root, e.g. /documents/2024
+func (t *FolderTree) Path(id string) string {
+	var names []string
+	// the depth is bounded in case the stored parents form a cycle
+	for folder, ok := t.folders[id]; ok && len(names) <= len(t.folders); folder, ok = t.folders[t.parentID(folder)] {
+		names = append(names, folder.Name)
+	}
+	slices.Reverse(names)
+	return RootFolderPath + strings.Join(names, "/")
+}
+
+// Resolve returns the ID of the folder at the path, the empty ID for the root. Names are compared ignoring case.
+func (t *FolderTree) Resolve(path string) (string, error) {
+	id := ""
+	for _, name := range strings.Split(path, "/") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		folder := t.child(id, name)
+		if folder == nil {
+			return "", ErrFolderNotFound
+		}
+		id = folder.ID
+	}
+	return id, nil
+}
+
+// FolderOf returns the ID of the folder the file is in, the empty ID if it is at the root or its folder is gone
+func (t *FolderTree) FolderOf(file *File) string {
+	if _, ok := t.folders[file.FolderID]; ok {
+		return file.FolderID
+	}
+	return ""
+}
+
+// Add checks that the folder can be created in its parent and adds it to the tree
+func (t *FolderTree) Add(folder *Folder) error {
+	if err := t.Check(folder.ParentID); err != nil {
+		return err
+	}
+	if t.child(folder.ParentID, folder.Name) != nil {
+		return ErrFolderNameTaken
+	}
+	t.folders[folder.ID] = folder
+	t.children[folder.ParentID] = append(t.children[folder.ParentID], folder)
+	sortFolders(t.children[folder.ParentID])
+	return nil
+}
+
+// Rename changes the name of the folder, it must be unique in its parent
+func (t *FolderTree) Rename(folder *Folder, name string) error {
+	name, err := folderName(name)
+	if err != nil {
+		return err
+	}
+	if other := t.child(t.parentID(folder), name); other != nil && other.ID != folder.ID {
+		return ErrFolderNameTaken
+	}
+	folder.Name = name
+	sortFolders(t.children[t.parentID(folder)])
+	return nil
+}
+
+// Move makes the folder a subfolder of the parent, the empty ID is the root. A folder can't be moved under itself.
+func (t *FolderTree) Move(folder *Folder, parentID string) error {
+	if err := t.Check(parentID); err != nil {
+		return err
+	}
+	for _, descendant := range t.Subtree(folder.ID) {
+		if descendant.ID == parentID {
+			return ErrInvalidFolderMove
+		}
+	}
+	if other := t.child(parentID, folder.Name); other != nil && other.ID != folder.ID {
+		return ErrFolderNameTaken
+	}
+
+	previous := t.parentID(folder)
+	t.children[previous] = slices.DeleteFunc(t.children[previous], func(f *Folder) bool { return f.ID == folder.ID })
+	folder.ParentID = parentID
+	t.children[parentID] = append(t.children[parentID], folder)
+	sortFolders(t.children[parentID])
+	return nil
+}
+
+// Node builds the tree view of the folder with the files in it and its subfolders, the empty ID is the root
+func (t *FolderTree) Node(id string, files []*File) *v1.FolderNode {
+	byFolder := make(map[string][]*File)
+	for _, file := range files {
+		folderID := t.FolderOf(file)
+		byFolder[folderID] = append(byFolder[folderID], file)
+	}
+	name := ""
+	if folder, ok := t.folders[id]; ok {
+		name = folder.Name
+	}
+	return t.node(id, name, byFolder, 0)
+}
+
+func (t *FolderTree) node(id, name string, files map[string][]*File, depth int) *v1.FolderNode {
+	node := &v1.FolderNode{ID: id, Name: name, Path: t.Path(id), Folders: []*v1.FolderNode{}, Files: []*v1.File{}}
+	for _, file := range files[id] {
+		node.Files = append(node.Files, file.ToDTO())
+	}
+	// the depth is bounded in case the stored parents form a cycle
+	if depth > len(t.folders) {
+		return node
+	}
+	for _, child := range t.children[id] {
+		node.Folders = append(node.Folders, t.node(child.ID, child.Name, files, depth+1))
+	}
+	return node
+}
+
+// ToDTO converts the folder with its path in the tree
+func (t *FolderTree) ToDTO(folder *Folder) *v1.Folder {
+	return &v1.Folder{ID: folder.ID, ParentID: folder.ParentID, Name: folder.Name, Path: t.Path(folder.ID)}
+}
+
+// child returns the folder of the parent with the name, ignoring case, or nil
+func (t *FolderTree) child(parentID, name string) *Folder {
+	for _, folder := range t.children[parentID] {
+		if strings.EqualFold(folder.Name, name) {
+			return folder
+		}
+	}
+	return nil
+}
+
+// parentID is the ID of the parent of the folder in the tree, the empty ID if it is at the root or its parent is gone
+func (t *FolderTree) parentID(folder *Folder) string {
+	if _, ok := t.folders[folder.ParentID]; ok {
+		return folder.ParentID
+	}
+	return ""
+}
+
+func sortFolders(folders []*Folder) {
+	slices.SortFunc(folders, func(a, b *Folder) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestFolderTree builds /documents/2024, /documents/2025 and /photos
+func newTestFolderTree() *FolderTree {
+	return NewFolderTree([]*Folder{
+		{ID: "2024", ParentID: "documents", Name: "2024"},
+		{ID: "photos", Name: "Photos"},
+		{ID: "documents", Name: "documents"},
+		{ID: "2025", ParentID: "documents", Name: "2025"},
+	})
+}
+
+func TestNewFolder(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		folder, err := NewFolder("folder-1", "user-1", "", " Documents ", time.Now())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Documents", folder.Name)
+	})
+
+	t.Run("Invalid Name", func(t *testing.T) {
+		for _, name := range []string{"", " ", ".", "..", "a/b", "tab\there", strings.Repeat("a", 256)} {
+			_, err := NewFolder("folder-1", "user-1", "", name, time.Now())
+
+			assert.ErrorIs(t, err, ErrInvalidFolderName, name)
+		}
+	})
+}
+
+func TestFolderTree_Paths(t *testing.T) {
+	tree := newTestFolderTree()
+
+	assert.Equal(t, "/documents/2024", tree.Path("2024"))
+	assert.Equal(t, "/", tree.Path(""))
+
+	id, err := tree.Resolve("/Documents/2024/")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024", id)
+	id, err = tree.Resolve("/")
+	assert.NoError(t, err)
+	assert.Empty(t, id)
+	_, err = tree.Resolve("/documents/2023")
+	assert.ErrorIs(t, err, ErrFolderNotFound)
+}
+
+func TestFolderTree_Orphans(t *testing.T) {
+	tree := NewFolderTree([]*Folder{{ID: "a", ParentID: "gone", Name: "a"}, {ID: "b", ParentID: "c", Name: "b"}, {ID: "c", ParentID: "b", Name: "c"}})
+
+	assert.Equal(t, "/a", tree.Path("a"))
+	assert.Equal(t, "", tree.FolderOf(&File{FolderID: "gone"}))
+	// stored parents forming a cycle don't hang the tree
+	assert.Len(t, tree.Subtree("b"), 2)
+	assert.NotEmpty(t, tree.Path("b"))
+}
+
+func TestFolderTree_Add(t *testing.T) {
+	tree := newTestFolderTree()
+
+	assert.NoError(t, tree.Add(&Folder{ID: "2023", ParentID: "documents", Name: "2023"}))
+	assert.Equal(t, []*Folder{{ID: "2023", ParentID: "documents", Name: "2023"}}, tree.Children("documents")[:1])
+	assert.ErrorIs(t, tree.Add(&Folder{ID: "other", Name: "photos"}), ErrFolderNameTaken)
+	assert.ErrorIs(t, tree.Add(&Folder{ID: "other", ParentID: "gone", Name: "other"}), ErrFolderNotFound)
+}
+
+func TestFolderTree_Rename(t *testing.T) {
+	tree := newTestFolderTree()
+	folder, _ := tree.Get("2024")
+
+	assert.ErrorIs(t, tree.Rename(folder, "2025"), ErrFolderNameTaken)
+	assert.ErrorIs(t, tree.Rename(folder, "a/b"), ErrInvalidFolderName)
+	assert.NoError(t, tree.Rename(folder, "2024 archive"))
+	assert.Equal(t, "/documents/2024 archive", tree.Path("2024"))
+}
+
+func TestFolderTree_Move(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		tree := newTestFolderTree()
+		folder, _ := tree.Get("2024")
+
+		assert.NoError(t, tree.Move(folder, "photos"))
+		assert.Equal(t, "/Photos/2024", tree.Path("2024"))
+		assert.Len(t, tree.Children("documents"), 1)
+
+		assert.NoError(t, tree.Move(folder, ""))
+		assert.Equal(t, "/2024", tree.Path("2024"))
+	})
+
+	t.Run("Into Itself", func(t *testing.T) {
+		tree := newTestFolderTree()
+		folder, _ := tree.Get("documents")
+
+		assert.ErrorIs(t, tree.Move(folder, "documents"), ErrInvalidFolderMove)
+		assert.ErrorIs(t, tree.Move(folder, "2024"), ErrInvalidFolderMove)
+	})
+
+	t.Run("Name Taken", func(t *testing.T) {
+		tree := newTestFolderTree()
+		_ = tree.Add(&Folder{ID: "other", ParentID: "photos", Name: "2024"})
+		folder, _ := tree.Get("2024")
+
+		assert.ErrorIs(t, tree.Move(folder, "photos"), ErrFolderNameTaken)
+	})
+}
+
+func TestFolderTree_Node(t *testing.T) {
+	tree := newTestFolderTree()
+	files := []*File{{ID: "file-1", FolderID: "2024"}, {ID: "file-2"}, {ID: "file-3", FolderID: "gone"}}
+
+	root := tree.Node("", files)
+
+	assert.Equal(t, "/", root.Path)
+	assert.Equal(t, []*v1.File{files[1].ToDTO(), files[2].ToDTO()}, root.Files)
+	assert.Equal(t, []string{"documents", "Photos"}, []string{root.Folders[0].Name, root.Folders[1].Name})
+	assert.Equal(t, "/documents/2024", root.Folders[0].Folders[0].Path)
+	assert.Equal(t, []*v1.File{files[0].ToDTO()}, root.Folders[0].Folders[0].Files)
+
+	documents := tree.Node("documents", files)
+
+	assert.Equal(t, "documents", documents.Name)
+	assert.Empty(t, documents.Files)
+	assert.Len(t, documents.Folders, 2)
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var ErrInvalidGroupName = errors.New("invalid group name")
+
+// Group gathers users, its members hold all the roles assigned to it
+type Group struct {
+	ID          string
+	name        string
+	description string
+	roles       []string
+}
+
+func NewGroup(name, description string, roles []string) (*Group, error) {
+	group := &Group{description: description}
+	if err := group.SetName(name); err != nil {
+		return nil, err
+	}
+	if err := group.SetRoles(roles); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (g *Group) SetName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 255 {
+		return ErrInvalidGroupName
+	}
+	g.name = name
+	return nil
+}
+
+func (g *Group) SetDescription(description string) {
+	g.description = description
+}
+
+// SetRoles replaces the roles assigned to the group, duplicates are dropped
+func (g *Group) SetRoles(roles []string) error {
+	for _, role := range roles {
+		if err := ValidateRoleName(role); err != nil {
+			return err
+		}
+	}
+	sorted := append(make([]string, 0, len(roles)), roles...)
+	slices.Sort(sorted)
+	g.roles = slices.Compact(sorted)
+	return nil
+}
+
+func (g *Group) GetRoles() []string {
+	return g.roles
+}
+
+func (g *Group) ToDTO() *v1.Group {
+	return &v1.Group{
+		ID:          g.ID,
+		Name:        g.name,
+		Description: g.description,
+		Roles:       slices.Clone(g.roles),
+	}
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGroup(t *testing.T) {
+	group, err := NewGroup("  Editors ", "Content editors", []string{"editor", "admin", "editor"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &v1.Group{Name: "Editors", Description: "Content editors", Roles: []string{"admin", "editor"}}, group.ToDTO())
+
+	_, err = NewGroup("", "", nil)
+	assert.ErrorIs(t, err, ErrInvalidGroupName)
+
+	_, err = NewGroup("Editors", "", []string{"Not A Role"})
+	assert.ErrorIs(t, err, ErrInvalidRoleName)
+}
+
+func TestNewRole(t *testing.T) {
+	for _, name := range []string{"admin", "billing:read", "files.write", "super_user-2"} {
+		_, err := NewRole(name, "")
+		assert.NoError(t, err, name)
+	}
+	for _, name := range []string{"", "Admin", "1admin", "admin role", "admin/*"} {
+		_, err := NewRole(name, "")
+		assert.ErrorIs(t, err, ErrInvalidRoleName, name)
+	}
+}
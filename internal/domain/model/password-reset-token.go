@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// PasswordResetToken is a single-use token allowing a user to choose a new password.
+// Only the hash of the token is kept, the token itself is sent to the user's email address.
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	Hash      string
+	ExpiresAt time.Time
+}
+
+// NewPasswordResetToken returns the token to persist along with its plain value to send
+func NewPasswordResetToken(userID string, ttl time.Duration) (*PasswordResetToken, string, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &PasswordResetToken{
+		UserID:    userID,
+		Hash:      HashPasswordResetToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}, token, nil
+}
+
+func HashPasswordResetToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+func (t *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
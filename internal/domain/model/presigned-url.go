@@ -0,0 +1,89 @@
+package model
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PresignedDownload = "download"
+	PresignedUpload   = "upload"
+)
+
+var (
+	ErrInvalidPresignedAction = errors.New("invalid pre-signed URL action, must be download or upload")
+	ErrInvalidPresignedExpiry = errors.New("invalid pre-signed URL expiry")
+	ErrInvalidPresignedIP     = errors.New("invalid IP address")
+	ErrInvalidSignature       = errors.New("invalid signature")
+	ErrPresignedURLExpired    = errors.New("pre-signed URL expired")
+	ErrPresignedIPMismatch    = errors.New("pre-signed URL is bound to another IP address")
+)
+
+// PresignedURL grants the holder of a signed URL the download of a file, or an upload into the space of a
+// user, until it expires. It can be bound to the IP address of the client it is handed to.
+type PresignedURL struct {
+	Action string
+	UserID string
+	// FileID is the file to download, uploads choose their file name
+	FileID    string
+	ExpiresAt time.Time
+	IP        string
+}
+
+// NewPresignedURL grants the action for the ttl, which can't exceed maxTTL
+func NewPresignedURL(action, userID, fileID, ip string, ttl, maxTTL time.Duration, now time.Time) (*PresignedURL, error) {
+	switch action {
+	case PresignedDownload:
+		if fileID == "" {
+			return nil, ErrFileNotFound
+		}
+	case PresignedUpload:
+		fileID = ""
+	default:
+		return nil, ErrInvalidPresignedAction
+	}
+
+	// the signed expiry has a precision of a second
+	if ttl < time.Second || ttl > maxTTL {
+		return nil, ErrInvalidPresignedExpiry
+	}
+
+	if ip != "" {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, ErrInvalidPresignedIP
+		}
+		ip = parsed.String()
+	}
+
+	return &PresignedURL{
+		Action:    action,
+		UserID:    userID,
+		FileID:    fileID,
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+		IP:        ip,
+	}, nil
+}
+
+// Payload is what the signature covers, every field is part of it so that none can be altered
+func (u *PresignedURL) Payload() string {
+	return strings.Join([]string{u.Action, u.UserID, u.FileID, strconv.FormatInt(u.ExpiresAt.Unix(), 10), u.IP}, "\n")
+}
+
+func (u *PresignedURL) IsExpired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
+
+// Check tells whether the URL can still be used by the client, its signature must be verified beforehand
+func (u *PresignedURL) Check(now time.Time, clientIP string) error {
+	if u.IsExpired(now) {
+		return ErrPresignedURLExpired
+	}
+	if u.IP != "" && !net.ParseIP(u.IP).Equal(net.ParseIP(clientIP)) {
+		return ErrPresignedIPMismatch
+	}
+	return nil
+}
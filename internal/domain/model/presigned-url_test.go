@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPresignedURL(t *testing.T) {
+	now := time.Unix(1700000000, 500)
+
+	presigned, err := NewPresignedURL(PresignedDownload, "user-123", "file-123", "::ffff:203.0.113.7", time.Minute, time.Hour, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", presigned.IP)
+	assert.Equal(t, time.Unix(1700000060, 0), presigned.ExpiresAt)
+	assert.Equal(t, "download\nuser-123\nfile-123\n1700000060\n203.0.113.7", presigned.Payload())
+
+	presigned, err = NewPresignedURL(PresignedUpload, "user-123", "file-123", "", time.Minute, time.Hour, now)
+	assert.NoError(t, err)
+	assert.Empty(t, presigned.FileID, "uploads choose their file name")
+
+	_, err = NewPresignedURL("delete", "user-123", "file-123", "", time.Minute, time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidPresignedAction)
+	_, err = NewPresignedURL(PresignedDownload, "user-123", "", "", time.Minute, time.Hour, now)
+	assert.ErrorIs(t, err, ErrFileNotFound)
+	_, err = NewPresignedURL(PresignedDownload, "user-123", "file-123", "", 2*time.Hour, time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidPresignedExpiry)
+	_, err = NewPresignedURL(PresignedDownload, "user-123", "file-123", "", -time.Minute, time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidPresignedExpiry)
+	_, err = NewPresignedURL(PresignedDownload, "user-123", "file-123", "localhost", time.Minute, time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidPresignedIP)
+}
+
+func TestPresignedURL_Check(t *testing.T) {
+	now := time.Now()
+	presigned := &PresignedURL{Action: PresignedDownload, ExpiresAt: now.Add(time.Minute)}
+
+	assert.NoError(t, presigned.Check(now, "198.51.100.1"))
+	assert.ErrorIs(t, presigned.Check(now.Add(time.Minute), "198.51.100.1"), ErrPresignedURLExpired)
+
+	presigned.IP = "2001:db8::1"
+	assert.NoError(t, presigned.Check(now, "2001:0db8:0000::1"))
+	assert.ErrorIs(t, presigned.Check(now, "198.51.100.1"), ErrPresignedIPMismatch)
+	assert.ErrorIs(t, presigned.Check(now, ""), ErrPresignedIPMismatch)
+}
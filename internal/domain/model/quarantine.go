@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Quarantine records the content of a file found infected, it is kept apart from the users' files for review.
+// It is carried by the FileQuarantined event.
+type Quarantine struct {
+	ID     string
+	UserID string
+	// FileID is empty if the file was rejected before being added
+	FileID   string `json:",omitempty"`
+	Filename string
+	Size     int64
+	Threat   string
+	// Location is where the quarantine storage put the content
+	Location string
+	At       time.Time
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidReconcilePolicy = errors.New("invalid reconcile policy: use report or delete for orphaned blobs, " +
+	"report, flag or delete for missing blobs, and a minimum age of orphaned blobs of 0 or more")
+
+// the repairs of a reconciliation, report repairs nothing
+const (
+	ReconcileReport = "report"
+	ReconcileFlag   = "flag"
+	ReconcileDelete = "delete"
+)
+
+// ReconcilePolicy tells how a reconciliation repairs what it finds. Orphaned blobs, stored without a file or
+// version, can be deleted. Files whose blob is missing can be flagged as corrupted, so they aren't served and
+// the users see it, or deleted with their versions; versions whose blob is missing can only be deleted.
+// Orphaned blobs younger than the minimum age are kept, they can be the content of an upload in progress.
+type ReconcilePolicy struct {
+	OrphanedBlobs string
+	MissingBlobs  string
+	OrphanMinAge  time.Duration
+}
+
+func NewReconcilePolicy(orphanedBlobs, missingBlobs string, orphanMinAge time.Duration) (*ReconcilePolicy, error) {
+	if orphanedBlobs != ReconcileReport && orphanedBlobs != ReconcileDelete {
+		return nil, ErrInvalidReconcilePolicy
+	}
+	if missingBlobs != ReconcileReport && missingBlobs != ReconcileFlag && missingBlobs != ReconcileDelete {
+		return nil, ErrInvalidReconcilePolicy
+	}
+	if orphanMinAge < 0 {
+		return nil, ErrInvalidReconcilePolicy
+	}
+	return &ReconcilePolicy{OrphanedBlobs: orphanedBlobs, MissingBlobs: missingBlobs, OrphanMinAge: orphanMinAge}, nil
+}
+
+// OrphanedBlob is content stored for a user without a file or version referencing it
+type OrphanedBlob struct {
+	UserID   string
+	Location string
+	Repaired bool
+}
+
+func (b *OrphanedBlob) String() string {
+	return fmt.Sprintf("orphaned blob %s of user %s", b.Location, b.UserID)
+}
+
+// MissingBlob is a file or a version whose content isn't stored
+type MissingBlob struct {
+	UserID    string
+	FileID    string
+	VersionID string // empty if the blob of the current content is missing
+	Name      string
+	Repaired  bool
+}
+
+func (b *MissingBlob) String() string {
+	if b.VersionID != "" {
+		return fmt.Sprintf("missing blob of version %s of file %s (%s) of user %s", b.VersionID, b.FileID, b.Name, b.UserID)
+	}
+	return fmt.Sprintf("missing blob of file %s (%s) of user %s", b.FileID, b.Name, b.UserID)
+}
+
+// Reconciliation is what a comparison of the stored blobs with the files and versions of the users found
+type Reconciliation struct {
+	Users    int
+	Blobs    int
+	Orphaned []*OrphanedBlob
+	Missing  []*MissingBlob
+}
+
+// Repaired counts the orphaned and missing blobs that were repaired
+func (r *Reconciliation) Repaired() int {
+	repaired := 0
+	for _, b := range r.Orphaned {
+		if b.Repaired {
+			repaired++
+		}
+	}
+	for _, b := range r.Missing {
+		if b.Repaired {
+			repaired++
+		}
+	}
+	return repaired
+}
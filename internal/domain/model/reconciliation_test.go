@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReconcilePolicy(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		policy, err := NewReconcilePolicy(ReconcileDelete, ReconcileFlag, time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &ReconcilePolicy{OrphanedBlobs: ReconcileDelete, MissingBlobs: ReconcileFlag, OrphanMinAge: time.Hour}, policy)
+	})
+
+	t.Run("Orphaned Blobs Can't Be Flagged", func(t *testing.T) {
+		_, err := NewReconcilePolicy(ReconcileFlag, ReconcileReport, 0)
+
+		assert.ErrorIs(t, err, ErrInvalidReconcilePolicy)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := NewReconcilePolicy(ReconcileReport, "repair", 0)
+
+		assert.ErrorIs(t, err, ErrInvalidReconcilePolicy)
+	})
+
+	t.Run("Negative Minimum Age", func(t *testing.T) {
+		_, err := NewReconcilePolicy(ReconcileDelete, ReconcileReport, -time.Hour)
+
+		assert.ErrorIs(t, err, ErrInvalidReconcilePolicy)
+	})
+}
+
+func TestReconciliation_Repaired(t *testing.T) {
+	reconciliation := &Reconciliation{
+		Orphaned: []*OrphanedBlob{{Repaired: true}, {}},
+		Missing:  []*MissingBlob{{Repaired: true}, {Repaired: true}},
+	}
+
+	assert.Equal(t, 3, reconciliation.Repaired())
+}
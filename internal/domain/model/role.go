@@ -0,0 +1,40 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var ErrInvalidRoleName = errors.New("invalid role name: use lowercase letters, digits and _ . : - (max 64)")
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_.:-]{0,63}$`)
+
+// Role is a named permission set granted to the members of the groups it is assigned to
+type Role struct {
+	Name        string
+	Description string
+}
+
+func NewRole(name, description string) (*Role, error) {
+	if err := ValidateRoleName(name); err != nil {
+		return nil, err
+	}
+	return &Role{Name: name, Description: description}, nil
+}
+
+// ValidateRoleName checks that a role name can be safely used by downstream authorization
+func ValidateRoleName(name string) error {
+	if !roleNamePattern.MatchString(name) {
+		return ErrInvalidRoleName
+	}
+	return nil
+}
+
+func (r *Role) ToDTO() *v1.Role {
+	return &v1.Role{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"errors"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var (
+	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+	ErrInvalidStorageQuota  = errors.New("invalid storage quota: use non-negative bytes and files, 0 is unlimited")
+)
+
+// StorageQuota limits the bytes and the number of files a user can store, 0 is unlimited
+type StorageQuota struct {
+	Bytes int64
+	Files int64
+}
+
+func NewStorageQuota(bytes, files int64) (*StorageQuota, error) {
+	if bytes < 0 || files < 0 {
+		return nil, ErrInvalidStorageQuota
+	}
+	return &StorageQuota{Bytes: bytes, Files: files}, nil
+}
+
+// StorageUsage is what a user stores, accounted for as files are added and deleted
+type StorageUsage struct {
+	UserID string
+	Bytes  int64
+	Files  int64
+	// Quota overrides the default quota for the user, nil if it doesn't
+	Quota *StorageQuota
+}
+
+// EffectiveQuota is the quota of the user, or the default one
+func (u *StorageUsage) EffectiveQuota(defaultQuota *StorageQuota) *StorageQuota {
+	if u.Quota != nil {
+		return u.Quota
+	}
+	return defaultQuota
+}
+
+// Check tells whether a file of the size fits in the quota. A file bigger than the whole quota is too large,
+// one that doesn't fit in what's left exceeds the quota.
+func (u *StorageUsage) Check(size int64, defaultQuota *StorageQuota) error {
+	quota := u.EffectiveQuota(defaultQuota)
+	if quota.Bytes > 0 && size > quota.Bytes {
+		return ErrFileTooLarge
+	}
+	if (quota.Bytes > 0 && u.Bytes+size > quota.Bytes) || (quota.Files > 0 && u.Files+1 > quota.Files) {
+		return ErrStorageQuotaExceeded
+	}
+	return nil
+}
+
+// Add accounts for a new file if it fits in the quota
+func (u *StorageUsage) Add(size int64, defaultQuota *StorageQuota) error {
+	if err := u.Check(size, defaultQuota); err != nil {
+		return err
+	}
+	u.Bytes += size
+	u.Files++
+	return nil
+}
+
+// Remove accounts for a deleted file
+func (u *StorageUsage) Remove(size int64) {
+	u.Bytes = max(u.Bytes-size, 0)
+	u.Files = max(u.Files-1, 0)
+}
+
+// Grow accounts for more content of the files already counted, e.g. a new version, if it fits in the quota
+func (u *StorageUsage) Grow(size int64, defaultQuota *StorageQuota) error {
+	quota := u.EffectiveQuota(defaultQuota)
+	if size > 0 && quota.Bytes > 0 && u.Bytes+size > quota.Bytes {
+		if size > quota.Bytes {
+			return ErrFileTooLarge
+		}
+		return ErrStorageQuotaExceeded
+	}
+	u.Bytes = max(u.Bytes+size, 0)
+	return nil
+}
+
+// Shrink accounts for less content of the files still counted, e.g. a deleted version
+func (u *StorageUsage) Shrink(size int64) {
+	u.Bytes = max(u.Bytes-size, 0)
+}
+
+func (u *StorageUsage) ToDTO(defaultQuota *StorageQuota) *v1.StorageUsage {
+	quota := u.EffectiveQuota(defaultQuota)
+	return &v1.StorageUsage{
+		UserID:          u.UserID,
+		UsedBytes:       u.Bytes,
+		FileCount:       u.Files,
+		QuotaBytes:      quota.Bytes,
+		QuotaFiles:      quota.Files,
+		QuotaOverridden: u.Quota != nil,
+	}
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStorageQuota(t *testing.T) {
+	quota, err := NewStorageQuota(1024, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, &StorageQuota{Bytes: 1024}, quota)
+
+	_, err = NewStorageQuota(-1, 0)
+	assert.ErrorIs(t, err, ErrInvalidStorageQuota)
+	_, err = NewStorageQuota(0, -1)
+	assert.ErrorIs(t, err, ErrInvalidStorageQuota)
+}
+
+func TestStorageUsage_Add(t *testing.T) {
+	defaultQuota := &StorageQuota{Bytes: 100, Files: 2}
+
+	t.Run("Fits", func(t *testing.T) {
+		usage := &StorageUsage{UserID: "user-123", Bytes: 40, Files: 1}
+
+		assert.NoError(t, usage.Add(60, defaultQuota))
+		assert.Equal(t, int64(100), usage.Bytes)
+		assert.Equal(t, int64(2), usage.Files)
+	})
+
+	t.Run("Bytes Exceeded", func(t *testing.T) {
+		usage := &StorageUsage{UserID: "user-123", Bytes: 41}
+
+		assert.ErrorIs(t, usage.Add(60, defaultQuota), ErrStorageQuotaExceeded)
+		assert.Equal(t, int64(41), usage.Bytes)
+	})
+
+	t.Run("Files Exceeded", func(t *testing.T) {
+		usage := &StorageUsage{UserID: "user-123", Files: 2}
+
+		assert.ErrorIs(t, usage.Add(1, defaultQuota), ErrStorageQuotaExceeded)
+	})
+
+	t.Run("Larger Than The Quota", func(t *testing.T) {
+		usage := &StorageUsage{UserID: "user-123"}
+
+		assert.ErrorIs(t, usage.Add(101, defaultQuota), ErrFileTooLarge)
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		usage := &StorageUsage{UserID: "user-123", Bytes: 100, Files: 2, Quota: &StorageQuota{}}
+
+		assert.NoError(t, usage.Add(1000, defaultQuota))
+	})
+}
+
+func TestStorageUsage_Remove(t *testing.T) {
+	usage := &StorageUsage{UserID: "user-123", Bytes: 40, Files: 1}
+
+	usage.Remove(100)
+
+	assert.Equal(t, int64(0), usage.Bytes)
+	assert.Equal(t, int64(0), usage.Files)
+}
+
+func TestStorageUsage_Grow(t *testing.T) {
+	defaultQuota := &StorageQuota{Bytes: 100, Files: 2}
+	usage := &StorageUsage{UserID: "user-123", Bytes: 40, Files: 2}
+
+	assert.NoError(t, usage.Grow(60, defaultQuota), "the file count doesn't change")
+	assert.Equal(t, int64(100), usage.Bytes)
+	assert.Equal(t, int64(2), usage.Files)
+	assert.ErrorIs(t, usage.Grow(1, defaultQuota), ErrStorageQuotaExceeded)
+	assert.ErrorIs(t, usage.Grow(101, defaultQuota), ErrFileTooLarge)
+	assert.NoError(t, usage.Grow(-30, defaultQuota), "replaced content can shrink over the quota")
+	assert.Equal(t, int64(70), usage.Bytes)
+
+	usage.Shrink(100)
+	assert.Equal(t, int64(0), usage.Bytes)
+	assert.Equal(t, int64(2), usage.Files)
+}
+
+func TestStorageUsage_ToDTO(t *testing.T) {
+	defaultQuota := &StorageQuota{Bytes: 100}
+	usage := &StorageUsage{UserID: "user-123", Bytes: 40, Files: 1}
+
+	dto := usage.ToDTO(defaultQuota)
+	assert.Equal(t, int64(100), dto.QuotaBytes)
+	assert.False(t, dto.QuotaOverridden)
+
+	usage.Quota = &StorageQuota{Bytes: 500, Files: 5}
+	dto = usage.ToDTO(defaultQuota)
+	assert.Equal(t, int64(500), dto.QuotaBytes)
+	assert.Equal(t, int64(5), dto.QuotaFiles)
+	assert.True(t, dto.QuotaOverridden)
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrFileTypeNotAllowed    = errors.New("file type is not allowed")
+	ErrFileTypeMismatch      = errors.New("file content doesn't match its declared type")
+	ErrInvalidMediaTypeRange = errors.New("invalid media type range: use type/subtype or type/*")
+)
+
+// UploadPolicy decides which files can be uploaded from the media type detected from their content.
+// Types are matched against media ranges, e.g. image/png or image/*, the most specific range wins.
+type UploadPolicy struct {
+	allow    []string
+	deny     []string
+	maxSize  int64
+	maxSizes map[string]int64
+}
+
+// NewUploadPolicy creates a policy, an empty allowlist allows any type that isn't denied.
+// Files of types without a size limit of their own are limited to maxSize.
+func NewUploadPolicy(allow, deny []string, maxSize int64, maxSizes map[string]int64) (*UploadPolicy, error) {
+	policy := &UploadPolicy{maxSize: maxSize, maxSizes: make(map[string]int64, len(maxSizes))}
+
+	for _, mediaRange := range allow {
+		normalized, err := normalizeMediaRange(mediaRange)
+		if err != nil {
+			return nil, err
+		}
+		policy.allow = append(policy.allow, normalized)
+	}
+	for _, mediaRange := range deny {
+		normalized, err := normalizeMediaRange(mediaRange)
+		if err != nil {
+			return nil, err
+		}
+		policy.deny = append(policy.deny, normalized)
+	}
+	for mediaRange, size := range maxSizes {
+		normalized, err := normalizeMediaRange(mediaRange)
+		if err != nil {
+			return nil, err
+		}
+		policy.maxSizes[normalized] = size
+	}
+	return policy, nil
+}
+
+// Check tells whether a file of the detected type and size can be uploaded
+func (p *UploadPolicy) Check(contentType string, size int64) error {
+	contentType = MediaTypeEssence(contentType)
+
+	denied := mostSpecificMatch(p.deny, contentType)
+	allowed := len(p.allow) == 0 || mostSpecificMatch(p.allow, contentType) != ""
+	// an allowed range more specific than the denied one is an exception, e.g. deny image/* but allow image/png
+	if !allowed || (denied != "" && !isMoreSpecific(mostSpecificMatch(p.allow, contentType), denied)) {
+		return fmt.Errorf("%w: %s", ErrFileTypeNotAllowed, contentType)
+	}
+
+	if size > p.MaxSize(contentType) {
+		return ErrFileTooLarge
+	}
+	return nil
+}
+
+// MaxSize is the size limit of a type
+func (p *UploadPolicy) MaxSize(contentType string) int64 {
+	ranges := make([]string, 0, len(p.maxSizes))
+	for mediaRange := range p.maxSizes {
+		ranges = append(ranges, mediaRange)
+	}
+	if match := mostSpecificMatch(ranges, MediaTypeEssence(contentType)); match != "" {
+		return p.maxSizes[match]
+	}
+	return p.maxSize
+}
+
+// LargestMaxSize is the limit of the largest file of any type, bigger uploads can be rejected before reading them
+func (p *UploadPolicy) LargestMaxSize() int64 {
+	largest := p.maxSize
+	for _, size := range p.maxSizes {
+		largest = max(largest, size)
+	}
+	return largest
+}
+
+// MediaTypeEssence strips the parameters of a media type, e.g. "text/plain; charset=utf-8" becomes "text/plain"
+func MediaTypeEssence(mediaType string) string {
+	essence, _, _ := strings.Cut(mediaType, ";")
+	return strings.ToLower(strings.TrimSpace(essence))
+}
+
+func normalizeMediaRange(mediaRange string) (string, error) {
+	mediaRange = MediaTypeEssence(mediaRange)
+	mainType, subType, ok := strings.Cut(mediaRange, "/")
+	if !ok || mainType == "" || mainType == "*" || subType == "" || strings.Contains(subType, "/") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidMediaTypeRange, mediaRange)
+	}
+	return mediaRange, nil
+}
+
+// mostSpecificMatch returns the exact range of the type if listed, else its type/* range, else an empty string
+func mostSpecificMatch(ranges []string, contentType string) string {
+	mainType, _, _ := strings.Cut(contentType, "/")
+	match := ""
+	for _, mediaRange := range ranges {
+		if mediaRange == contentType {
+			return mediaRange
+		}
+		if mediaRange == mainType+"/*" {
+			match = mediaRange
+		}
+	}
+	return match
+}
+
+func isMoreSpecific(mediaRange, than string) bool {
+	return mediaRange != "" && !strings.HasSuffix(mediaRange, "/*") && strings.HasSuffix(than, "/*")
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUploadPolicy(t *testing.T) {
+	_, err := NewUploadPolicy([]string{"image/*", "Application/PDF"}, []string{"image/svg+xml"}, 1024, map[string]int64{"video/*": 4096})
+	assert.NoError(t, err)
+
+	for _, mediaRange := range []string{"image", "*/*", "image/", "/png", "image/png/x"} {
+		_, err := NewUploadPolicy([]string{mediaRange}, nil, 1024, nil)
+		assert.ErrorIs(t, err, ErrInvalidMediaTypeRange, mediaRange)
+	}
+	_, err = NewUploadPolicy(nil, nil, 1024, map[string]int64{"video": 4096})
+	assert.ErrorIs(t, err, ErrInvalidMediaTypeRange)
+}
+
+func TestUploadPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		allow       []string
+		deny        []string
+		contentType string
+		size        int64
+		expectedErr error
+	}{
+		{name: "Empty allowlist", contentType: "application/zip", size: 512},
+		{name: "Denied", deny: []string{"application/x-elf"}, contentType: "application/x-elf", size: 512, expectedErr: ErrFileTypeNotAllowed},
+		{name: "Denied range", deny: []string{"text/*"}, contentType: "text/html; charset=utf-8", size: 512, expectedErr: ErrFileTypeNotAllowed},
+		{name: "Allowed", allow: []string{"image/png"}, contentType: "image/png", size: 512},
+		{name: "Allowed range", allow: []string{"image/*"}, contentType: "image/jpeg", size: 512},
+		{name: "Not allowed", allow: []string{"image/*"}, contentType: "application/pdf", size: 512, expectedErr: ErrFileTypeNotAllowed},
+		{name: "Deny wins over same range", allow: []string{"image/*"}, deny: []string{"image/*"}, contentType: "image/png", size: 512, expectedErr: ErrFileTypeNotAllowed},
+		{name: "Deny wins over a broader range", allow: []string{"image/*"}, deny: []string{"image/svg+xml"}, contentType: "image/svg+xml", size: 512, expectedErr: ErrFileTypeNotAllowed},
+		{name: "Allowed exception", allow: []string{"image/png"}, deny: []string{"image/*"}, contentType: "image/png", size: 512},
+		{name: "Too large", contentType: "application/zip", size: 1025, expectedErr: ErrFileTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewUploadPolicy(tt.allow, tt.deny, 1024, nil)
+			assert.NoError(t, err)
+
+			err = policy.Check(tt.contentType, tt.size)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUploadPolicy_MaxSize(t *testing.T) {
+	policy, _ := NewUploadPolicy(nil, nil, 1024, map[string]int64{"image/*": 4096, "image/gif": 2048})
+
+	assert.Equal(t, int64(2048), policy.MaxSize("image/gif"))
+	assert.Equal(t, int64(4096), policy.MaxSize("image/png"))
+	assert.Equal(t, int64(1024), policy.MaxSize("application/pdf"))
+	assert.Equal(t, int64(4096), policy.LargestMaxSize())
+	assert.NoError(t, policy.Check("image/png", 4096))
+	assert.ErrorIs(t, policy.Check("image/gif", 4096), ErrFileTooLarge)
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+)
+
+var (
+	ErrInvalidUploadLength  = errors.New("invalid upload length: it must be a positive number of bytes")
+	ErrInvalidFilename      = errors.New("invalid file name: it is required and at most 255 characters")
+	ErrUploadOffsetMismatch = errors.New("upload offset doesn't match the bytes received so far")
+	ErrUploadExceedsLength  = errors.New("chunk exceeds the length of the upload")
+	ErrInvalidUploadOffset  = errors.New("invalid upload offset: it must be a non-negative number of bytes")
+	ErrInvalidUploadMeta    = errors.New("invalid upload metadata: use comma-separated keys with base64 values")
+	ErrInvalidChunkType     = errors.New("invalid chunk content type: use application/offset+octet-stream")
+)
+
+// UploadURLTemplate is the path of a resumable upload
+const UploadURLTemplate = "/v1/users/%s/uploads/%s"
+
+// Upload is a resumable upload in progress. Its content is received in chunks and becomes a File once complete.
+type Upload struct {
+	ID           string
+	UserID       string
+	Filename     string
+	FolderID     string // folder the file is added to, empty for the root
+	DeclaredType string
+	Digest       string // expected hex SHA-256 of the content, if the client sent one
+	Length       int64
+	Offset       int64
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func NewUpload(userID, filename, folderID, declaredType, digest string, length int64, expiresAt time.Time) (*Upload, error) {
+	if length <= 0 {
+		return nil, ErrInvalidUploadLength
+	}
+	filename = strings.TrimSpace(filename)
+	if filename == "" || len(filename) > 255 {
+		return nil, ErrInvalidFilename
+	}
+	if digest != "" {
+		var err error
+		if digest, err = ParseDigest(digest); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Upload{
+		UserID:       userID,
+		Filename:     filename,
+		FolderID:     folderID,
+		DeclaredType: MediaTypeEssence(declaredType),
+		Digest:       digest,
+		Length:       length,
+		CreatedAt:    time.Now(),
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// CheckChunk tells whether a chunk of the given size can be appended at the offset, a negative size is unknown
+func (u *Upload) CheckChunk(offset, size int64) error {
+	if offset != u.Offset {
+		return ErrUploadOffsetMismatch
+	}
+	if size > u.Remaining() {
+		return ErrUploadExceedsLength
+	}
+	return nil
+}
+
+// Advance records the bytes received and extends the expiration, so an active upload doesn't expire
+func (u *Upload) Advance(received int64, expiresAt time.Time) {
+	u.Offset += received
+	u.ExpiresAt = expiresAt
+}
+
+func (u *Upload) Remaining() int64 {
+	return u.Length - u.Offset
+}
+
+func (u *Upload) IsComplete() bool {
+	return u.Offset == u.Length
+}
+
+func (u *Upload) IsExpired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
+
+// URL is the location the chunks of the upload are sent to
+func (u *Upload) URL() string {
+	return fmt.Sprintf(UploadURLTemplate, u.UserID, u.ID)
+}
+
+func (u *Upload) ToDTO() *v1.Upload {
+	return &v1.Upload{
+		ID:        u.ID,
+		UserID:    u.UserID,
+		Filename:  u.Filename,
+		FolderID:  u.FolderID,
+		Length:    u.Length,
+		Offset:    u.Offset,
+		ExpiresAt: u.ExpiresAt,
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUpload(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	upload, err := NewUpload("user-123", " report.pdf ", "2024", "application/pdf; charset=binary", "", 1024, expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "report.pdf", upload.Filename)
+	assert.Equal(t, "2024", upload.FolderID)
+	assert.Equal(t, "application/pdf", upload.DeclaredType)
+	assert.Equal(t, int64(0), upload.Offset)
+
+	upload, err = NewUpload("user-123", "hello.txt", "", "", "sha-256=LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=", 5, expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, helloDigest, upload.Digest)
+
+	_, err = NewUpload("user-123", "report.pdf", "", "", "", 0, expiresAt)
+	assert.ErrorIs(t, err, ErrInvalidUploadLength)
+	_, err = NewUpload("user-123", " ", "", "", "", 1024, expiresAt)
+	assert.ErrorIs(t, err, ErrInvalidFilename)
+	_, err = NewUpload("user-123", "report.pdf", "", "", "abc", 1024, expiresAt)
+	assert.ErrorIs(t, err, ErrInvalidDigest)
+}
+
+func TestUpload_CheckChunk(t *testing.T) {
+	upload := &Upload{Length: 100, Offset: 40}
+
+	assert.NoError(t, upload.CheckChunk(40, 60))
+	assert.NoError(t, upload.CheckChunk(40, -1), "chunks of unknown size are accepted")
+	assert.ErrorIs(t, upload.CheckChunk(0, 10), ErrUploadOffsetMismatch)
+	assert.ErrorIs(t, upload.CheckChunk(40, 61), ErrUploadExceedsLength)
+}
+
+func TestUpload_Advance(t *testing.T) {
+	now := time.Now()
+	upload := &Upload{Length: 100, Offset: 40, ExpiresAt: now}
+	assert.True(t, upload.IsExpired(now))
+
+	upload.Advance(60, now.Add(time.Hour))
+
+	assert.True(t, upload.IsComplete())
+	assert.Equal(t, int64(0), upload.Remaining())
+	assert.False(t, upload.IsExpired(now), "receiving a chunk extends the expiration")
+}
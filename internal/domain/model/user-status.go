@@ -0,0 +1,95 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid user status transition")
+	ErrUnknownStatusAction     = errors.New("unknown user status action")
+	ErrStatusReasonRequired    = errors.New("a reason is required to change the user status")
+	ErrFilesReadOnly           = errors.New("user files are read-only")
+)
+
+type UserStatus string
+
+const (
+	UserPending     UserStatus = "pending"
+	UserActive      UserStatus = "active"
+	UserSuspended   UserStatus = "suspended"
+	UserLocked      UserStatus = "locked"
+	UserDeactivated UserStatus = "deactivated"
+)
+
+type StatusAction string
+
+const (
+	ActivateAction   StatusAction = "activate"
+	SuspendAction    StatusAction = "suspend"
+	LockAction       StatusAction = "lock"
+	UnlockAction     StatusAction = "unlock"
+	ReactivateAction StatusAction = "reactivate"
+	DeactivateAction StatusAction = "deactivate"
+)
+
+type statusTransition struct {
+	from []UserStatus
+	to   UserStatus
+}
+
+// statusTransitions is the user account state machine
+var statusTransitions = map[StatusAction]statusTransition{
+	ActivateAction:   {from: []UserStatus{UserPending}, to: UserActive},
+	SuspendAction:    {from: []UserStatus{UserActive, UserLocked}, to: UserSuspended},
+	LockAction:       {from: []UserStatus{UserActive}, to: UserLocked},
+	UnlockAction:     {from: []UserStatus{UserLocked}, to: UserActive},
+	ReactivateAction: {from: []UserStatus{UserSuspended, UserDeactivated}, to: UserActive},
+	DeactivateAction: {from: []UserStatus{UserPending, UserActive, UserSuspended, UserLocked}, to: UserDeactivated},
+}
+
+// StatusChange records a transition of the user status, it is carried by the state-change event
+type StatusChange struct {
+	Action StatusAction
+	From   UserStatus
+	To     UserStatus
+	Reason string
+	At     time.Time
+}
+
+// Transition applies an action to the user status, enforcing the allowed transitions
+func (u *User) Transition(action StatusAction, reason string) (*StatusChange, error) {
+	transition, ok := statusTransitions[action]
+	if !ok {
+		return nil, ErrUnknownStatusAction
+	}
+
+	if reason == "" {
+		return nil, ErrStatusReasonRequired
+	}
+
+	if !slices.Contains(transition.from, u.status) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	change := &StatusChange{Action: action, From: u.status, To: transition.to, Reason: reason, At: time.Now()}
+	u.status = transition.to
+	u.statusReason = reason
+	return change, nil
+}
+
+// RestoreStatus sets a persisted status without going through the transitions, it is meant for repositories
+func (u *User) RestoreStatus(status UserStatus, reason string) {
+	u.status = status
+	u.statusReason = reason
+}
+
+func (u *User) Status() UserStatus {
+	return u.status
+}
+
+// CanModifyFiles tells whether files can be added or removed, they are read-only for suspended and deactivated users
+func (u *User) CanModifyFiles() bool {
+	return u.status != UserSuspended && u.status != UserDeactivated
+}
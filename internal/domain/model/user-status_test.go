@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_Transition(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        UserStatus
+		action      StatusAction
+		reason      string
+		expected    UserStatus
+		expectedErr error
+	}{
+		{name: "Activate pending user", from: UserPending, action: ActivateAction, reason: "verified", expected: UserActive},
+		{name: "Suspend active user", from: UserActive, action: SuspendAction, reason: "abuse", expected: UserSuspended},
+		{name: "Lock active user", from: UserActive, action: LockAction, reason: "too many failed logins", expected: UserLocked},
+		{name: "Unlock locked user", from: UserLocked, action: UnlockAction, reason: "identity confirmed", expected: UserActive},
+		{name: "Reactivate suspended user", from: UserSuspended, action: ReactivateAction, reason: "appeal accepted", expected: UserActive},
+		{name: "Reactivate deactivated user", from: UserDeactivated, action: ReactivateAction, reason: "came back", expected: UserActive},
+		{name: "Deactivate locked user", from: UserLocked, action: DeactivateAction, reason: "closed", expected: UserDeactivated},
+		{name: "Suspend pending user", from: UserPending, action: SuspendAction, reason: "abuse", expectedErr: ErrInvalidStatusTransition},
+		{name: "Unlock active user", from: UserActive, action: UnlockAction, reason: "none", expectedErr: ErrInvalidStatusTransition},
+		{name: "Deactivate deactivated user", from: UserDeactivated, action: DeactivateAction, reason: "again", expectedErr: ErrInvalidStatusTransition},
+		{name: "Unknown action", from: UserActive, action: "ban", reason: "abuse", expectedErr: ErrUnknownStatusAction},
+		{name: "Missing reason", from: UserActive, action: SuspendAction, expectedErr: ErrStatusReasonRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "user-123", status: tt.from}
+
+			change, err := user.Transition(tt.action, tt.reason)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, change)
+				assert.Equal(t, tt.from, user.status)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.from, change.From)
+				assert.Equal(t, tt.expected, change.To)
+				assert.Equal(t, tt.reason, change.Reason)
+				assert.Equal(t, tt.expected, user.status)
+				assert.Equal(t, tt.reason, user.statusReason)
+			}
+		})
+	}
+}
+
+func TestUser_CanModifyFiles(t *testing.T) {
+	assert.True(t, (&User{status: UserActive}).CanModifyFiles())
+	assert.True(t, (&User{status: UserPending}).CanModifyFiles())
+	assert.False(t, (&User{status: UserSuspended}).CanModifyFiles())
+	assert.False(t, (&User{status: UserDeactivated}).CanModifyFiles())
+}
@@ -1,10 +1,14 @@
 package model
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"net/mail"
+	"regexp"
+	"slices"
 	"time"
 
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
@@ -16,19 +20,30 @@ var (
 	ErrInvalidEmailAddress     = errors.New("invalid email address")
 	ErrInvalidDob              = errors.New("invalid date of birth")
 	ErrMinAgeRequirementNotMet = fmt.Errorf("user must be at least %d years old", MinimumAge)
+	ErrInvalidAttributeName    = errors.New("invalid attribute name: use letters, digits and _ (max 64)")
 )
 
+var attributeNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
 type User struct {
-	ID    string
-	name  string
-	email string
-	dob   string
-	files []*File
+	ID            string
+	name          string
+	email         string
+	emailVerified bool
+	dob           string
+	status        UserStatus
+	statusReason  string
+	roles         []string
+	attributes    map[string]any
+	contacts      []*ContactPoint
+	addresses     []*Address
+	avatar        *Avatar
+	files         []*File
 }
 
 func NewUser(name string, email string, dob string) (*User, error) {
 
-	user := &User{name: name}
+	user := &User{name: name, status: UserPending}
 	err := user.SetDob(dob)
 	if err != nil {
 		return nil, err
@@ -38,6 +53,10 @@ func NewUser(name string, email string, dob string) (*User, error) {
 	if err != nil {
 		return nil, err
 	}
+	user.roles = make([]string, 0)
+	user.attributes = make(map[string]any)
+	user.contacts = make([]*ContactPoint, 0)
+	user.addresses = make([]*Address, 0)
 	user.files = make([]*File, 0)
 	return user, nil
 }
@@ -68,11 +87,64 @@ func (u *User) SetEmail(email string) error {
 		return ErrInvalidEmailAddress
 	}
 
+	// a new address has to be verified again, unless it was a verified contact point
+	if parsedEmail.Address != u.email {
+		u.emailVerified = false
+		if contact := u.FindContactPoint(ContactEmail, parsedEmail.Address); contact != nil {
+			u.emailVerified = contact.Verified
+			u.contacts = slices.DeleteFunc(u.contacts, func(c *ContactPoint) bool { return c == contact })
+		}
+	}
 	u.email = parsedEmail.Address
 
 	return nil
 }
 
+func (u *User) VerifyEmail() {
+	u.emailVerified = true
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.emailVerified
+}
+
+// SetRoles replaces the roles the user holds through its groups, duplicates are dropped
+func (u *User) SetRoles(roles []string) {
+	sorted := append(make([]string, 0, len(roles)), roles...)
+	slices.Sort(sorted)
+	u.roles = slices.Compact(sorted)
+}
+
+func (u *User) GetRoles() []string {
+	return u.roles
+}
+
+// SetAttributes replaces the custom attributes, their values are checked against the deployment's schema by the caller
+func (u *User) SetAttributes(attributes map[string]any) error {
+	for name := range attributes {
+		if err := ValidateAttributeName(name); err != nil {
+			return err
+		}
+	}
+	u.attributes = maps.Clone(attributes)
+	if u.attributes == nil {
+		u.attributes = make(map[string]any)
+	}
+	return nil
+}
+
+func (u *User) GetAttributes() map[string]any {
+	return u.attributes
+}
+
+// ValidateAttributeName checks that an attribute name can be used as a JSON path member
+func ValidateAttributeName(name string) error {
+	if !attributeNamePattern.MatchString(name) {
+		return ErrInvalidAttributeName
+	}
+	return nil
+}
+
 func (u *User) AddFile(file *File) {
 	u.files = append(u.files, file)
 }
@@ -90,6 +162,21 @@ func (u *User) DeleteFiles() {
 	u.files = make([]*File, 0)
 }
 
+// Erase irreversibly overwrites the user's personal data with tombstone values and drops its files
+func (u *User) Erase() {
+	u.name = ErasedName
+	u.email = ErasedEmail(u.ID)
+	u.dob = ErasedDob
+	u.status = UserDeactivated
+	u.statusReason = ErasedName
+	u.roles = make([]string, 0)
+	u.attributes = make(map[string]any)
+	u.contacts = make([]*ContactPoint, 0)
+	u.addresses = make([]*Address, 0)
+	u.avatar = nil
+	u.DeleteFiles()
+}
+
 func (u *User) GetFiles() []*File {
 	return u.files
 }
@@ -99,11 +186,36 @@ func (u *User) ToDTO() *v1.User {
 	for _, f := range u.files {
 		files = append(files, f.ToDTO())
 	}
+	contacts := make([]*v1.ContactPoint, 0, len(u.contacts))
+	for _, c := range u.contacts {
+		contacts = append(contacts, c.ToDTO())
+	}
+	addresses := make([]*v1.Address, 0, len(u.addresses))
+	for _, a := range u.addresses {
+		addresses = append(addresses, a.ToDTO())
+	}
+	var avatarURL string
+	if u.avatar != nil {
+		avatarURL = fmt.Sprintf(AvatarURLTemplate, u.ID, u.avatar.ID)
+	}
 	return &v1.User{
-		ID:    u.ID,
-		Name:  u.name,
-		Email: u.email,
-		DOB:   u.dob,
-		Files: files,
+		ID:            u.ID,
+		Name:          u.name,
+		Email:         u.email,
+		EmailVerified: u.emailVerified,
+		DOB:           u.dob,
+		Status:        string(u.status),
+		StatusReason:  u.statusReason,
+		Roles:         slices.Clone(u.roles),
+		Attributes:    maps.Clone(u.attributes),
+		Contacts:      contacts,
+		Addresses:     addresses,
+		AvatarURL:     avatarURL,
+		Files:         files,
 	}
 }
+
+// MarshalJSON encodes the user as its DTO, so that published events carry the user's data
+func (u *User) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.ToDTO())
+}
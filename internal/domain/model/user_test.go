@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -88,6 +89,24 @@ func TestUser_SetEmail(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "john.doe@example.com", user.email)
 }
+func TestUser_SetEmail_ResetsVerification(t *testing.T) {
+	user := &User{email: "jane.doe@example.com", emailVerified: true}
+
+	err := user.SetEmail("Jane Doe <jane.doe@example.com>")
+	assert.NoError(t, err)
+	assert.True(t, user.IsEmailVerified(), "same address should stay verified")
+
+	err = user.SetEmail("john.doe@example.com")
+	assert.NoError(t, err)
+	assert.False(t, user.IsEmailVerified())
+}
+
+func TestUser_VerifyEmail(t *testing.T) {
+	user := &User{email: "jane.doe@example.com"}
+	user.VerifyEmail()
+	assert.True(t, user.IsEmailVerified())
+}
+
 func TestUser_SetDob(t *testing.T) {
 	user := &User{dob: "2000-01-01"}
 	err := user.SetDob("1990-12-31")
@@ -125,6 +144,17 @@ func TestUser_DeleteFiles(t *testing.T) {
 	assert.Len(t, user.files, 0)
 }
 
+func TestUser_Erase(t *testing.T) {
+	user := &User{ID: "user-123", name: "Test User", email: "test@example.com", dob: "1995-05-10", files: []*File{
+		{ID: "file-123", UserID: "user-123", Name: "file1.txt", Path: "/tmp/user/user-123/files/file1.txt", Size: 128},
+	}}
+	user.Erase()
+	assert.Equal(t, ErasedName, user.name)
+	assert.Equal(t, "erased+user-123@erased.invalid", user.email)
+	assert.Equal(t, ErasedDob, user.dob)
+	assert.Len(t, user.files, 0)
+}
+
 func TestUser_GetFiles(t *testing.T) {
 	user := &User{ID: "user-123", files: []*File{
 		{ID: "file-123", UserID: "user-123", Name: "file1.txt", Path: "/tmp/user/user-123/files/file1.txt", Size: 128},
@@ -147,10 +177,12 @@ func TestUser_ToDTO(t *testing.T) {
 	}
 
 	expectedDto := &v1.User{
-		ID:    "user-123",
-		Name:  "Test User",
-		Email: "test@example.com",
-		DOB:   "1995-05-10",
+		ID:        "user-123",
+		Name:      "Test User",
+		Email:     "test@example.com",
+		DOB:       "1995-05-10",
+		Contacts:  []*v1.ContactPoint{},
+		Addresses: []*v1.Address{},
 		Files: []*v1.File{
 			{ID: "123-456", UserID: "user-123", Name: "example.txt", Path: "/tmp/user/user-123/files/example.txt", Size: 128},
 		},
@@ -160,3 +192,46 @@ func TestUser_ToDTO(t *testing.T) {
 
 	assert.Equal(t, expectedDto, dto)
 }
+
+func TestUser_SetRoles(t *testing.T) {
+	user, _ := NewUser("Test User", "test@example.com", "1990-01-01")
+	assert.Equal(t, []string{}, user.ToDTO().Roles)
+
+	user.SetRoles([]string{"editor", "admin", "editor"})
+	assert.Equal(t, []string{"admin", "editor"}, user.GetRoles())
+
+	user.Erase()
+	assert.Empty(t, user.GetRoles())
+}
+
+func TestUser_MarshalJSON(t *testing.T) {
+	user, _ := NewUser("Test User", "test@example.com", "1990-01-01")
+	user.ID = "user-123"
+	user.SetRoles([]string{"admin"})
+	_ = user.SetAttributes(map[string]any{"department": "sales"})
+
+	encoded, err := json.Marshal(user)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"user-123","name":"Test User","email":"test@example.com","emailVerified":false,
+		"dob":"1990-01-01","status":"pending","roles":["admin"],"attributes":{"department":"sales"},"contacts":[],"addresses":[],"files":[]}`, string(encoded))
+}
+
+func TestUser_SetAttributes(t *testing.T) {
+	user, _ := NewUser("Test User", "test@example.com", "1990-01-01")
+
+	attributes := map[string]any{"department": "sales", "cost_center": 42.0}
+	assert.NoError(t, user.SetAttributes(attributes))
+	attributes["department"] = "support"
+	assert.Equal(t, "sales", user.GetAttributes()["department"], "attributes are copied")
+
+	assert.ErrorIs(t, user.SetAttributes(map[string]any{"cost-center": 42.0}), ErrInvalidAttributeName)
+	assert.ErrorIs(t, user.SetAttributes(map[string]any{`a"b`: 1.0}), ErrInvalidAttributeName)
+
+	assert.NoError(t, user.SetAttributes(nil))
+	assert.Equal(t, map[string]any{}, user.ToDTO().Attributes)
+
+	_ = user.SetAttributes(attributes)
+	user.Erase()
+	assert.Empty(t, user.GetAttributes())
+}
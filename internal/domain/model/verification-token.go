@@ -0,0 +1,65 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid verification token")
+	ErrVerificationTokenExpired = errors.New("verification token has expired")
+	ErrEmailAlreadyVerified     = errors.New("email address is already verified")
+)
+
+const opaqueTokenLength = 32
+
+// VerificationToken is a single-use token proving that a user controls an email address.
+// Only the hash of the token is kept, the token itself is sent to the address.
+type VerificationToken struct {
+	ID        string
+	UserID    string
+	Email     string
+	Hash      string
+	ExpiresAt time.Time
+}
+
+// NewVerificationToken returns the token to persist along with its plain value to send
+func NewVerificationToken(userID, email string, ttl time.Duration) (*VerificationToken, string, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &VerificationToken{
+		UserID:    userID,
+		Email:     email,
+		Hash:      HashVerificationToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}, token, nil
+}
+
+func HashVerificationToken(token string) string {
+	return hashOpaqueToken(token)
+}
+
+// newOpaqueToken returns a random token meant to be sent to users
+func newOpaqueToken() (string, error) {
+	raw := make([]byte, opaqueTokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// hashOpaqueToken is the persisted form of a token, so that a database leak doesn't leak usable tokens
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *VerificationToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "io"
+
+// PartialUploadRepository stores the content of resumable uploads until they are complete
+//
+//go:generate mockery --name PartialUploadRepository --output ../../mocks --outpkg mocks
+type PartialUploadRepository interface {
+	Create(uploadID string) error
+	// Append writes the content at the offset and returns the bytes written, even if the content couldn't be read
+	// to the end, so an interrupted chunk can be resumed
+	Append(uploadID string, offset int64, content io.Reader) (int64, error)
+	Open(uploadID string) (io.ReadCloser, error)
+	Delete(uploadID string) error
+}
@@ -0,0 +1,7 @@
+package domain
+
+//go:generate mockery --name PasswordHasher --output ../../mocks --outpkg mocks
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, hash string) (bool, error)
+}
@@ -11,15 +11,61 @@ var (
 	ErrUserAlreadyExists = errors.New("user already exists")
 )
 
+// UserFilter restricts the users returned by a listing, nil fields match any user
+type UserFilter struct {
+	EmailVerified *bool
+	GroupID       string
+	// Attributes match users whose top-level attributes have these values
+	Attributes map[string]string
+}
+
 //go:generate mockery --name UserRepository --output ../../mocks --outpkg mocks
 type UserRepository interface {
 	Create(user *model.User) (string, error)
 	Get(id string) (*model.User, error)
 	GetByEmail(email string) (*model.User, error)
-	List() ([]*model.User, error)
+	List(filter *UserFilter) ([]*model.User, error)
 	Update(id string, user *model.User) error
 	Delete(id string) error
+	// GetIncludingDeleted returns the user even if it has been soft-deleted
+	GetIncludingDeleted(id string) (*model.User, error)
+	// ListDeletedIDs returns the IDs of the soft-deleted users, their files are deleted with them
+	ListDeletedIDs() ([]string, error)
+	// Erase persists an erased user, hard-deletes its files and records the erasure
+	Erase(user *model.User, erasure *model.Erasure) error
 	GetFiles(userID string) ([]*model.File, error)
 	GetFile(userID, fileID string) (*model.File, error)
+	// AddFile stores a file of the user and accounts for it in the user's storage usage in one transaction,
+	// it fails with model.ErrStorageQuotaExceeded if the file doesn't fit the user's quota or the default one
+	AddFile(file *model.File, defaultQuota *model.StorageQuota) error
+	// ReplaceFile saves the new content of a file and its previous version in one transaction, the version is
+	// still accounted for in the user's storage usage. Without a version the replaced content is deducted. It
+	// fails with model.ErrFileContentChanged if the file no longer has the content of the previous version. The
+	// restored version, if any, is the one whose content the file gets: it's deleted, its content is the file's.
+	ReplaceFile(file *model.File, previous, restored *model.FileVersion, defaultQuota *model.StorageQuota) error
+	// DeleteFile deletes a file of the user and its versions, and deducts them from the user's storage usage in
+	// one transaction
+	DeleteFile(userID, fileID string) error
+	// UpdateFile saves the metadata of a file of the user
+	UpdateFile(file *model.File) error
+	// ListFilesPendingScan returns the oldest files of any user waiting for a malware scan
+	ListFilesPendingScan(limit int) ([]*model.File, error)
 	DeleteFiles(userID string) error
+	// GetFileVersions returns the prior versions of a file, the newest first
+	GetFileVersions(userID, fileID string) ([]*model.FileVersion, error)
+	GetFileVersion(userID, fileID, versionID string) (*model.FileVersion, error)
+	// DeleteFileVersion deletes a version and deducts it from the user's storage usage in one transaction
+	DeleteFileVersion(userID, versionID string) error
+	GetStorageUsage(userID string) (*model.StorageUsage, error)
+	// SetStorageQuota overrides the default quota of the user, nil restores the default
+	SetStorageQuota(userID string, quota *model.StorageQuota) error
+	// GetStorageTotals sums the storage usage of all the users
+	GetStorageTotals() (*model.StorageUsage, error)
+	GetFolders(userID string) ([]*model.Folder, error)
+	// CreateFolder fails with model.ErrFolderNameTaken if the parent has a folder with the same name
+	CreateFolder(folder *model.Folder) error
+	// UpdateFolder saves the name and parent of a folder
+	UpdateFolder(folder *model.Folder) error
+	// DeleteFolder deletes a folder of the user, it fails with model.ErrFolderNotEmpty if files or folders are in it
+	DeleteFolder(userID, folderID string) error
 }
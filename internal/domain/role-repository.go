@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleAlreadyExists = errors.New("role already exists")
+	ErrRoleInUse         = errors.New("role is assigned to a group")
+)
+
+//go:generate mockery --name RoleRepository --output ../../mocks --outpkg mocks
+type RoleRepository interface {
+	Create(role *model.Role) error
+	Get(name string) (*model.Role, error)
+	List() ([]*model.Role, error)
+	// Delete removes a role that isn't assigned to any group
+	Delete(name string) error
+}
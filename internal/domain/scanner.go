@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrScanFailed = errors.New("malware scan failed")
+
+// Scanner scans content for malware
+//
+//go:generate mockery --name Scanner --output ../../mocks --outpkg mocks
+type Scanner interface {
+	// Scan reads the content to the end and returns the name of the threat found, empty if the content is clean.
+	// Errors match ErrScanFailed if the scanner couldn't tell.
+	Scan(content io.Reader) (threat string, err error)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+// TokenIssuer issues the signed access tokens returned on login
+//
+//go:generate mockery --name TokenIssuer --output ../../mocks --outpkg mocks
+type TokenIssuer interface {
+	Issue(user *model.User) (token string, expiresAt time.Time, err error)
+}
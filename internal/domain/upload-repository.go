@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+var (
+	ErrUploadNotFound = errors.New("upload not found")
+	ErrUploadExpired  = errors.New("upload has expired")
+	ErrUploadLocked   = errors.New("upload is receiving another chunk")
+)
+
+//go:generate mockery --name UploadRepository --output ../../mocks --outpkg mocks
+type UploadRepository interface {
+	Create(upload *model.Upload) (string, error)
+	Get(userID, uploadID string) (*model.Upload, error)
+	Update(upload *model.Upload) error
+	Delete(uploadID string) error
+	List(userID string) ([]*model.Upload, error)
+	ListExpired(now time.Time) ([]*model.Upload, error)
+}
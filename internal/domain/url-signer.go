@@ -0,0 +1,9 @@
+package domain
+
+// URLSigner signs the pre-signed URLs, so that they can't be forged or altered
+//
+//go:generate mockery --name URLSigner --output ../../mocks --outpkg mocks
+type URLSigner interface {
+	Sign(payload string) string
+	Verify(payload, signature string) bool
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+//go:generate mockery --name VerificationTokenRepository --output ../../mocks --outpkg mocks
+type VerificationTokenRepository interface {
+	Create(token *model.VerificationToken) error
+	// GetByHash returns model.ErrInvalidVerificationToken when no token matches
+	GetByHash(hash string) (*model.VerificationToken, error)
+	DeleteByUser(userID string) error
+}
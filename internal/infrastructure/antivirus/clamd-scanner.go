@@ -0,0 +1,121 @@
+package antivirus
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+)
+
+// chunkSize is the size of the chunks streamed to clamd, it must stay below its StreamMaxLength
+const chunkSize = 64 << 10
+
+// ClamdScanner scans content with the INSTREAM command of clamd, over TCP or a Unix socket
+type ClamdScanner struct {
+	network string
+	address string
+	// timeout applies to connecting and to each exchange with clamd, not to the whole scan
+	timeout time.Duration
+}
+
+// NewClamdScanner connects to clamd at an address like tcp://localhost:3310 or unix:///run/clamav/clamd.ctl
+func NewClamdScanner(address string, timeout time.Duration) (*ClamdScanner, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clamd address '%s': %w", address, err)
+	}
+	switch u.Scheme {
+	case "tcp":
+		return &ClamdScanner{network: "tcp", address: u.Host, timeout: timeout}, nil
+	case "unix":
+		return &ClamdScanner{network: "unix", address: u.Path, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("invalid clamd address '%s': the scheme must be tcp or unix", address)
+	}
+}
+
+func (s *ClamdScanner) Scan(content io.Reader) (string, error) {
+	conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", domain.ErrScanFailed, err)
+	}
+	defer conn.Close()
+
+	if err := s.stream(conn, content); err != nil {
+		// clamd replies before closing the connection when the stream exceeds its limit
+		if reply, replyErr := s.readReply(conn); replyErr == nil {
+			if _, parseErr := parseReply(reply); parseErr != nil {
+				return "", parseErr
+			}
+		}
+		return "", fmt.Errorf("%w: %s", domain.ErrScanFailed, err)
+	}
+
+	reply, err := s.readReply(conn)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", domain.ErrScanFailed, err)
+	}
+	return parseReply(reply)
+}
+
+// stream sends the content as length-prefixed chunks, a chunk of length 0 ends it
+func (s *ClamdScanner) stream(conn net.Conn, content io.Reader) error {
+	conn.SetDeadline(time.Now().Add(s.timeout))
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, err := io.ReadFull(content, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			conn.SetDeadline(time.Now().Add(s.timeout))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	conn.SetDeadline(time.Now().Add(s.timeout))
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// readReply reads the reply, terminated by a null character with the z prefix of the command
+func (s *ClamdScanner) readReply(conn net.Conn) (string, error) {
+	// the scan itself happens once the stream is complete, large files take a while
+	conn.SetDeadline(time.Now().Add(s.timeout))
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return "", err
+	}
+	return strings.TrimRight(reply, "\x00"), nil
+}
+
+// parseReply reads a reply like "stream: OK", "stream: Eicar-Test-Signature FOUND" or
+// "INSTREAM size limit exceeded. ERROR"
+func parseReply(reply string) (string, error) {
+	reply = strings.TrimSpace(reply)
+	switch {
+	case strings.HasSuffix(reply, " FOUND"):
+		return strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND"), nil
+	case reply == "stream: OK":
+		return "", nil
+	default:
+		return "", fmt.Errorf("%w: clamd replied '%s'", domain.ErrScanFailed, reply)
+	}
+}
@@ -0,0 +1,140 @@
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClamd serves the INSTREAM command, it flags the content with the fake scanner and replies with ERROR past
+// maxLength
+func fakeClamd(t *testing.T, network, address string, maxLength int) string {
+	listener, err := net.Listen(network, address)
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveClamd(conn, maxLength)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveClamd(conn net.Conn, maxLength int) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	command, err := r.ReadString(0)
+	if err != nil || command != "zINSTREAM\x00" {
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+
+	var content bytes.Buffer
+	for {
+		var length uint32
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			return
+		}
+		if length == 0 {
+			break
+		}
+		if content.Len()+int(length) > maxLength {
+			conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+			return
+		}
+		if _, err := io.CopyN(&content, r, int64(length)); err != nil {
+			return
+		}
+	}
+
+	threat, _ := NewFakeScanner().Scan(&content)
+	if threat != "" {
+		conn.Write([]byte("stream: " + threat + " FOUND\x00"))
+		return
+	}
+	conn.Write([]byte("stream: OK\x00"))
+}
+
+func TestClamdScanner_Scan(t *testing.T) {
+	address := fakeClamd(t, "tcp", "127.0.0.1:0", 1<<20)
+	scanner, err := NewClamdScanner("tcp://"+address, 5*time.Second)
+	assert.NoError(t, err)
+
+	t.Run("Clean", func(t *testing.T) {
+		threat, err := scanner.Scan(strings.NewReader(strings.Repeat("hello ", 30000)))
+
+		assert.NoError(t, err)
+		assert.Empty(t, threat)
+	})
+
+	t.Run("Infected", func(t *testing.T) {
+		// the signature spans two chunks
+		content := strings.Repeat("a", chunkSize-10) + EICAR + strings.Repeat("b", 100)
+
+		threat, err := scanner.Scan(strings.NewReader(content))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Eicar-Test-Signature", threat)
+	})
+
+	t.Run("Size Limit Exceeded", func(t *testing.T) {
+		_, err := scanner.Scan(bytes.NewReader(make([]byte, 2<<20)))
+
+		assert.ErrorIs(t, err, domain.ErrScanFailed)
+	})
+
+	t.Run("Unix Socket", func(t *testing.T) {
+		socket := filepath.Join(t.TempDir(), "clamd.ctl")
+		fakeClamd(t, "unix", socket, 1<<20)
+		scanner, err := NewClamdScanner("unix://"+socket, 5*time.Second)
+		assert.NoError(t, err)
+
+		threat, err := scanner.Scan(strings.NewReader(EICAR))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Eicar-Test-Signature", threat)
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		scanner, err := NewClamdScanner("unix://"+filepath.Join(t.TempDir(), "missing.ctl"), time.Second)
+		assert.NoError(t, err)
+
+		_, err = scanner.Scan(strings.NewReader("hello"))
+
+		assert.ErrorIs(t, err, domain.ErrScanFailed)
+	})
+}
+
+func TestNewClamdScanner(t *testing.T) {
+	_, err := NewClamdScanner("localhost:3310", time.Second)
+	assert.Error(t, err)
+	_, err = NewClamdScanner("http://localhost:3310", time.Second)
+	assert.Error(t, err)
+}
+
+func TestParseReply(t *testing.T) {
+	threat, err := parseReply("stream: Win.Test.EICAR_HDB-1 FOUND")
+	assert.NoError(t, err)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", threat)
+
+	threat, err = parseReply("stream: OK")
+	assert.NoError(t, err)
+	assert.Empty(t, threat)
+
+	_, err = parseReply("stream: Can't allocate memory ERROR")
+	assert.ErrorIs(t, err, domain.ErrScanFailed)
+}
@@ -0,0 +1,46 @@
+package antivirus
+
+import (
+	"bytes"
+	"io"
+)
+
+// EICAR is the standard antivirus test file, every scanner detects it although it is harmless
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// FakeScanner detects the EICAR test string anywhere in the content, it stands in for clamd in tests and
+// development
+type FakeScanner struct{}
+
+func NewFakeScanner() *FakeScanner {
+	return &FakeScanner{}
+}
+
+func (s *FakeScanner) Scan(content io.Reader) (string, error) {
+	signature := []byte(EICAR)
+	found := false
+	// the tail of the previous read is kept, the string can span two reads
+	window := make([]byte, 0, 2*chunkSize)
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := content.Read(buf)
+		window = append(window, buf[:n]...)
+		if bytes.Contains(window, signature) {
+			found = true
+		}
+		if len(window) >= len(signature) {
+			window = append(window[:0], window[len(window)-len(signature)+1:]...)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if found {
+		return "Eicar-Test-Signature", nil
+	}
+	return "", nil
+}
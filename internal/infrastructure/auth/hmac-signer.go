@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// HMACSigner signs with HMAC-SHA256, signatures are base64url encoded so that they can be put in a URL as is
+type HMACSigner struct {
+	secret []byte
+}
+
+func NewHMACSigner(secret []byte) *HMACSigner {
+	return &HMACSigner{secret: secret}
+}
+
+func (s *HMACSigner) Sign(payload string) string {
+	return base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify compares the signatures in constant time
+func (s *HMACSigner) Verify(payload, signature string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(decoded, s.mac(payload))
+}
+
+func (s *HMACSigner) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the claims of the access tokens
+type Claims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// JWTIssuer issues HMAC-SHA256 signed JWTs
+type JWTIssuer struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+}
+
+func NewJWTIssuer(secret []byte, issuer string, ttl time.Duration) *JWTIssuer {
+	return &JWTIssuer{secret: secret, issuer: issuer, ttl: ttl}
+}
+
+func (i *JWTIssuer) Issue(user *model.User) (string, time.Time, error) {
+	dto := user.ToDTO()
+	now := time.Now()
+	expiresAt := now.Add(i.ttl)
+
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   dto.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Email:         dto.Email,
+		EmailVerified: dto.EmailVerified,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// Parse validates a token issued by this issuer and returns its claims
+func (i *JWTIssuer) Parse(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		return i.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(i.issuer))
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// ListAddresses list a user's postal addresses
+//
+//	@Summary		List addresses
+//	@Description	List the postal addresses of a user
+//	@Tags			addresses
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	v1.ListAddressesResponse
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/addresses [GET]
+func (s *GinHttpService) ListAddresses(c *gin.Context) {
+	req := &v1.ListAddressesRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.listAddressesService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetAddress get a postal address
+//
+//	@Summary		Get an address
+//	@Description	Get a postal address of a user
+//	@Tags			addresses
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			addressID	path		string	true	"Address ID"
+//	@Success		200			{object}	v1.GetAddressResponse
+//	@Failure		404			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/addresses/{addressID} [GET]
+func (s *GinHttpService) GetAddress(c *gin.Context) {
+	req := &v1.AddressRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.getAddressService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AddAddress add a postal address
+//
+//	@Summary		Add an address
+//	@Description	Add a postal address. The country is an ISO 3166-1 alpha-2 code and the postal code must match its format.
+//	@Tags			addresses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"User ID"
+//	@Param			address	body		v1.AddAddressRequest	true	"Address to add"
+//	@Success		201		{object}	v1.AddAddressResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/addresses [POST]
+func (s *GinHttpService) AddAddress(c *gin.Context) {
+	req := &v1.AddAddressRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.addAddressService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateAddress replace a postal address
+//
+//	@Summary		Update an address
+//	@Description	Replace all the fields of a postal address
+//	@Tags			addresses
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"User ID"
+//	@Param			addressID	path		string					true	"Address ID"
+//	@Param			address		body		v1.UpdateAddressRequest	true	"New address"
+//	@Success		200			{object}	v1.UpdateAddressResponse
+//	@Failure		400			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/addresses/{addressID} [PUT]
+func (s *GinHttpService) UpdateAddress(c *gin.Context) {
+	req := &v1.UpdateAddressRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.updateAddressService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteAddress delete a postal address
+//
+//	@Summary		Delete an address
+//	@Description	Delete a postal address of a user
+//	@Tags			addresses
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			addressID	path		string	true	"Address ID"
+//	@Success		204			{object}	nil
+//	@Failure		404			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/addresses/{addressID} [DELETE]
+func (s *GinHttpService) DeleteAddress(c *gin.Context) {
+	req := &v1.AddressRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.deleteAddressService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
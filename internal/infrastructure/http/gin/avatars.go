@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// SetAvatar upload a user's avatar
+//
+//	@Summary		Set the avatar
+//	@Description	Upload a JPEG, PNG or WebP image as the user's avatar. The real type is sniffed from the content,
+//	@Description	metadata such as EXIF and GPS is stripped and square thumbnails of 64, 128, 256 and 512 pixels are stored.
+//	@Tags			users
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"User ID"
+//	@Param			file	formData	file	true	"Image to upload"
+//	@Success		200		{object}	v1.SetAvatarResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		403		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		413		{object}	HttpError
+//	@Failure		415		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/avatar [PUT]
+func (s *GinHttpService) SetAvatar(c *gin.Context) {
+	// the form binding validates the whole request, the path parameter is set beforehand
+	req := &v1.SetAvatarRequest{UserID: c.Param("id")}
+	if err := c.Bind(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.setAvatarService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetAvatar download a user's avatar
+//
+//	@Summary		Get the avatar
+//	@Description	Download a thumbnail of the user's avatar, the largest one unless a size is given
+//	@Tags			users
+//	@Produce		image/jpeg,image/png
+//	@Param			id		path		string	true	"User ID"
+//	@Param			size	query		int		false	"Thumbnail size in pixels: 64, 128, 256 or 512"
+//	@Success		200		{file}		binary
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/avatar [GET]
+func (s *GinHttpService) GetAvatar(c *gin.Context) {
+	req := &v1.GetAvatarRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindQuery(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	thumbnail, contentType, err := s.getAvatarService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer thumbnail.Close()
+
+	// the avatar URL changes with every upload
+	c.DataFromReader(http.StatusOK, -1, contentType, thumbnail, map[string]string{
+		"Cache-Control": "public, max-age=86400",
+	})
+}
+
+// DeleteAvatar delete a user's avatar
+//
+//	@Summary		Delete the avatar
+//	@Description	Delete the user's avatar and all its thumbnails
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		204	{object}	nil
+//	@Failure		403	{object}	HttpError
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/avatar [DELETE]
+func (s *GinHttpService) DeleteAvatar(c *gin.Context) {
+	req := &v1.DeleteAvatarRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.deleteAvatarService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
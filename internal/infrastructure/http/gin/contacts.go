@@ -0,0 +1,170 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// ListContactPoints list a user's contact points
+//
+//	@Summary		List contact points
+//	@Description	List the secondary email addresses and the phone numbers of a user
+//	@Tags			contacts
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	v1.ListContactPointsResponse
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/contacts [GET]
+func (s *GinHttpService) ListContactPoints(c *gin.Context) {
+	req := &v1.ListContactPointsRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.listContactsService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// AddContactPoint add a contact point
+//
+//	@Summary		Add a contact point
+//	@Description	Add a secondary email address or a phone number in the E.164 format. The first phone number becomes
+//	@Description	the primary one, a verification token is sent to a new email address.
+//	@Tags			contacts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"User ID"
+//	@Param			contact	body		v1.AddContactPointRequest	true	"Contact point to add"
+//	@Success		201		{object}	v1.AddContactPointResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/contacts [POST]
+func (s *GinHttpService) AddContactPoint(c *gin.Context) {
+	req := &v1.AddContactPointRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.addContactService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateContactPoint update a contact point
+//
+//	@Summary		Update a contact point
+//	@Description	Change the label, promote the contact point to primary or mark a phone number as verified.
+//	@Description	Promoting a verified email address makes it the user's email.
+//	@Tags			contacts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string							true	"User ID"
+//	@Param			contactID	path		string							true	"Contact point ID"
+//	@Param			contact		body		v1.UpdateContactPointRequest	true	"Fields to change"
+//	@Success		200			{object}	v1.UpdateContactPointResponse
+//	@Failure		400			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		409			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/contacts/{contactID} [PATCH]
+func (s *GinHttpService) UpdateContactPoint(c *gin.Context) {
+	req := &v1.UpdateContactPointRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.updateContactService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteContactPoint delete a contact point
+//
+//	@Summary		Delete a contact point
+//	@Description	Delete a contact point, the next phone number replaces a deleted primary one
+//	@Tags			contacts
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			contactID	path		string	true	"Contact point ID"
+//	@Success		204			{object}	nil
+//	@Failure		404			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/contacts/{contactID} [DELETE]
+func (s *GinHttpService) DeleteContactPoint(c *gin.Context) {
+	req := &v1.ContactPointRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.deleteContactService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SendContactPointVerification send a new verification token for an email contact point
+//
+//	@Summary		Send contact point verification
+//	@Description	Send a new verification token to a secondary email address, it is verified at /users/{id}/email/verify
+//	@Tags			contacts
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			contactID	path		string	true	"Contact point ID"
+//	@Success		202			{object}	nil
+//	@Failure		404			{object}	HttpError
+//	@Failure		409			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/contacts/{contactID}/verification [POST]
+func (s *GinHttpService) SendContactPointVerification(c *gin.Context) {
+	req := &v1.ContactPointRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.sendContactVerifySvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadFile download a user's file
+//
+//	@Summary		Download a file
+//	@Description	Download the content of a file. The Digest header has its SHA-256 and the ETag is based on it,
+//	@Description	so a cached copy can be revalidated with If-None-Match. Corrupted files and files pending a
+//	@Description	malware scan are not served.
+//	@Tags			files
+//	@Produce		application/octet-stream
+//	@Param			id				path		string	true	"User ID"
+//	@Param			fileID			path		string	true	"File ID"
+//	@Param			If-None-Match	header		string	false	"ETag of a cached copy"
+//	@Success		200				{file}		binary
+//	@Success		304				{object}	nil
+//	@Failure		404				{object}	HttpError
+//	@Failure		409				{object}	HttpError
+//	@Failure		500				{object}	HttpError
+//	@Router			/users/{id}/files/{fileID}/download [GET]
+func (s *GinHttpService) DownloadFile(c *gin.Context) {
+	req := &v1.DownloadFileRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	file, content, err := s.downloadFileService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer content.Close()
+
+	serveFile(c, file, content)
+}
+
+// DownloadFiles download all of a user's files as a ZIP archive
+//
+//	@Summary		Download all files
+//	@Description	Stream a ZIP archive with the content of all the user's files, built while it is downloaded.
+//	@Description	Colliding names are numbered, e.g. report (1).pdf. Corrupted files and files pending a malware
+//	@Description	scan are left out. With manifest=true a last manifest.json entry lists the names, sizes and
+//	@Description	SHA-256 digests of the archived files and why the others were skipped. A failure while
+//	@Description	streaming cuts the archive short, it then can't be opened.
+//	@Tags			files
+//	@Produce		application/zip
+//	@Param			id			path		string	true	"User ID"
+//	@Param			manifest	query		bool	false	"Add a manifest.json entry"
+//	@Success		200			{file}		binary
+//	@Failure		400			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/files/archive [GET]
+func (s *GinHttpService) DownloadFiles(c *gin.Context) {
+	req := &v1.DownloadFilesRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindQuery(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	archive, err := s.downloadFilesService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer archive.Close()
+
+	// the size isn't known until the archive is written
+	c.DataFromReader(http.StatusOK, -1, "application/zip", archive, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": "files.zip"}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// VerifyFile verify the integrity of a user's file
+//
+//	@Summary		Verify a file
+//	@Description	Re-hash the stored content of a file and compare it with its digest, a mismatch flags the file
+//	@Description	as corrupted. Files stored before digests were recorded get their digest.
+//	@Tags			files
+//	@Produce		json
+//	@Param			id		path		string	true	"User ID"
+//	@Param			fileID	path		string	true	"File ID"
+//	@Success		200		{object}	v1.VerifyFileResponse
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/files/{fileID}/verify [POST]
+func (s *GinHttpService) VerifyFile(c *gin.Context) {
+	req := &v1.VerifyFileRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.verifyFileService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// serveFile writes the content of a file as an attachment, or 304 if the client has it cached
+func serveFile(c *gin.Context, file *model.File, content io.Reader) {
+	headers := map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.AttachmentName()}),
+	}
+	// files stored before digests were recorded have neither header until they are verified
+	if file.Digest != "" {
+		if etagMatches(c.GetHeader("If-None-Match"), file.ETag()) {
+			c.Header("ETag", file.ETag())
+			c.Status(http.StatusNotModified)
+			return
+		}
+		headers["ETag"] = file.ETag()
+		headers["Digest"] = file.DigestHeader()
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	headers["X-Content-Type-Options"] = "nosniff"
+	c.DataFromReader(http.StatusOK, file.Size, contentType, content, headers)
+}
+
+// etagMatches tells whether an If-None-Match header lists the entity tag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateFile update the metadata of a user's file
+//
+//	@Summary		Update a file
+//	@Description	Rename a file, change its description, tags and labels or move it to another folder; the tags
+//	@Description	and labels that are set replace the current ones and an empty folder ID moves the file to the
+//	@Description	root. The names of the files in a folder are unique. The content is left as is. A FileUpdated
+//	@Description	event is published.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"User ID"
+//	@Param			fileID	path		string					true	"File ID"
+//	@Param			file	body		v1.UpdateFileRequest	true	"Fields to change"
+//	@Success		200		{object}	v1.UpdateFileResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		403		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/files/{fileID} [PATCH]
+func (s *GinHttpService) UpdateFile(c *gin.Context) {
+	req := &v1.UpdateFileRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.updateFileService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteFile delete a user's file
+//
+//	@Summary		Delete a file
+//	@Description	Delete a single file of a user, its size is given back to the user's storage quota
+//	@Tags			files
+//	@Produce		json
+//	@Param			id		path		string	true	"User ID"
+//	@Param			fileID	path		string	true	"File ID"
+//	@Success		204		{object}	nil
+//	@Failure		403		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/files/{fileID} [DELETE]
+func (s *GinHttpService) DeleteFile(c *gin.Context) {
+	req := &v1.DeleteFileRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := s.deleteFileService.Do(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// ListFolders list a user's folders
+//
+//	@Summary		List folders
+//	@Description	List the folders of a user with their paths, sorted by path
+//	@Tags			folders
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	v1.ListFoldersResponse
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/folders [GET]
+func (s *GinHttpService) ListFolders(c *gin.Context) {
+	req := &v1.ListFoldersRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.listFoldersService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateFolder create a folder
+//
+//	@Summary		Create a folder
+//	@Description	Create a folder in a parent folder, or at the root without one. The names of the folders with
+//	@Description	the same parent are unique, ignoring case.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"User ID"
+//	@Param			folder	body		v1.CreateFolderRequest	true	"Folder to create"
+//	@Success		201		{object}	v1.CreateFolderResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		403		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/folders [POST]
+func (s *GinHttpService) CreateFolder(c *gin.Context) {
+	req := &v1.CreateFolderRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.createFolderService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateFolder rename or move a folder
+//
+//	@Summary		Update a folder
+//	@Description	Rename a folder or move it to another parent, an empty parent ID moves it to the root. The files
+//	@Description	and subfolders in it move with it, their content stays where it is stored.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"User ID"
+//	@Param			folderID	path		string					true	"Folder ID"
+//	@Param			folder		body		v1.UpdateFolderRequest	true	"Fields to change"
+//	@Success		200			{object}	v1.UpdateFolderResponse
+//	@Failure		400			{object}	HttpError
+//	@Failure		403			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		409			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/folders/{folderID} [PATCH]
+func (s *GinHttpService) UpdateFolder(c *gin.Context) {
+	req := &v1.UpdateFolderRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.updateFolderService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteFolder delete a folder
+//
+//	@Summary		Delete a folder
+//	@Description	Delete an empty folder. With recursive=true the files in it and its subfolders are deleted
+//	@Description	too, with their versions; a non-empty folder is refused otherwise.
+//	@Tags			folders
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			folderID	path		string	true	"Folder ID"
+//	@Param			recursive	query		bool	false	"Delete the files and subfolders in the folder"
+//	@Success		204			{object}	nil
+//	@Failure		403			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		409			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/folders/{folderID} [DELETE]
+func (s *GinHttpService) DeleteFolder(c *gin.Context) {
+	req := &v1.DeleteFolderRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindQuery(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := s.deleteFolderService.Do(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,286 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// ListRoles list all roles
+//
+//	@Summary		List all roles
+//	@Description	List all the roles that can be assigned to groups
+//	@Tags			roles
+//	@Produce		json
+//	@Success		200	{object}	v1.ListRolesResponse
+//	@Failure		500	{object}	HttpError
+//	@Router			/roles [GET]
+func (s *GinHttpService) ListRoles(c *gin.Context) {
+	res, err := s.listRolesService.Do()
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateRole create a new role
+//
+//	@Summary		Create a new role
+//	@Description	Define a role that can be assigned to groups. Names are lowercase letters, digits and _ . : -
+//	@Tags			roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			role	body		v1.CreateRoleRequest	true	"Role to create"
+//	@Success		201		{object}	v1.CreateRoleResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/roles [POST]
+func (s *GinHttpService) CreateRole(c *gin.Context) {
+	req := &v1.CreateRoleRequest{}
+
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.createRoleService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// DeleteRole delete a role
+//
+//	@Summary		Delete a role
+//	@Description	Delete a role that isn't assigned to any group
+//	@Tags			roles
+//	@Produce		json
+//	@Param			name	path		string	true	"Role name"
+//	@Success		204		{object}	nil
+//	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/roles/{name} [DELETE]
+func (s *GinHttpService) DeleteRole(c *gin.Context) {
+	req := &v1.DeleteRoleRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.deleteRoleService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListGroups list all groups
+//
+//	@Summary		List all groups
+//	@Description	List all groups with their roles. Use GET /users?group={id} to list the members of a group
+//	@Tags			groups
+//	@Produce		json
+//	@Success		200	{object}	v1.ListGroupsResponse
+//	@Failure		500	{object}	HttpError
+//	@Router			/groups [GET]
+func (s *GinHttpService) ListGroups(c *gin.Context) {
+	res, err := s.listGroupsService.Do()
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetGroup get a group by ID
+//
+//	@Summary		Get a group by ID
+//	@Description	Get a single group by its ID
+//	@Tags			groups
+//	@Produce		json
+//	@Param			id	path		string	true	"Group ID"
+//	@Success		200	{object}	v1.GetGroupResponse
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/groups/{id} [GET]
+func (s *GinHttpService) GetGroup(c *gin.Context) {
+	req := &v1.GetGroupRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.getGroupService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// CreateGroup create a new group
+//
+//	@Summary		Create a new group
+//	@Description	Create a new group with a set of existing roles
+//	@Tags			groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			group	body		v1.CreateGroupRequest	true	"Group to create"
+//	@Success		201		{object}	v1.CreateGroupResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/groups [POST]
+func (s *GinHttpService) CreateGroup(c *gin.Context) {
+	req := &v1.CreateGroupRequest{}
+
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.createGroupService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// UpdateGroup update a group
+//
+//	@Summary		Update a group
+//	@Description	Update a group's name, description or roles. A UserUpdated event is published for every member when the roles change
+//	@Tags			groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Group ID"
+//	@Param			group	body		v1.UpdateGroupRequest	true	"Group data to update"
+//	@Success		200		{object}	v1.UpdateGroupResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/groups/{id} [PUT]
+func (s *GinHttpService) UpdateGroup(c *gin.Context) {
+	req := &v1.UpdateGroupRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.updateGroupService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteGroup delete a group
+//
+//	@Summary		Delete a group
+//	@Description	Delete a group and its memberships. A UserUpdated event is published for every former member
+//	@Tags			groups
+//	@Produce		json
+//	@Param			id	path		string	true	"Group ID"
+//	@Success		204	{object}	nil
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/groups/{id} [DELETE]
+func (s *GinHttpService) DeleteGroup(c *gin.Context) {
+	req := &v1.DeleteGroupRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.deleteGroupService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddGroupMember add a user to a group
+//
+//	@Summary		Add a member
+//	@Description	Add a user to a group, the user gets the group's roles. Adding an existing member has no effect
+//	@Tags			groups
+//	@Produce		json
+//	@Param			id		path		string	true	"Group ID"
+//	@Param			userID	path		string	true	"User ID"
+//	@Success		200		{object}	v1.GroupMemberResponse
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/groups/{id}/members/{userID} [PUT]
+func (s *GinHttpService) AddGroupMember(c *gin.Context) {
+	req := &v1.GroupMemberRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.addMemberService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RemoveGroupMember remove a user from a group
+//
+//	@Summary		Remove a member
+//	@Description	Remove a user from a group, the user loses the roles it only held through the group
+//	@Tags			groups
+//	@Produce		json
+//	@Param			id		path		string	true	"Group ID"
+//	@Param			userID	path		string	true	"User ID"
+//	@Success		200		{object}	v1.GroupMemberResponse
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/groups/{id}/members/{userID} [DELETE]
+func (s *GinHttpService) RemoveGroupMember(c *gin.Context) {
+	req := &v1.GroupMemberRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.removeMemberSvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePresignedURL mint a pre-signed URL
+//
+//	@Summary		Create a pre-signed URL
+//	@Description	Mint a time-limited URL to download a file, or to upload a file into the user's space, without
+//	@Description	an Authorization header. The URL can be bound to the IP address of the client it is handed to.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"User ID"
+//	@Param			request	body		v1.CreatePresignedURLRequest	true	"Action, file and expiry"
+//	@Success		201		{object}	v1.CreatePresignedURLResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		403		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/presigned-urls [POST]
+func (s *GinHttpService) CreatePresignedURL(c *gin.Context) {
+	req := &v1.CreatePresignedURLRequest{UserID: c.Param("id")}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.createPresignedSvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// PresignedDownload download a file with a pre-signed URL
+//
+//	@Summary		Download a file with a pre-signed URL
+//	@Description	Download the content of a file with a URL minted by POST /users/{id}/presigned-urls, no
+//	@Description	Authorization header is needed. The headers are the ones of the regular download.
+//	@Tags			files
+//	@Produce		application/octet-stream
+//	@Param			user			query		string	true	"User ID"
+//	@Param			fileID			query		string	true	"File ID"
+//	@Param			expires			query		int		true	"Expiry as a Unix time"
+//	@Param			ip				query		string	false	"IP address the URL is bound to"
+//	@Param			signature		query		string	true	"Signature"
+//	@Param			If-None-Match	header		string	false	"ETag of a cached copy"
+//	@Success		200				{file}		binary
+//	@Success		304				{object}	nil
+//	@Failure		403				{object}	HttpError
+//	@Failure		404				{object}	HttpError
+//	@Failure		410				{object}	HttpError
+//	@Failure		500				{object}	HttpError
+//	@Router			/presigned/download [GET]
+func (s *GinHttpService) PresignedDownload(c *gin.Context) {
+	req := &v1.PresignedDownloadRequest{}
+	if err := c.BindQuery(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	req.ClientIP = c.ClientIP()
+
+	file, content, err := s.presignedDownloadSvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer content.Close()
+
+	serveFile(c, file, content)
+}
+
+// PresignedUpload upload a file with a pre-signed URL
+//
+//	@Summary		Upload a file with a pre-signed URL
+//	@Description	Upload a file into a user's space with a URL minted by POST /users/{id}/presigned-urls, no
+//	@Description	Authorization header is needed. The file is checked as any other upload.
+//	@Tags			files
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			user		query		string	true	"User ID"
+//	@Param			expires		query		int		true	"Expiry as a Unix time"
+//	@Param			ip			query		string	false	"IP address the URL is bound to"
+//	@Param			signature	query		string	true	"Signature"
+//	@Param			file		formData	file	true	"File to upload"
+//	@Param			digest		formData	string	false	"SHA-256 of the file, as hex or sha-256=<base64>"
+//	@Param			folderID	formData	string	false	"Folder of the file, the root if empty"
+//	@Success		201			{object}	v1.UploadFileResponse
+//	@Failure		400			{object}	HttpError
+//	@Failure		403			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		410			{object}	HttpError
+//	@Failure		413			{object}	HttpError
+//	@Failure		415			{object}	HttpError
+//	@Failure		422			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Failure		503			{object}	HttpError
+//	@Failure		507			{object}	HttpError
+//	@Router			/presigned/upload [POST]
+func (s *GinHttpService) PresignedUpload(c *gin.Context) {
+	// the form binding doesn't read the query, it leaves the fields it doesn't find as they are
+	req := &v1.PresignedUploadRequest{}
+	if err := c.BindQuery(&req.PresignedURLQuery); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.Bind(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	req.ClientIP = c.ClientIP()
+
+	res, err := s.presignedUploadSvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	applicationService "github.com/bizio/abc-user-service/internal/application/service"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGinHttpService_PresignedDownloadClientIP(t *testing.T) {
+	userID := "user-123"
+	// the URL is bound to 203.0.113.7
+	target := fmt.Sprintf("/v1/presigned/download?user=%s&fileID=file-123&expires=%d&ip=203.0.113.7&signature=c2lnbmF0dXJl",
+		userID, time.Now().Add(time.Minute).Unix())
+
+	newService := func(trustedProxies []string) *GinHttpService {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFileRepo := new(mocks.FileRepository)
+		mockSigner := new(mocks.URLSigner)
+		user, _ := model.NewUser("Test User", "test@example.com", "1990-01-01")
+		user.ID = userID
+		user.AddFile(&model.File{ID: "file-123", UserID: userID, Name: "hello.txt", Size: 5})
+		mockSigner.On("Verify", mock.Anything, "c2lnbmF0dXJl").Return(true)
+		mockUserRepo.On("Get", userID).Return(user, nil)
+		mockFileRepo.On("Get", userID, "hello.txt").Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+		downloads := applicationService.NewDownloadFileApplicationService(mockUserRepo, mockFileRepo)
+		return &GinHttpService{
+			presignedDownloadSvc: applicationService.NewPresignedDownloadApplicationService(mockSigner, downloads),
+			maxFileSize:          1024,
+			trustedProxies:       trustedProxies,
+		}
+	}
+
+	t.Run("Forged X-Forwarded-For", func(t *testing.T) {
+		router := newService(nil).GetRouter()
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.RemoteAddr = "198.51.100.1:4321"
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("Trusted Proxy", func(t *testing.T) {
+		router := newService([]string{"198.51.100.0/24"}).GetRouter()
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.RemoteAddr = "198.51.100.1:4321"
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "hello", rec.Body.String())
+	})
+
+	t.Run("Direct Client", func(t *testing.T) {
+		router := newService(nil).GetRouter()
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.RemoteAddr = "203.0.113.7:4321"
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
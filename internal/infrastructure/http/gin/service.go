@@ -1,10 +1,15 @@
 package http
 
 import (
+	"errors"
+	"expvar"
+	"fmt"
 	"net/http"
+	"strings"
 
 	applicationService "github.com/bizio/abc-user-service/internal/application/service"
 	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
 	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -16,15 +21,73 @@ type HttpError struct {
 }
 
 type GinHttpService struct {
-	listService        *applicationService.ListUsersApplicationService
-	getService         *applicationService.GetUserApplicationService
-	createService      *applicationService.CreateUserApplicationService
-	updateService      *applicationService.UpdateUserApplicationService
-	deleteService      *applicationService.DeleteUserApplicationService
-	getFilesSerivce    *applicationService.GetFilesApplicationService
-	addFileService     *applicationService.AddFileApplicationService
-	deleteFilesService *applicationService.DeleteFilesApplicationService
-	maxFileSize        int64
+	listService          *applicationService.ListUsersApplicationService
+	getService           *applicationService.GetUserApplicationService
+	createService        *applicationService.CreateUserApplicationService
+	updateService        *applicationService.UpdateUserApplicationService
+	deleteService        *applicationService.DeleteUserApplicationService
+	eraseService         *applicationService.EraseUserApplicationService
+	transitionService    *applicationService.TransitionUserStatusApplicationService
+	getFilesSerivce      *applicationService.GetFilesApplicationService
+	addFileService       *applicationService.AddFileApplicationService
+	deleteFilesService   *applicationService.DeleteFilesApplicationService
+	exportService        *applicationService.ExportUserApplicationService
+	getExportService     *applicationService.GetExportApplicationService
+	downloadService      *applicationService.DownloadExportApplicationService
+	verifyEmailService   *applicationService.VerifyEmailApplicationService
+	sendEmailService     *applicationService.SendEmailVerificationApplicationService
+	setPasswordService   *applicationService.SetPasswordApplicationService
+	changePwdService     *applicationService.ChangePasswordApplicationService
+	requestResetSvc      *applicationService.RequestPasswordResetApplicationService
+	resetPwdService      *applicationService.ResetPasswordApplicationService
+	loginService         *applicationService.LoginApplicationService
+	listRolesService     *applicationService.ListRolesApplicationService
+	createRoleService    *applicationService.CreateRoleApplicationService
+	deleteRoleService    *applicationService.DeleteRoleApplicationService
+	listGroupsService    *applicationService.ListGroupsApplicationService
+	getGroupService      *applicationService.GetGroupApplicationService
+	createGroupService   *applicationService.CreateGroupApplicationService
+	updateGroupService   *applicationService.UpdateGroupApplicationService
+	deleteGroupService   *applicationService.DeleteGroupApplicationService
+	addMemberService     *applicationService.AddGroupMemberApplicationService
+	removeMemberSvc      *applicationService.RemoveGroupMemberApplicationService
+	listContactsService  *applicationService.ListContactPointsApplicationService
+	addContactService    *applicationService.AddContactPointApplicationService
+	updateContactService *applicationService.UpdateContactPointApplicationService
+	deleteContactService *applicationService.DeleteContactPointApplicationService
+	sendContactVerifySvc *applicationService.SendContactPointVerificationApplicationService
+	listAddressesService *applicationService.ListAddressesApplicationService
+	getAddressService    *applicationService.GetAddressApplicationService
+	addAddressService    *applicationService.AddAddressApplicationService
+	updateAddressService *applicationService.UpdateAddressApplicationService
+	deleteAddressService *applicationService.DeleteAddressApplicationService
+	setAvatarService     *applicationService.SetAvatarApplicationService
+	getAvatarService     *applicationService.GetAvatarApplicationService
+	deleteAvatarService  *applicationService.DeleteAvatarApplicationService
+	downloadFileService  *applicationService.DownloadFileApplicationService
+	verifyFileService    *applicationService.VerifyFileApplicationService
+	downloadFilesService *applicationService.DownloadFilesApplicationService
+	updateFileService    *applicationService.UpdateFileApplicationService
+	listFoldersService   *applicationService.ListFoldersApplicationService
+	createFolderService  *applicationService.CreateFolderApplicationService
+	updateFolderService  *applicationService.UpdateFolderApplicationService
+	deleteFolderService  *applicationService.DeleteFolderApplicationService
+	createUploadService  *applicationService.CreateUploadApplicationService
+	getUploadService     *applicationService.GetUploadApplicationService
+	patchUploadService   *applicationService.PatchUploadApplicationService
+	deleteUploadService  *applicationService.DeleteUploadApplicationService
+	deleteFileService    *applicationService.DeleteFileApplicationService
+	getUsageService      *applicationService.GetStorageUsageApplicationService
+	setQuotaService      *applicationService.SetStorageQuotaApplicationService
+	deleteQuotaService   *applicationService.DeleteStorageQuotaApplicationService
+	createPresignedSvc   *applicationService.CreatePresignedURLApplicationService
+	presignedDownloadSvc *applicationService.PresignedDownloadApplicationService
+	presignedUploadSvc   *applicationService.PresignedUploadApplicationService
+	listVersionsService  *applicationService.ListFileVersionsApplicationService
+	downloadVersionSvc   *applicationService.DownloadFileVersionApplicationService
+	restoreVersionSvc    *applicationService.RestoreFileVersionApplicationService
+	maxFileSize          int64
+	trustedProxies       []string
 }
 
 func NewGinHttpService(
@@ -33,10 +96,68 @@ func NewGinHttpService(
 	createService *applicationService.CreateUserApplicationService,
 	updateService *applicationService.UpdateUserApplicationService,
 	deleteService *applicationService.DeleteUserApplicationService,
+	eraseService *applicationService.EraseUserApplicationService,
+	transitionService *applicationService.TransitionUserStatusApplicationService,
 	getFilesService *applicationService.GetFilesApplicationService,
 	addFileService *applicationService.AddFileApplicationService,
 	deleteApplicationService *applicationService.DeleteFilesApplicationService,
+	exportService *applicationService.ExportUserApplicationService,
+	getExportService *applicationService.GetExportApplicationService,
+	downloadService *applicationService.DownloadExportApplicationService,
+	verifyEmailService *applicationService.VerifyEmailApplicationService,
+	sendEmailService *applicationService.SendEmailVerificationApplicationService,
+	setPasswordService *applicationService.SetPasswordApplicationService,
+	changePwdService *applicationService.ChangePasswordApplicationService,
+	requestResetSvc *applicationService.RequestPasswordResetApplicationService,
+	resetPwdService *applicationService.ResetPasswordApplicationService,
+	loginService *applicationService.LoginApplicationService,
+	listRolesService *applicationService.ListRolesApplicationService,
+	createRoleService *applicationService.CreateRoleApplicationService,
+	deleteRoleService *applicationService.DeleteRoleApplicationService,
+	listGroupsService *applicationService.ListGroupsApplicationService,
+	getGroupService *applicationService.GetGroupApplicationService,
+	createGroupService *applicationService.CreateGroupApplicationService,
+	updateGroupService *applicationService.UpdateGroupApplicationService,
+	deleteGroupService *applicationService.DeleteGroupApplicationService,
+	addMemberService *applicationService.AddGroupMemberApplicationService,
+	removeMemberSvc *applicationService.RemoveGroupMemberApplicationService,
+	listContactsService *applicationService.ListContactPointsApplicationService,
+	addContactService *applicationService.AddContactPointApplicationService,
+	updateContactService *applicationService.UpdateContactPointApplicationService,
+	deleteContactService *applicationService.DeleteContactPointApplicationService,
+	sendContactVerifySvc *applicationService.SendContactPointVerificationApplicationService,
+	listAddressesService *applicationService.ListAddressesApplicationService,
+	getAddressService *applicationService.GetAddressApplicationService,
+	addAddressService *applicationService.AddAddressApplicationService,
+	updateAddressService *applicationService.UpdateAddressApplicationService,
+	deleteAddressService *applicationService.DeleteAddressApplicationService,
+	setAvatarService *applicationService.SetAvatarApplicationService,
+	getAvatarService *applicationService.GetAvatarApplicationService,
+	deleteAvatarService *applicationService.DeleteAvatarApplicationService,
+	downloadFileService *applicationService.DownloadFileApplicationService,
+	verifyFileService *applicationService.VerifyFileApplicationService,
+	downloadFilesService *applicationService.DownloadFilesApplicationService,
+	updateFileService *applicationService.UpdateFileApplicationService,
+	listFoldersService *applicationService.ListFoldersApplicationService,
+	createFolderService *applicationService.CreateFolderApplicationService,
+	updateFolderService *applicationService.UpdateFolderApplicationService,
+	deleteFolderService *applicationService.DeleteFolderApplicationService,
+	createUploadService *applicationService.CreateUploadApplicationService,
+	getUploadService *applicationService.GetUploadApplicationService,
+	patchUploadService *applicationService.PatchUploadApplicationService,
+	deleteUploadService *applicationService.DeleteUploadApplicationService,
+	deleteFileService *applicationService.DeleteFileApplicationService,
+	getUsageService *applicationService.GetStorageUsageApplicationService,
+	setQuotaService *applicationService.SetStorageQuotaApplicationService,
+	deleteQuotaService *applicationService.DeleteStorageQuotaApplicationService,
+	createPresignedSvc *applicationService.CreatePresignedURLApplicationService,
+	presignedDownloadSvc *applicationService.PresignedDownloadApplicationService,
+	presignedUploadSvc *applicationService.PresignedUploadApplicationService,
+	listVersionsService *applicationService.ListFileVersionsApplicationService,
+	downloadVersionSvc *applicationService.DownloadFileVersionApplicationService,
+	restoreVersionSvc *applicationService.RestoreFileVersionApplicationService,
 	maxFileSize int64,
+	trustedProxies []string,
 ) *GinHttpService {
 	return &GinHttpService{
 		listService,
@@ -44,19 +165,91 @@ func NewGinHttpService(
 		createService,
 		updateService,
 		deleteService,
+		eraseService,
+		transitionService,
 		getFilesService,
 		addFileService,
 		deleteApplicationService,
+		exportService,
+		getExportService,
+		downloadService,
+		verifyEmailService,
+		sendEmailService,
+		setPasswordService,
+		changePwdService,
+		requestResetSvc,
+		resetPwdService,
+		loginService,
+		listRolesService,
+		createRoleService,
+		deleteRoleService,
+		listGroupsService,
+		getGroupService,
+		createGroupService,
+		updateGroupService,
+		deleteGroupService,
+		addMemberService,
+		removeMemberSvc,
+		listContactsService,
+		addContactService,
+		updateContactService,
+		deleteContactService,
+		sendContactVerifySvc,
+		listAddressesService,
+		getAddressService,
+		addAddressService,
+		updateAddressService,
+		deleteAddressService,
+		setAvatarService,
+		getAvatarService,
+		deleteAvatarService,
+		downloadFileService,
+		verifyFileService,
+		downloadFilesService,
+		updateFileService,
+		listFoldersService,
+		createFolderService,
+		updateFolderService,
+		deleteFolderService,
+		createUploadService,
+		getUploadService,
+		patchUploadService,
+		deleteUploadService,
+		deleteFileService,
+		getUsageService,
+		setQuotaService,
+		deleteQuotaService,
+		createPresignedSvc,
+		presignedDownloadSvc,
+		presignedUploadSvc,
+		listVersionsService,
+		downloadVersionSvc,
+		restoreVersionSvc,
 		maxFileSize,
+		trustedProxies,
 	}
 
 }
 
+const (
+	// multipartMemory is how much of a multipart form is buffered in memory, larger files spill to temporary files
+	multipartMemory = 8 << 20
+	// bodyOverhead leaves room for the multipart headers and the other fields around the largest file
+	bodyOverhead = 1 << 20
+)
+
 func (s *GinHttpService) GetRouter() http.Handler {
 	router := gin.Default()
+	// the client IP is the address of the connection unless it is one of the proxies, any X-Forwarded-For is
+	// ignored by default, pre-signed URLs bound to an IP rely on it
+	if err := router.SetTrustedProxies(s.trustedProxies); err != nil {
+		panic(err)
+	}
 
-	router.MaxMultipartMemory = s.maxFileSize
+	router.MaxMultipartMemory = multipartMemory
+	router.Use(limitBody(s.maxFileSize + bodyOverhead))
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
 
 	// v1 API routes
 	v1Users := router.Group("/v1/users")
@@ -65,9 +258,78 @@ func (s *GinHttpService) GetRouter() http.Handler {
 	v1Users.POST("", s.Create)
 	v1Users.PUT("/:id", s.Update)
 	v1Users.DELETE("/:id", s.Delete)
+	v1Users.POST("/:id/erase", s.Erase)
+	v1Users.POST("/:id", s.TransitionStatus) // custom methods, e.g. /v1/users/{id}:suspend
+	v1Users.POST("/:id/email/verify", s.VerifyEmail)
+	v1Users.POST("/:id/email/verification", s.SendEmailVerification)
+	v1Users.GET("/:id/contacts", s.ListContactPoints)
+	v1Users.POST("/:id/contacts", s.AddContactPoint)
+	v1Users.PATCH("/:id/contacts/:contactID", s.UpdateContactPoint)
+	v1Users.DELETE("/:id/contacts/:contactID", s.DeleteContactPoint)
+	v1Users.POST("/:id/contacts/:contactID/verification", s.SendContactPointVerification)
+	v1Users.GET("/:id/addresses", s.ListAddresses)
+	v1Users.POST("/:id/addresses", s.AddAddress)
+	v1Users.GET("/:id/addresses/:addressID", s.GetAddress)
+	v1Users.PUT("/:id/addresses/:addressID", s.UpdateAddress)
+	v1Users.DELETE("/:id/addresses/:addressID", s.DeleteAddress)
+	v1Users.PUT("/:id/avatar", s.SetAvatar)
+	v1Users.GET("/:id/avatar", s.GetAvatar)
+	v1Users.DELETE("/:id/avatar", s.DeleteAvatar)
+	v1Users.PUT("/:id/password", s.SetPassword)
+	v1Users.POST("/:id/password/change", s.ChangePassword)
 	v1Users.GET("/:id/files", s.GetFiles)
 	v1Users.POST("/:id/files", s.UploadFile)
 	v1Users.DELETE("/:id/files", s.DeleteFiles)
+	v1Users.GET("/:id/files/archive", s.DownloadFiles)
+	v1Users.PATCH("/:id/files/:fileID", s.UpdateFile)
+	v1Users.DELETE("/:id/files/:fileID", s.DeleteFile)
+	v1Users.GET("/:id/files/:fileID/download", s.DownloadFile)
+	v1Users.POST("/:id/files/:fileID/verify", s.VerifyFile)
+	v1Users.GET("/:id/files/:fileID/versions", s.ListFileVersions)
+	v1Users.GET("/:id/files/:fileID/versions/:versionID/download", s.DownloadFileVersion)
+	v1Users.POST("/:id/files/:fileID/versions/:versionID/restore", s.RestoreFileVersion)
+	v1Users.GET("/:id/folders", s.ListFolders)
+	v1Users.POST("/:id/folders", s.CreateFolder)
+	v1Users.PATCH("/:id/folders/:folderID", s.UpdateFolder)
+	v1Users.DELETE("/:id/folders/:folderID", s.DeleteFolder)
+	// resumable uploads following the tus 1.0 protocol
+	v1Uploads := v1Users.Group("/:id/uploads", tusResumable)
+	v1Uploads.OPTIONS("", s.UploadOptions)
+	v1Uploads.POST("", s.CreateUpload)
+	v1Uploads.HEAD("/:uploadID", s.GetUpload)
+	v1Uploads.PATCH("/:uploadID", s.PatchUpload)
+	v1Uploads.DELETE("/:uploadID", s.DeleteUpload)
+	v1Users.GET("/:id/storage", s.GetStorageUsage)
+	v1Users.PUT("/:id/storage/quota", s.SetStorageQuota)
+	v1Users.DELETE("/:id/storage/quota", s.DeleteStorageQuota)
+	v1Users.POST("/:id/presigned-urls", s.CreatePresignedURL)
+	v1Users.POST("/:id/export", s.Export)
+	v1Users.GET("/:id/exports/:exportID", s.GetExport)
+	v1Users.GET("/:id/exports/:exportID/download", s.DownloadExport)
+
+	// public, the signature of the URL is the authorization
+	v1Presigned := router.Group("/v1/presigned")
+	v1Presigned.GET("/download", s.PresignedDownload)
+	v1Presigned.POST("/upload", s.PresignedUpload)
+
+	v1Auth := router.Group("/v1/auth")
+	v1Auth.POST("/login", s.Login)
+	v1Auth.POST("/password-reset", s.RequestPasswordReset)
+	v1Auth.POST("/password-reset/confirm", s.ResetPassword)
+
+	v1Roles := router.Group("/v1/roles")
+	v1Roles.GET("", s.ListRoles)
+	v1Roles.POST("", s.CreateRole)
+	v1Roles.DELETE("/:name", s.DeleteRole)
+
+	v1Groups := router.Group("/v1/groups")
+	v1Groups.GET("", s.ListGroups)
+	v1Groups.GET("/:id", s.GetGroup)
+	v1Groups.POST("", s.CreateGroup)
+	v1Groups.PUT("/:id", s.UpdateGroup)
+	v1Groups.DELETE("/:id", s.DeleteGroup)
+	v1Groups.PUT("/:id/members/:userID", s.AddGroupMember)
+	v1Groups.DELETE("/:id/members/:userID", s.RemoveGroupMember)
 
 	return router
 }
@@ -79,11 +341,21 @@ func (s *GinHttpService) GetRouter() http.Handler {
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
-//	@Success		200		{object}	v1.ListUsersResponse
-//	@Failure		500		{object}	HttpError
+//	@Param			emailVerified	query		bool	false	"Filter on email verification status"
+//	@Param			group			query		string	false	"Only list the members of the group"
+//	@Param			attr[name]		query		string	false	"Filter on the value of a top-level attribute, repeatable"
+//	@Success		200				{object}	v1.ListUsersResponse
+//	@Failure		500				{object}	HttpError
 //	@Router			/users [GET]
 func (s *GinHttpService) List(c *gin.Context) {
-	users, err := s.listService.Do()
+	req := &v1.ListUsersRequest{}
+	if err := c.BindQuery(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	req.Attributes = c.QueryMap("attr")
+
+	users, err := s.listService.Do(req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -160,6 +432,7 @@ func (s *GinHttpService) Create(c *gin.Context) {
 //	@Success		201		{object}	v1.UpdateUserResponse
 //	@Failure		400		{object}	HttpError
 //	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
 //	@Failure		500		{object}	HttpError
 //	@Router			/users/{id} [PUT]
 func (s *GinHttpService) Update(c *gin.Context) {
@@ -209,17 +482,319 @@ func (s *GinHttpService) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-// GetFiles get user's files
+// Erase erase a user's personal data
 //
-//	@Summary		Get user's files
-//	@Description	Get a list of files for a specific user
-//	@Tags			files
+//	@Summary		Erase a user
+//	@Description	Irreversibly anonymise a user, deleted or not, and purge its files, quarantined files, exports,
+//	@Description	resumable uploads and verification tokens. A non-identifying record of the erasure is kept
+//	@Tags			users
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"User ID"
-//	@Success		200	{object}	v1.GetFilesResponse
+//	@Success		200	{object}	v1.EraseUserResponse
 //	@Failure		404	{object}	HttpError
 //	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/erase [POST]
+func (s *GinHttpService) Erase(c *gin.Context) {
+	req := v1.EraseUserRequest{}
+
+	if err := c.BindUri(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.eraseService.Do(req.ID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// TransitionStatus change the status of a user
+//
+//	@Summary		Change a user's status
+//	@Description	Apply a lifecycle action to a user, e.g. POST /users/{id}:suspend. Only the allowed transitions between pending, active, suspended, locked and deactivated are accepted
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"User ID"
+//	@Param			action	path		string							true	"Action"	Enums(activate, suspend, lock, unlock, reactivate, deactivate)
+//	@Param			reason	body		v1.TransitionUserStatusRequest	true	"Reason of the change"
+//	@Success		200		{object}	v1.TransitionUserStatusResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		409		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}:{action} [POST]
+func (s *GinHttpService) TransitionStatus(c *gin.Context) {
+	id, action, found := strings.Cut(c.Param("id"), ":")
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+
+	req := &v1.TransitionUserStatusRequest{ID: id, Action: action}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.transitionService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// VerifyEmail verify a user's email address
+//
+//	@Summary		Verify email address
+//	@Description	Verify the user's current email address with the single-use token sent to it
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"User ID"
+//	@Param			token	body		v1.VerifyEmailRequest	true	"Verification token"
+//	@Success		200		{object}	v1.VerifyEmailResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		410		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/email/verify [POST]
+func (s *GinHttpService) VerifyEmail(c *gin.Context) {
+	req := &v1.VerifyEmailRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.verifyEmailService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SendEmailVerification send a new verification token
+//
+//	@Summary		Send email verification
+//	@Description	Send a new verification token to the user's current email address, invalidating the previous ones
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		202	{object}	nil
+//	@Failure		404	{object}	HttpError
+//	@Failure		409	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/email/verification [POST]
+func (s *GinHttpService) SendEmailVerification(c *gin.Context) {
+	req := &v1.SendEmailVerificationRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.sendEmailService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// SetPassword set a user's password
+//
+//	@Summary		Set password
+//	@Description	Set the user's password, replacing the current one if any. The password is stored as an Argon2id hash
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"User ID"
+//	@Param			password	body		v1.SetPasswordRequest	true	"New password"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/password [PUT]
+func (s *GinHttpService) SetPassword(c *gin.Context) {
+	req := &v1.SetPasswordRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.setPasswordService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ChangePassword change a user's password
+//
+//	@Summary		Change password
+//	@Description	Replace the user's password after checking the current one
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"User ID"
+//	@Param			password	body		v1.ChangePasswordRequest	true	"Current and new password"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	HttpError
+//	@Failure		401			{object}	HttpError
+//	@Failure		409			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/password/change [POST]
+func (s *GinHttpService) ChangePassword(c *gin.Context) {
+	req := &v1.ChangePasswordRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.changePwdService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Login log a user in
+//
+//	@Summary		Login
+//	@Description	Check the user's email and password and issue a signed JWT access token. Credentials are locked for a while after repeated failures, a locked credential is refused like a wrong password
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			credentials	body		v1.LoginRequest	true	"Email and password"
+//	@Success		200			{object}	v1.LoginResponse
+//	@Failure		400			{object}	HttpError
+//	@Failure		401			{object}	HttpError
+//	@Failure		403			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/auth/login [POST]
+func (s *GinHttpService) Login(c *gin.Context) {
+	req := &v1.LoginRequest{}
+
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.loginService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RequestPasswordReset request a password reset
+//
+//	@Summary		Request a password reset
+//	@Description	Mail a single-use password reset token to the address. The response doesn't tell whether the address belongs to a user
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			email	body		v1.RequestPasswordResetRequest	true	"Email address"
+//	@Success		202		{object}	nil
+//	@Failure		400		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/auth/password-reset [POST]
+func (s *GinHttpService) RequestPasswordReset(c *gin.Context) {
+	req := &v1.RequestPasswordResetRequest{}
+
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.requestResetSvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPassword reset a password
+//
+//	@Summary		Reset password
+//	@Description	Set a new password with a password reset token
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			reset	body		v1.ResetPasswordRequest	true	"Token and new password"
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	HttpError
+//	@Failure		410		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/auth/password-reset/confirm [POST]
+func (s *GinHttpService) ResetPassword(c *gin.Context) {
+	req := &v1.ResetPasswordRequest{}
+
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	err := s.resetPwdService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFiles get user's files
+//
+//	@Summary		Get user's files
+//	@Description	Get a list of files for a specific user, only those with all the tags and labels if any are given.
+//	@Description	With a folder path only the files directly in the folder are listed; tree=true adds the tree view
+//	@Description	of the folder, or of all the folders, with the files in each.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"User ID"
+//	@Param			tag				query		string	false	"Only list the files with the tag, repeatable"
+//	@Param			label[name]		query		string	false	"Only list the files with the label value, repeatable"
+//	@Param			folder			query		string	false	"Folder path, e.g. /documents/2024 or / for the root"
+//	@Param			tree			query		bool	false	"Add the tree view of the folders"
+//	@Success		200				{object}	v1.GetFilesResponse
+//	@Failure		400				{object}	HttpError
+//	@Failure		404				{object}	HttpError
+//	@Failure		500				{object}	HttpError
 //	@Router			/users/{id}/files [GET]
 func (s *GinHttpService) GetFiles(c *gin.Context) {
 	req := v1.GetFilesRequest{}
@@ -228,8 +803,13 @@ func (s *GinHttpService) GetFiles(c *gin.Context) {
 		handleError(c, err)
 		return
 	}
+	if err := c.BindQuery(&req); err != nil {
+		handleError(c, err)
+		return
+	}
+	req.Labels = c.QueryMap("label")
 
-	files, err := s.getFilesSerivce.Do(req.UserID)
+	files, err := s.getFilesSerivce.Do(&req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -242,19 +822,33 @@ func (s *GinHttpService) GetFiles(c *gin.Context) {
 // UploadFile upload a file for a user
 //
 //	@Summary		Upload a file
-//	@Description	Upload a file for a specific user
+//	@Description	Upload a file for a specific user. Its type is detected from the content and checked against
+//	@Description	the declared one and the upload policy. If malware scanning is on, infected files are
+//	@Description	quarantined and rejected, or the file is pending a scan until it is found clean in async mode.
+//	@Description	A file with the same name in the folder gets the content as its next version, the replaced
+//	@Description	content is kept. Files with the same name in other folders are left alone.
 //	@Tags			files
 //	@Accept			multipart/form-data
 //	@Produce		json
-//	@Param			id		path		string	true	"User ID"
-//	@Param			file	formData	file	true	"File to upload"
-//	@Success		201		{object}	v1.UploadFileResponse
-//	@Failure		400		{object}	HttpError
-//	@Failure		500		{object}	HttpError
+//	@Param			id			path		string	true	"User ID"
+//	@Param			file		formData	file	true	"File to upload"
+//	@Param			digest		formData	string	false	"SHA-256 of the file as hex or sha-256=<base64>"
+//	@Param			folderID	formData	string	false	"Folder of the file, the root if empty"
+//	@Success		201			{object}	v1.UploadFileResponse
+//	@Failure		400			{object}	HttpError
+//	@Failure		403			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		413			{object}	HttpError
+//	@Failure		415			{object}	HttpError
+//	@Failure		422			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Failure		503			{object}	HttpError
+//	@Failure		507			{object}	HttpError
 //	@Router			/users/{id}/files [POST]
 func (s *GinHttpService) UploadFile(c *gin.Context) {
-	req := &v1.UploadFileRequest{}
-	if err := c.Bind(&req); err != nil {
+	// the form binding validates the whole request, the path parameter is set beforehand
+	req := &v1.UploadFileRequest{UserID: c.Param("id")}
+	if err := c.Bind(req); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -298,10 +892,204 @@ func (s *GinHttpService) DeleteFiles(c *gin.Context) {
 
 }
 
+// Export export all the data held about a user
+//
+//	@Summary		Export a user's data
+//	@Description	Start building a ZIP archive with the user's profile, file metadata and files. The archive is built asynchronously, poll the export to get the download link
+//	@Tags			exports
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		202	{object}	v1.ExportUserResponse
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/export [POST]
+func (s *GinHttpService) Export(c *gin.Context) {
+	req := &v1.ExportUserRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.exportService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, res)
+}
+
+// GetExport get the status of an export
+//
+//	@Summary		Get an export
+//	@Description	Get the status of a user's data export, including the download link once it is completed
+//	@Tags			exports
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			exportID	path		string	true	"Export ID"
+//	@Success		200			{object}	v1.GetExportResponse
+//	@Failure		404			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/exports/{exportID} [GET]
+func (s *GinHttpService) GetExport(c *gin.Context) {
+	req := &v1.GetExportRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.getExportService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DownloadExport download the archive of an export
+//
+//	@Summary		Download an export
+//	@Description	Download the ZIP archive of a completed export using the link returned by the export status
+//	@Tags			exports
+//	@Produce		application/zip
+//	@Param			id			path		string	true	"User ID"
+//	@Param			exportID	path		string	true	"Export ID"
+//	@Param			token		query		string	true	"Download token"
+//	@Success		200			{file}		file
+//	@Failure		404			{object}	HttpError
+//	@Failure		409			{object}	HttpError
+//	@Failure		410			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Router			/users/{id}/exports/{exportID}/download [GET]
+func (s *GinHttpService) DownloadExport(c *gin.Context) {
+	req := &v1.DownloadExportRequest{}
+
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+	if err := c.BindQuery(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	archive, err := s.downloadService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer archive.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "application/zip", archive, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="export-%s.zip"`, req.ExportID),
+	})
+}
+
+// limitBody rejects request bodies larger than the limit, up front if they declare their length
+func limitBody(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
 func handleError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+	// the wrapped error tells which attributes are invalid
+	if errors.Is(err, domain.ErrInvalidAttributes) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// the wrapped error tells the detected and declared types
+	if errors.Is(err, model.ErrFileTypeNotAllowed) || errors.Is(err, model.ErrFileTypeMismatch) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	}
+	// the wrapped error names the threat found
+	if errors.Is(err, model.ErrFileInfected) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrScanFailed) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
 	switch err {
-	case domain.ErrUserNotFound:
+	case domain.ErrUserNotFound, domain.ErrExportNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case domain.ErrGroupNotFound, domain.ErrGroupMemberNotFound, domain.ErrRoleNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrContactPointNotFound, model.ErrAddressNotFound, model.ErrAvatarNotFound, model.ErrFileNotFound,
+		model.ErrFileVersionNotFound, model.ErrFolderNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case domain.ErrUploadNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrUnknownStatusAction:
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case model.ErrInvalidVerificationToken, model.ErrStatusReasonRequired, model.ErrInvalidPassword, model.ErrInvalidResetToken:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidGroupName, model.ErrInvalidRoleName, model.ErrInvalidAttributeName:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidContactType, model.ErrInvalidPhoneNumber, model.ErrInvalidEmailAddress:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidAddressType, model.ErrInvalidAddressLines, model.ErrInvalidLocality, model.ErrInvalidRegion,
+		model.ErrInvalidCountry, model.ErrInvalidPostalCode:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidAvatarSize, domain.ErrInvalidImage:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidDigest, model.ErrDigestMismatch:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidFileDescription, model.ErrInvalidFileTag, model.ErrInvalidFileLabel, model.ErrInvalidFolderName:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidUploadLength, model.ErrInvalidFilename, model.ErrInvalidUploadOffset, model.ErrInvalidUploadMeta:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidStorageQuota:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidPresignedAction, model.ErrInvalidPresignedExpiry, model.ErrInvalidPresignedIP:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case model.ErrInvalidCredentials, model.ErrCurrentPasswordInvalid:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case model.ErrFilesReadOnly, model.ErrAccountDisabled:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrInvalidSignature, model.ErrPresignedIPMismatch:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case model.ErrFileTooLarge, domain.ErrImageTooLarge, model.ErrUploadExceedsLength:
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case domain.ErrUnsupportedImageType, model.ErrInvalidChunkType:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case domain.ErrExportNotReady, model.ErrEmailAlreadyVerified, model.ErrInvalidStatusTransition, model.ErrPasswordNotSet:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case domain.ErrGroupAlreadyExists, domain.ErrRoleAlreadyExists, domain.ErrRoleInUse:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrContactPointAlreadyExists, model.ErrContactPointNotVerified,
+		model.ErrEmailVerificationRequired, model.ErrPhoneVerificationNotSent:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrFileCorrupted, model.ErrFilePendingScan, model.ErrUploadOffsetMismatch, model.ErrFileNameTaken,
+		model.ErrFileContentChanged:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case model.ErrFolderNameTaken, model.ErrFolderNotEmpty, model.ErrInvalidFolderMove:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case domain.ErrUploadLocked:
+		c.JSON(http.StatusLocked, gin.H{"error": err.Error()})
+	case domain.ErrExportExpired, model.ErrVerificationTokenExpired, model.ErrPasswordResetExpired, domain.ErrUploadExpired,
+		model.ErrPresignedURLExpired:
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	case model.ErrStorageQuotaExceeded:
+		c.JSON(http.StatusInsufficientStorage, gin.H{"error": err.Error()})
 	default:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 	}
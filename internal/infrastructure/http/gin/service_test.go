@@ -0,0 +1,60 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newMultipartUpload builds a multipart form with a file of the size
+func newMultipartUpload(t *testing.T, size int) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "big.bin")
+	assert.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("a"), size))
+	assert.NoError(t, err)
+	assert.NoError(t, form.Close())
+	return &body, form.FormDataContentType()
+}
+
+func TestGinHttpService_BodyLimit(t *testing.T) {
+	// the services are never reached, the body is rejected first
+	service := &GinHttpService{maxFileSize: 1024}
+	router := service.GetRouter()
+
+	t.Run("Declared Length", func(t *testing.T) {
+		body, contentType := newMultipartUpload(t, 2*bodyOverhead)
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/user-123/files", body)
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("Chunked", func(t *testing.T) {
+		body, contentType := newMultipartUpload(t, 2*bodyOverhead)
+		// without a length, the body is cut short while it is read
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/user-123/files", io.MultiReader(body))
+		req.ContentLength = -1
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, req)
+
+		assert.Contains(t, []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge}, rec.Code)
+		assert.Contains(t, rec.Body.String(), "too large")
+	})
+}
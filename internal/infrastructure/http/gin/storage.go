@@ -0,0 +1,93 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// GetStorageUsage get the storage usage of a user
+//
+//	@Summary		Get storage usage
+//	@Description	Get the bytes and files a user stores and the quota that applies, 0 is unlimited
+//	@Tags			storage
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	v1.GetStorageUsageResponse
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/storage [GET]
+func (s *GinHttpService) GetStorageUsage(c *gin.Context) {
+	req := &v1.GetStorageUsageRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.getUsageService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// SetStorageQuota override the storage quota of a user
+//
+//	@Summary		Set a storage quota
+//	@Description	Override the default storage quota of a user, 0 is unlimited. Files already stored are kept
+//	@Description	even if they exceed the new quota.
+//	@Tags			storage
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"User ID"
+//	@Param			quota	body		v1.SetStorageQuotaRequest	true	"Quota of the user"
+//	@Success		200		{object}	v1.SetStorageQuotaResponse
+//	@Failure		400		{object}	HttpError
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/storage/quota [PUT]
+func (s *GinHttpService) SetStorageQuota(c *gin.Context) {
+	req := &v1.SetStorageQuotaRequest{UserID: c.Param("id")}
+	if err := c.BindJSON(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.setQuotaService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DeleteStorageQuota restore the default storage quota of a user
+//
+//	@Summary		Delete a storage quota
+//	@Description	Remove the quota override of a user, the default quota applies again
+//	@Tags			storage
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	v1.DeleteStorageQuotaResponse
+//	@Failure		404	{object}	HttpError
+//	@Failure		500	{object}	HttpError
+//	@Router			/users/{id}/storage/quota [DELETE]
+func (s *GinHttpService) DeleteStorageQuota(c *gin.Context) {
+	req := &v1.DeleteStorageQuotaRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.deleteQuotaService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tusVersion       = "1.0.0"
+	tusExtensions    = "creation,termination,expiration"
+	chunkContentType = "application/offset+octet-stream"
+)
+
+// tusResumable checks the protocol version of resumable upload requests and tells the one of the responses
+func tusResumable(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+	if c.Request.Method != http.MethodOptions && c.GetHeader("Tus-Resumable") != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		c.AbortWithStatusJSON(http.StatusPreconditionFailed, gin.H{"error": "unsupported tus version: use " + tusVersion})
+		return
+	}
+	c.Next()
+}
+
+// UploadOptions describe the resumable uploads
+//
+//	@Summary		Describe resumable uploads
+//	@Description	Tell the tus versions, extensions and maximum size supported by resumable uploads
+//	@Tags			files
+//	@Param			id	path	string	true	"User ID"
+//	@Success		204	{object}	nil
+//	@Header			204	{string}	Tus-Version		"Supported tus versions"
+//	@Header			204	{string}	Tus-Extension	"Supported tus extensions"
+//	@Header			204	{integer}	Tus-Max-Size	"Maximum upload size in bytes"
+//	@Router			/users/{id}/uploads [OPTIONS]
+func (s *GinHttpService) UploadOptions(c *gin.Context) {
+	c.Header("Tus-Version", tusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	c.Header("Tus-Max-Size", strconv.FormatInt(s.maxFileSize, 10))
+	c.Status(http.StatusNoContent)
+}
+
+// CreateUpload start a resumable upload
+//
+//	@Summary		Start a resumable upload
+//	@Description	Start a tus 1.0 upload of a file, its content is then sent in chunks to the returned location.
+//	@Description	The filename metadata is required, folderID, filetype and digest, the SHA-256 of the file, are
+//	@Description	optional. The file is added to the folder, the root if there is none.
+//	@Tags			files
+//	@Produce		json
+//	@Param			id				path		string	true	"User ID"
+//	@Param			Tus-Resumable	header		string	true	"Protocol version: 1.0.0"
+//	@Param			Upload-Length	header		integer	true	"Size of the file in bytes"
+//	@Param			Upload-Metadata	header		string	true	"Comma-separated keys with base64 values, e.g. filename ZG9jLnBkZg=="
+//	@Success		201				{object}	v1.CreateUploadResponse
+//	@Header			201				{string}	Location		"URL the chunks are sent to"
+//	@Header			201				{string}	Upload-Expires	"When the upload expires"
+//	@Failure		400				{object}	HttpError
+//	@Failure		403				{object}	HttpError
+//	@Failure		404				{object}	HttpError
+//	@Failure		412				{object}	HttpError
+//	@Failure		413				{object}	HttpError
+//	@Failure		500				{object}	HttpError
+//	@Router			/users/{id}/uploads [POST]
+func (s *GinHttpService) CreateUpload(c *gin.Context) {
+	req := &v1.CreateUploadRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil {
+		handleError(c, model.ErrInvalidUploadLength)
+		return
+	}
+	req.Length = length
+	if req.Metadata, err = parseUploadMetadata(c.GetHeader("Upload-Metadata")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.createUploadService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf(model.UploadURLTemplate, res.Upload.UserID, res.Upload.ID))
+	c.Header("Upload-Expires", res.Upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetUpload tell the offset of a resumable upload
+//
+//	@Summary		Get the offset of a resumable upload
+//	@Description	Tell how many bytes of a tus upload were received, the upload is resumed from this offset
+//	@Tags			files
+//	@Param			id				path	string	true	"User ID"
+//	@Param			uploadID		path	string	true	"Upload ID"
+//	@Param			Tus-Resumable	header	string	true	"Protocol version: 1.0.0"
+//	@Success		200				{object}	nil
+//	@Header			200				{integer}	Upload-Offset	"Bytes received"
+//	@Header			200				{integer}	Upload-Length	"Size of the file in bytes"
+//	@Header			200				{string}	Upload-Expires	"When the upload expires"
+//	@Failure		404				{object}	nil
+//	@Failure		410				{object}	nil
+//	@Failure		412				{object}	nil
+//	@Router			/users/{id}/uploads/{uploadID} [HEAD]
+func (s *GinHttpService) GetUpload(c *gin.Context) {
+	req := &v1.GetUploadRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.getUploadService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Upload-Offset", strconv.FormatInt(res.Upload.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(res.Upload.Length, 10))
+	c.Header("Upload-Expires", res.Upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusOK)
+}
+
+// PatchUpload send a chunk of a resumable upload
+//
+//	@Summary		Send a chunk of a resumable upload
+//	@Description	Append a chunk at the offset of a tus upload. The file is stored once its last byte is received,
+//	@Description	its type is then detected and checked against the upload policy.
+//	@Tags			files
+//	@Accept			application/offset+octet-stream
+//	@Param			id				path	string	true	"User ID"
+//	@Param			uploadID		path	string	true	"Upload ID"
+//	@Param			Tus-Resumable	header	string	true	"Protocol version: 1.0.0"
+//	@Param			Upload-Offset	header	integer	true	"Offset of the chunk, the bytes received so far"
+//	@Success		204				{object}	nil
+//	@Header			204				{integer}	Upload-Offset	"Bytes received"
+//	@Header			204				{string}	Upload-Expires	"When the upload expires"
+//	@Header			204				{string}	X-File-ID		"ID of the stored file, once the upload is complete"
+//	@Failure		400				{object}	HttpError
+//	@Failure		403				{object}	HttpError
+//	@Failure		404				{object}	HttpError
+//	@Failure		409				{object}	HttpError
+//	@Failure		410				{object}	HttpError
+//	@Failure		412				{object}	HttpError
+//	@Failure		413				{object}	HttpError
+//	@Failure		415				{object}	HttpError
+//	@Failure		422				{object}	HttpError
+//	@Failure		423				{object}	HttpError
+//	@Failure		500				{object}	HttpError
+//	@Failure		503				{object}	HttpError
+//	@Router			/users/{id}/uploads/{uploadID} [PATCH]
+func (s *GinHttpService) PatchUpload(c *gin.Context) {
+	req := &v1.PatchUploadRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if c.ContentType() != chunkContentType {
+		handleError(c, model.ErrInvalidChunkType)
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		handleError(c, model.ErrInvalidUploadOffset)
+		return
+	}
+	req.Offset = offset
+	req.ContentLength = c.Request.ContentLength
+	req.Content = c.Request.Body
+
+	res, err := s.patchUploadService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(res.Upload.Offset, 10))
+	c.Header("Upload-Expires", res.Upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	if res.File != nil {
+		c.Header("X-File-ID", res.File.ID)
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteUpload terminate a resumable upload
+//
+//	@Summary		Terminate a resumable upload
+//	@Description	Stop a tus upload and discard the content received so far
+//	@Tags			files
+//	@Param			id				path		string	true	"User ID"
+//	@Param			uploadID		path		string	true	"Upload ID"
+//	@Param			Tus-Resumable	header		string	true	"Protocol version: 1.0.0"
+//	@Success		204				{object}	nil
+//	@Failure		404				{object}	HttpError
+//	@Failure		412				{object}	HttpError
+//	@Failure		423				{object}	HttpError
+//	@Failure		500				{object}	HttpError
+//	@Router			/users/{id}/uploads/{uploadID} [DELETE]
+func (s *GinHttpService) DeleteUpload(c *gin.Context) {
+	req := &v1.DeleteUploadRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	if err := s.deleteUploadService.Do(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseUploadMetadata decodes the Upload-Metadata header: comma-separated keys, each followed by a base64 value
+// unless it is empty
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, model.ErrInvalidUploadMeta
+		}
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, model.ErrInvalidUploadMeta
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
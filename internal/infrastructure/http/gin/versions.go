@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+// ListFileVersions list the prior versions of a user's file
+//
+//	@Summary		List file versions
+//	@Description	List the prior versions of a file, the newest first. A version is kept each time the file is
+//	@Description	re-uploaded under the same name or restored, up to the configured retention.
+//	@Tags			files
+//	@Produce		json
+//	@Param			id		path		string	true	"User ID"
+//	@Param			fileID	path		string	true	"File ID"
+//	@Success		200		{object}	v1.ListFileVersionsResponse
+//	@Failure		404		{object}	HttpError
+//	@Failure		500		{object}	HttpError
+//	@Router			/users/{id}/files/{fileID}/versions [GET]
+func (s *GinHttpService) ListFileVersions(c *gin.Context) {
+	req := &v1.ListFileVersionsRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.listVersionsService.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// DownloadFileVersion download a prior version of a user's file
+//
+//	@Summary		Download a file version
+//	@Description	Download the content of a prior version of a file, with the headers of the file at that
+//	@Description	version. Versions pending a malware scan are not served.
+//	@Tags			files
+//	@Produce		application/octet-stream
+//	@Param			id				path		string	true	"User ID"
+//	@Param			fileID			path		string	true	"File ID"
+//	@Param			versionID		path		string	true	"Version ID"
+//	@Param			If-None-Match	header		string	false	"ETag of a cached copy"
+//	@Success		200				{file}		binary
+//	@Success		304				{object}	nil
+//	@Failure		404				{object}	HttpError
+//	@Failure		409				{object}	HttpError
+//	@Failure		500				{object}	HttpError
+//	@Router			/users/{id}/files/{fileID}/versions/{versionID}/download [GET]
+func (s *GinHttpService) DownloadFileVersion(c *gin.Context) {
+	req := &v1.DownloadFileVersionRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	file, content, err := s.downloadVersionSvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	defer content.Close()
+
+	serveFile(c, file, content)
+}
+
+// RestoreFileVersion restore a prior version of a user's file
+//
+//	@Summary		Restore a file version
+//	@Description	Make the content of a prior version the current content of the file, as its next version.
+//	@Description	The replaced content is kept as a version and counts against the storage quota.
+//	@Tags			files
+//	@Produce		json
+//	@Param			id			path		string	true	"User ID"
+//	@Param			fileID		path		string	true	"File ID"
+//	@Param			versionID	path		string	true	"Version ID"
+//	@Success		200			{object}	v1.RestoreFileVersionResponse
+//	@Failure		403			{object}	HttpError
+//	@Failure		404			{object}	HttpError
+//	@Failure		413			{object}	HttpError
+//	@Failure		500			{object}	HttpError
+//	@Failure		507			{object}	HttpError
+//	@Router			/users/{id}/files/{fileID}/versions/{versionID}/restore [POST]
+func (s *GinHttpService) RestoreFileVersion(c *gin.Context) {
+	req := &v1.RestoreFileVersionRequest{}
+	if err := c.BindUri(req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, err := s.restoreVersionSvc.Do(req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
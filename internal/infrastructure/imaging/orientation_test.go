@@ -0,0 +1,106 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// newTestPhoto encodes a 40x20 JPEG, red on top and blue below, with the EXIF orientation if it isn't 0
+func newTestPhoto(t *testing.T, order binary.ByteOrder, orientation uint16) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			if y < 10 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	if orientation == 0 {
+		return buf.Bytes()
+	}
+
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientation)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	photo := []byte{0xFF, 0xD8, 0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(photo[4:], uint16(len(segment)+2))
+	photo = append(photo, segment...)
+	return append(photo, buf.Bytes()[2:]...)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	assert.Equal(t, 6, jpegOrientation(newTestPhoto(t, binary.BigEndian, 6)))
+	assert.Equal(t, 8, jpegOrientation(newTestPhoto(t, binary.LittleEndian, 8)))
+	assert.Equal(t, 1, jpegOrientation(newTestPhoto(t, binary.BigEndian, 0)))
+	assert.Equal(t, 1, jpegOrientation(newTestPhoto(t, binary.BigEndian, 9)))
+	assert.Equal(t, 1, jpegOrientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF}))
+}
+
+func TestOrient(t *testing.T) {
+	// 2x1, a red pixel then a blue one
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, red)
+	img.Set(1, 0, blue)
+
+	rotated := orient(img, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), rotated.Bounds())
+	assert.Equal(t, red, rotated.At(0, 0))
+	assert.Equal(t, blue, rotated.At(0, 1))
+
+	rotated = orient(img, 8)
+	assert.Equal(t, blue, rotated.At(0, 0))
+	assert.Equal(t, red, rotated.At(0, 1))
+
+	flipped := orient(img, 2)
+	assert.Equal(t, blue, flipped.At(0, 0))
+	assert.Equal(t, img, orient(img, 1))
+}
+
+func TestThumbnailer_Orientation(t *testing.T) {
+	thumbnailer := NewThumbnailer(DefaultMaxPixels)
+	isRed := func(c color.Color) bool { r, _, b, _ := c.RGBA(); return r > 0xC000 && b < 0x4000 }
+	isBlue := func(c color.Color) bool { r, _, b, _ := c.RGBA(); return b > 0xC000 && r < 0x4000 }
+
+	thumbnails, err := thumbnailer.Thumbnails(newTestPhoto(t, binary.BigEndian, 0), []int{20})
+	require.NoError(t, err)
+	img, err := jpeg.Decode(bytes.NewReader(thumbnails[0].Content))
+	require.NoError(t, err)
+	assert.True(t, isRed(img.At(10, 3)), "top")
+	assert.True(t, isBlue(img.At(10, 16)), "bottom")
+
+	// rotated clockwise, the top of the stored image is on the right
+	thumbnails, err = thumbnailer.Thumbnails(newTestPhoto(t, binary.BigEndian, 6), []int{20})
+	require.NoError(t, err)
+	img, err = jpeg.Decode(bytes.NewReader(thumbnails[0].Content))
+	require.NoError(t, err)
+	assert.True(t, isBlue(img.At(3, 10)), "left")
+	assert.True(t, isRed(img.At(16, 10)), "right")
+}
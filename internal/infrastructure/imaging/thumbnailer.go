@@ -0,0 +1,105 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // registers the WebP decoder
+)
+
+// DefaultMaxPixels bounds the memory needed to decode an image, about 100 MB at 4 bytes per pixel
+const DefaultMaxPixels = 25_000_000
+
+// formats maps the sniffed content types to the names of the registered decoders
+var formats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// Thumbnailer turns JPEG photos upright, crops images to a centred square and scales them with the Go image packages.
+// Thumbnails are re-encoded from the pixels only, so EXIF and GPS metadata are never copied.
+type Thumbnailer struct {
+	maxPixels int
+}
+
+func NewThumbnailer(maxPixels int) *Thumbnailer {
+	return &Thumbnailer{maxPixels: maxPixels}
+}
+
+func (t *Thumbnailer) Thumbnails(content []byte, sizes []int) ([]*domain.Thumbnail, error) {
+	// the declared type and file name are not trusted
+	contentType := http.DetectContentType(content)
+	format, ok := formats[contentType]
+	if !ok {
+		return nil, domain.ErrUnsupportedImageType
+	}
+
+	// the header tells the dimensions, a decompression bomb is rejected before allocating its pixels
+	config, decodedFormat, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || decodedFormat != format {
+		return nil, domain.ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > t.maxPixels/config.Height {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+	// phones store photos as the sensor saw them, the EXIF orientation turns them upright
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(content))
+	}
+	square := cropSquare(img)
+
+	thumbnails := make([]*domain.Thumbnail, 0, len(sizes))
+	for _, size := range sizes {
+		thumbnail, err := encode(scale(square, size), contentType)
+		if err != nil {
+			return nil, err
+		}
+		thumbnail.Size = size
+		thumbnails = append(thumbnails, thumbnail)
+	}
+	return thumbnails, nil
+}
+
+func cropSquare(img image.Image) image.Image {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), img, image.Pt(x, y), draw.Src)
+	return square
+}
+
+func scale(img image.Image, size int) image.Image {
+	scaled := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return scaled
+}
+
+// encode keeps photos as JPEG, PNG and WebP become PNG to preserve transparency
+func encode(img image.Image, contentType string) (*domain.Thumbnail, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		return &domain.Thumbnail{ContentType: "image/jpeg", Content: buf.Bytes()}, nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return &domain.Thumbnail{ContentType: "image/png", Content: buf.Bytes()}, nil
+}
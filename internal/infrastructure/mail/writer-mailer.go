@@ -0,0 +1,25 @@
+package mail
+
+import (
+	"io"
+	"sync"
+)
+
+// WriterMailer writes emails to a writer such as stdout or a file, for local runs
+type WriterMailer struct {
+	mu   sync.Mutex
+	from string
+	w    io.Writer
+}
+
+func NewWriterMailer(from string, w io.Writer) *WriterMailer {
+	return &WriterMailer{from: from, w: w}
+}
+
+func (m *WriterMailer) Send(to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := m.w.Write(append(buildMessage(m.from, to, subject, body), "\r\n\r\n"...))
+	return err
+}
@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttributeSchema is the GORM model for an accepted attribute schema
+type AttributeSchema struct {
+	Digest     string `gorm:"primaryKey;size:64"`
+	AcceptedAt time.Time
+}
+
+// MysqlAttributeSchemaRepository is the GORM implementation of the attribute schema repository
+type MysqlAttributeSchemaRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlAttributeSchemaRepository creates a new repository instance, runs migrations
+func NewMysqlAttributeSchemaRepository(db *gorm.DB) *MysqlAttributeSchemaRepository {
+	if err := db.AutoMigrate(&AttributeSchema{}); err != nil {
+		panic(err)
+	}
+	return &MysqlAttributeSchemaRepository{db: db}
+}
+
+func (r *MysqlAttributeSchemaRepository) GetAcceptedDigest() (string, error) {
+	var schema AttributeSchema
+	result := r.db.Order("accepted_at DESC").First(&schema)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", result.Error
+	}
+	return schema.Digest, nil
+}
+
+func (r *MysqlAttributeSchemaRepository) Accept(digest string, acceptedAt time.Time) error {
+	// a schema that is accepted again becomes the current one
+	return r.db.Save(&AttributeSchema{Digest: digest, AcceptedAt: acceptedAt}).Error
+}
@@ -0,0 +1,213 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Blob is the GORM model for content stored once however many files have it
+type Blob struct {
+	Digest    string `gorm:"primaryKey;size:64"`
+	Size      int64
+	RefCount  int64 `gorm:"index"`
+	Stored    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BlobReference is the GORM model for a file pointing to a blob
+type BlobReference struct {
+	UserID    string `gorm:"primaryKey;size:255"`
+	Filename  string `gorm:"primaryKey;size:255"`
+	Digest    string `gorm:"size:64;index"`
+	UpdatedAt time.Time
+}
+
+// MysqlBlobRepository is the GORM implementation of the blob repository
+type MysqlBlobRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlBlobRepository creates a new repository instance, runs migrations
+func NewMysqlBlobRepository(db *gorm.DB) *MysqlBlobRepository {
+	if err := db.AutoMigrate(&Blob{}, &BlobReference{}); err != nil {
+		panic(err)
+	}
+	return &MysqlBlobRepository{db: db}
+}
+
+func (r *MysqlBlobRepository) Reference(userID, filename, digest string, size int64) (bool, string, error) {
+	var stored bool
+	var previous string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ref BlobReference
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&ref, "user_id = ? AND filename = ?", userID, filename).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		previous = ref.Digest
+
+		var blob Blob
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&blob, "digest = ?", digest).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			blob = Blob{Digest: digest, Size: size}
+			if err := tx.Create(&blob).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		}
+		stored = blob.Stored
+
+		// the file already has this content
+		if previous == digest {
+			previous = ""
+			return nil
+		}
+
+		if err := tx.Model(&blob).Update("ref_count", gorm.Expr("ref_count + 1")).Error; err != nil {
+			return err
+		}
+		if previous != "" {
+			if err := decrementRefCount(tx, previous, 1); err != nil {
+				return err
+			}
+		}
+		ref = BlobReference{UserID: userID, Filename: filename, Digest: digest}
+		return tx.Save(&ref).Error
+	})
+	if err != nil {
+		return false, "", err
+	}
+	return stored, previous, nil
+}
+
+func (r *MysqlBlobRepository) MarkStored(digest string) error {
+	return r.db.Model(&Blob{}).Where("digest = ?", digest).Update("stored", true).Error
+}
+
+func (r *MysqlBlobRepository) Dereference(userID, filename string) (string, error) {
+	var digest string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ref BlobReference
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&ref, "user_id = ? AND filename = ?", userID, filename).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrBlobReferenceNotFound
+			}
+			return err
+		}
+		digest = ref.Digest
+
+		if err := tx.Delete(&ref).Error; err != nil {
+			return err
+		}
+		return decrementRefCount(tx, ref.Digest, 1)
+	})
+	return digest, err
+}
+
+func (r *MysqlBlobRepository) DereferenceAll(userID string) ([]string, error) {
+	var digests []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var refs []BlobReference
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&refs, "user_id = ?", userID).Error
+		if err != nil {
+			return err
+		}
+
+		counts := map[string]int64{}
+		for _, ref := range refs {
+			if counts[ref.Digest] == 0 {
+				digests = append(digests, ref.Digest)
+			}
+			counts[ref.Digest]++
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&BlobReference{}).Error; err != nil {
+			return err
+		}
+		for _, digest := range digests {
+			if err := decrementRefCount(tx, digest, counts[digest]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+func (r *MysqlBlobRepository) GetReference(userID, filename string) (string, error) {
+	var ref BlobReference
+	err := r.db.First(&ref, "user_id = ? AND filename = ?", userID, filename).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", domain.ErrBlobReferenceNotFound
+		}
+		return "", err
+	}
+	return ref.Digest, nil
+}
+
+func (r *MysqlBlobRepository) ReferencedAt(userID, filename string) (time.Time, error) {
+	var ref BlobReference
+	err := r.db.First(&ref, "user_id = ? AND filename = ?", userID, filename).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, domain.ErrBlobReferenceNotFound
+		}
+		return time.Time{}, err
+	}
+	return ref.UpdatedAt, nil
+}
+
+func (r *MysqlBlobRepository) ListReferences(userID string) ([]string, error) {
+	var filenames []string
+	err := r.db.Model(&BlobReference{}).Where("user_id = ?", userID).Order("filename").Pluck("filename", &filenames).Error
+	return filenames, err
+}
+
+func (r *MysqlBlobRepository) ListUnreferenced() ([]string, error) {
+	var digests []string
+	err := r.db.Model(&Blob{}).Where("ref_count <= 0").Pluck("digest", &digests).Error
+	return digests, err
+}
+
+func (r *MysqlBlobRepository) Collect(digest string, remove func() error) (bool, error) {
+	collected := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// the lock covers a missing blob too, so it can't be created until the content is deleted
+		var blob Blob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&blob, "digest = ?", digest).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if blob.RefCount > 0 {
+			return nil
+		}
+
+		if err := remove(); err != nil {
+			return err
+		}
+		if err := tx.Where("digest = ?", digest).Delete(&Blob{}).Error; err != nil {
+			return err
+		}
+		collected = true
+		return nil
+	})
+	return collected, err
+}
+
+func decrementRefCount(tx *gorm.DB, digest string, n int64) error {
+	return tx.Model(&Blob{}).Where("digest = ?", digest).Update("ref_count", gorm.Expr("ref_count - ?", n)).Error
+}
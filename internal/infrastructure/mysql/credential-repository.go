@@ -0,0 +1,148 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Credential is the GORM model for a user's password, kept apart from the user
+type Credential struct {
+	UserID         string `gorm:"primaryKey;size:255"`
+	Hash           string
+	FailedAttempts int
+	LockedUntil    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// PasswordResetToken is the GORM model for a password reset token
+type PasswordResetToken struct {
+	ID        string `gorm:"primaryKey"`
+	UserID    string `gorm:"index;size:255"`
+	Hash      string `gorm:"uniqueIndex;size:64"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// MysqlCredentialRepository is the GORM implementation of the credential repository
+type MysqlCredentialRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlCredentialRepository creates a new repository instance, runs migrations
+func NewMysqlCredentialRepository(db *gorm.DB) *MysqlCredentialRepository {
+	if err := db.AutoMigrate(&Credential{}, &PasswordResetToken{}); err != nil {
+		panic(err)
+	}
+	return &MysqlCredentialRepository{db: db}
+}
+
+func (r *MysqlCredentialRepository) Get(userID string) (*model.Credential, error) {
+	var credential Credential
+	result := r.db.First(&credential, "user_id = ?", userID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCredentialNotFound
+		}
+		return nil, result.Error
+	}
+
+	domainCredential := model.NewCredential(credential.UserID, credential.Hash)
+	domainCredential.FailedAttempts = credential.FailedAttempts
+	if credential.LockedUntil != nil {
+		domainCredential.LockedUntil = *credential.LockedUntil
+	}
+	return domainCredential, nil
+}
+
+func (r *MysqlCredentialRepository) Save(credential *model.Credential) error {
+	persistenceCredential := &Credential{
+		UserID:         credential.UserID,
+		Hash:           credential.Hash,
+		FailedAttempts: credential.FailedAttempts,
+	}
+	if !credential.LockedUntil.IsZero() {
+		persistenceCredential.LockedUntil = &credential.LockedUntil
+	}
+	return r.db.Save(persistenceCredential).Error
+}
+
+func (r *MysqlCredentialRepository) RecordFailure(userID string, now time.Time, maxAttempts int, lockout time.Duration) error {
+	// MySQL assigns from left to right, locked_until is set from the count before the increment. Updates would
+	// sort the columns, hence the statement.
+	return r.db.Exec("UPDATE credentials SET "+
+		"locked_until = IF(failed_attempts + 1 >= ?, ?, locked_until), "+
+		"failed_attempts = IF(failed_attempts + 1 >= ?, 0, failed_attempts + 1), "+
+		"updated_at = ? WHERE user_id = ?",
+		maxAttempts, now.Add(lockout), maxAttempts, now, userID).Error
+}
+
+func (r *MysqlCredentialRepository) RecordSuccess(userID string) error {
+	return r.db.Model(&Credential{}).Where("user_id = ?", userID).Updates(map[string]any{
+		"failed_attempts": 0,
+		"locked_until":    nil,
+	}).Error
+}
+
+func (r *MysqlCredentialRepository) Delete(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&Credential{}).Error
+	})
+}
+
+// MysqlPasswordResetTokenRepository is the GORM implementation of the password reset token repository
+type MysqlPasswordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlPasswordResetTokenRepository creates a new repository instance, the tables are migrated by the credential repository
+func NewMysqlPasswordResetTokenRepository(db *gorm.DB) *MysqlPasswordResetTokenRepository {
+	return &MysqlPasswordResetTokenRepository{db: db}
+}
+
+func (r *MysqlPasswordResetTokenRepository) Create(token *model.PasswordResetToken) error {
+	token.ID = uuid.NewString()
+	return r.db.Create(&PasswordResetToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Hash:      token.Hash,
+		ExpiresAt: token.ExpiresAt,
+	}).Error
+}
+
+func (r *MysqlPasswordResetTokenRepository) Consume(hash string) (*model.PasswordResetToken, error) {
+	var token PasswordResetToken
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// the lock makes a concurrent request with the same token wait, then find it gone
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&token, "hash = ?", hash)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return model.ErrInvalidResetToken
+			}
+			return result.Error
+		}
+		return tx.Where("user_id = ?", token.UserID).Delete(&PasswordResetToken{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.PasswordResetToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Hash:      token.Hash,
+		ExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+func (r *MysqlPasswordResetTokenRepository) DeleteByUser(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&PasswordResetToken{}).Error
+}
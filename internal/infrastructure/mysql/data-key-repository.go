@@ -0,0 +1,71 @@
+package mysql
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+// DataKey is the GORM model for the wrapped data key of an encrypted file
+type DataKey struct {
+	UserID     string `gorm:"primaryKey;size:255"`
+	Filename   string `gorm:"primaryKey;size:255"`
+	KeyID      string `gorm:"size:64;index"`
+	WrappedKey []byte `gorm:"type:varbinary(255)"`
+}
+
+// MysqlDataKeyRepository is the GORM implementation of the data key repository
+type MysqlDataKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlDataKeyRepository creates a new repository instance, runs migrations
+func NewMysqlDataKeyRepository(db *gorm.DB) *MysqlDataKeyRepository {
+	if err := db.AutoMigrate(&DataKey{}); err != nil {
+		panic(err)
+	}
+	return &MysqlDataKeyRepository{db: db}
+}
+
+func toDomainDataKey(k *DataKey) *model.DataKey {
+	return &model.DataKey{UserID: k.UserID, Filename: k.Filename, KeyID: k.KeyID, WrappedKey: k.WrappedKey}
+}
+
+func (r *MysqlDataKeyRepository) Save(key *model.DataKey) error {
+	return r.db.Save(&DataKey{UserID: key.UserID, Filename: key.Filename, KeyID: key.KeyID, WrappedKey: key.WrappedKey}).Error
+}
+
+func (r *MysqlDataKeyRepository) Get(userID, filename string) (*model.DataKey, error) {
+	var key DataKey
+	err := r.db.First(&key, "user_id = ? AND filename = ?", userID, filename).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDataKeyNotFound
+		}
+		return nil, err
+	}
+	return toDomainDataKey(&key), nil
+}
+
+func (r *MysqlDataKeyRepository) Delete(userID, filename string) error {
+	return r.db.Where("user_id = ? AND filename = ?", userID, filename).Delete(&DataKey{}).Error
+}
+
+func (r *MysqlDataKeyRepository) DeleteAll(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&DataKey{}).Error
+}
+
+func (r *MysqlDataKeyRepository) ListWrappedWithOtherKey(keyID string, limit int) ([]*model.DataKey, error) {
+	var keys []DataKey
+	err := r.db.Where("key_id <> ?", keyID).Order("user_id, filename").Limit(limit).Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.DataKey, len(keys))
+	for i := range keys {
+		result[i] = toDomainDataKey(&keys[i])
+	}
+	return result, nil
+}
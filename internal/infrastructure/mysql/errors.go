@@ -0,0 +1,18 @@
+package mysql
+
+import (
+	"errors"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// duplicateEntry is the MySQL error number of a row violating a unique index (ER_DUP_ENTRY)
+const duplicateEntry = 1062
+
+// isDuplicateKey tells whether the error is a violation of a unique index, translated by GORM or as returned by
+// the driver if the database was opened without TranslateError
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysqlDriver.MySQLError
+	return errors.Is(err, gorm.ErrDuplicatedKey) || (errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry)
+}
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Export is the GORM model for a data subject export
+type Export struct {
+	ID        string `gorm:"primaryKey"`
+	UserID    string `gorm:"index;size:255"`
+	Status    string `gorm:"size:32"`
+	Token     string `gorm:"size:64"`
+	Error     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt *time.Time
+}
+
+// MysqlExportRepository is the GORM implementation of the export repository
+type MysqlExportRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlExportRepository creates a new repository instance, runs migrations
+func NewMysqlExportRepository(db *gorm.DB) *MysqlExportRepository {
+	if err := db.AutoMigrate(&Export{}); err != nil {
+		panic(err)
+	}
+	return &MysqlExportRepository{db: db}
+}
+
+// toDomainExport converts a GORM export to a domain export
+func toDomainExport(e *Export) *model.Export {
+	export := &model.Export{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Status:    model.ExportStatus(e.Status),
+		Token:     e.Token,
+		Error:     e.Error,
+		CreatedAt: e.CreatedAt,
+	}
+	if e.ExpiresAt != nil {
+		export.ExpiresAt = *e.ExpiresAt
+	}
+	return export
+}
+
+// fromDomainExport converts a domain export to a GORM export
+func fromDomainExport(e *model.Export) *Export {
+	export := &Export{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Status:    string(e.Status),
+		Token:     e.Token,
+		Error:     e.Error,
+		CreatedAt: e.CreatedAt,
+	}
+	if !e.ExpiresAt.IsZero() {
+		export.ExpiresAt = &e.ExpiresAt
+	}
+	return export
+}
+
+func (r *MysqlExportRepository) Create(export *model.Export) (string, error) {
+	export.ID = uuid.NewString()
+	if err := r.db.Create(fromDomainExport(export)).Error; err != nil {
+		return "", err
+	}
+	return export.ID, nil
+}
+
+func (r *MysqlExportRepository) Get(userID, exportID string) (*model.Export, error) {
+	var export Export
+	result := r.db.First(&export, "user_id = ? AND id = ?", userID, exportID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrExportNotFound
+		}
+		return nil, result.Error
+	}
+	return toDomainExport(&export), nil
+}
+
+func (r *MysqlExportRepository) Update(export *model.Export) error {
+	return r.db.Save(fromDomainExport(export)).Error
+}
+
+func (r *MysqlExportRepository) List(userID string) ([]*model.Export, error) {
+	var exports []Export
+	if err := r.db.Where("user_id = ?", userID).Find(&exports).Error; err != nil {
+		return nil, err
+	}
+	list := make([]*model.Export, 0, len(exports))
+	for i := range exports {
+		list = append(list, toDomainExport(&exports[i]))
+	}
+	return list, nil
+}
+
+func (r *MysqlExportRepository) ListExpired(now time.Time) ([]*model.Export, error) {
+	var exports []Export
+	err := r.db.Where("status = ? AND expires_at <= ?", string(model.ExportCompleted), now).Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	expired := make([]*model.Export, 0, len(exports))
+	for i := range exports {
+		expired = append(expired, toDomainExport(&exports[i]))
+	}
+	return expired, nil
+}
+
+func (r *MysqlExportRepository) Delete(userID, exportID string) error {
+	return r.db.Where("user_id = ? AND id = ?", userID, exportID).Delete(&Export{}).Error
+}
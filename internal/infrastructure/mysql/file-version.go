@@ -0,0 +1,171 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FileVersion is the GORM model for the prior content of a file
+type FileVersion struct {
+	ID           string `gorm:"primaryKey"`
+	FileID       string `gorm:"size:255;index"`
+	UserID       string `gorm:"size:255;index"`
+	StorageKey   string `gorm:"size:255"`
+	Path         string
+	Version      int
+	Size         int64
+	ContentType  string `gorm:"size:255"`
+	DeclaredType string `gorm:"size:255"`
+	Digest       string `gorm:"size:64"`
+	ScanStatus   string `gorm:"size:16"`
+	ReplacedAt   time.Time
+}
+
+// toDomainFileVersion converts a GORM file version to a domain file version
+func toDomainFileVersion(v *FileVersion) *model.FileVersion {
+	return &model.FileVersion{
+		ID:           v.ID,
+		FileID:       v.FileID,
+		UserID:       v.UserID,
+		StorageKey:   v.StorageKey,
+		Path:         v.Path,
+		Version:      v.Version,
+		Size:         v.Size,
+		ContentType:  v.ContentType,
+		DeclaredType: v.DeclaredType,
+		Digest:       v.Digest,
+		ScanStatus:   v.ScanStatus,
+		ReplacedAt:   v.ReplacedAt,
+	}
+}
+
+// fromDomainFileVersion converts a domain file version to a GORM file version
+func fromDomainFileVersion(v *model.FileVersion) *FileVersion {
+	return &FileVersion{
+		ID:           v.ID,
+		FileID:       v.FileID,
+		UserID:       v.UserID,
+		StorageKey:   v.StorageKey,
+		Path:         v.Path,
+		Version:      v.Version,
+		Size:         v.Size,
+		ContentType:  v.ContentType,
+		DeclaredType: v.DeclaredType,
+		Digest:       v.Digest,
+		ScanStatus:   v.ScanStatus,
+		ReplacedAt:   v.ReplacedAt,
+	}
+}
+
+func (r *MysqlUserRepository) ReplaceFile(file *model.File, previous, restored *model.FileVersion, defaultQuota *model.StorageQuota) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := lockStorageUsage(tx, file.UserID)
+		if err != nil {
+			return err
+		}
+
+		var current File
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, "user_id = ? AND id = ?", file.UserID, file.ID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrFileNotFound
+			}
+			return err
+		}
+
+		// the version was taken of the content the file had when it was read
+		if previous != nil && previous.StorageName() != toDomainFile(&current).StorageName() {
+			return model.ErrFileContentChanged
+		}
+
+		usage := toDomainStorageUsage(locked)
+		growth := file.Size
+		if previous == nil {
+			growth -= current.Size
+		}
+		if restored != nil {
+			// the content of the version becomes the file's, it's no longer counted as a version
+			var version FileVersion
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				First(&version, "user_id = ? AND file_id = ? AND id = ?", file.UserID, file.ID, restored.ID).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return model.ErrFileVersionNotFound
+				}
+				return err
+			}
+			if err := tx.Delete(&version).Error; err != nil {
+				return err
+			}
+			growth -= version.Size
+		}
+		if err := usage.Grow(growth, defaultQuota); err != nil {
+			return err
+		}
+		if err := tx.Save(fromDomainStorageUsage(usage)).Error; err != nil {
+			return err
+		}
+
+		if previous != nil {
+			if err := tx.Create(fromDomainFileVersion(previous)).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&current).
+			Select("StorageKey", "Path", "Size", "ContentType", "DeclaredType", "Digest", "Corrupted", "ScanStatus", "Version").
+			Updates(fromDomainFile(file)).Error
+	})
+}
+
+func (r *MysqlUserRepository) GetFileVersions(userID, fileID string) ([]*model.FileVersion, error) {
+	var versions []FileVersion
+	result := r.db.Where("user_id = ? AND file_id = ?", userID, fileID).Order("version DESC").Find(&versions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	domainVersions := make([]*model.FileVersion, 0, len(versions))
+	for _, v := range versions {
+		domainVersions = append(domainVersions, toDomainFileVersion(&v))
+	}
+	return domainVersions, nil
+}
+
+func (r *MysqlUserRepository) GetFileVersion(userID, fileID, versionID string) (*model.FileVersion, error) {
+	var version FileVersion
+	result := r.db.First(&version, "user_id = ? AND file_id = ? AND id = ?", userID, fileID, versionID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, model.ErrFileVersionNotFound
+		}
+		return nil, result.Error
+	}
+	return toDomainFileVersion(&version), nil
+}
+
+func (r *MysqlUserRepository) DeleteFileVersion(userID, versionID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := lockStorageUsage(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		var version FileVersion
+		if err := tx.First(&version, "user_id = ? AND id = ?", userID, versionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrFileVersionNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&version).Error; err != nil {
+			return err
+		}
+
+		usage := toDomainStorageUsage(locked)
+		usage.Shrink(version.Size)
+		return tx.Save(fromDomainStorageUsage(usage)).Error
+	})
+}
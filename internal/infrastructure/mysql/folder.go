@@ -0,0 +1,108 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Folder is the GORM model for a folder of a user's files. The names of the folders with the same parent are
+// unique, the root folders have an empty parent ID.
+type Folder struct {
+	ID        string `gorm:"primaryKey"`
+	UserID    string `gorm:"size:255;uniqueIndex:idx_folder_name"`
+	ParentID  string `gorm:"size:36;uniqueIndex:idx_folder_name"`
+	Name      string `gorm:"size:255;uniqueIndex:idx_folder_name"`
+	CreatedAt time.Time
+}
+
+// toDomainFolder converts a GORM folder to a domain folder
+func toDomainFolder(f *Folder) *model.Folder {
+	return &model.Folder{
+		ID:        f.ID,
+		UserID:    f.UserID,
+		ParentID:  f.ParentID,
+		Name:      f.Name,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+// fromDomainFolder converts a domain folder to a GORM folder
+func fromDomainFolder(f *model.Folder) *Folder {
+	return &Folder{
+		ID:        f.ID,
+		UserID:    f.UserID,
+		ParentID:  f.ParentID,
+		Name:      f.Name,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func (r *MysqlUserRepository) GetFolders(userID string) ([]*model.Folder, error) {
+	var folders []Folder
+	result := r.db.Where("user_id = ?", userID).Find(&folders)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	domainFolders := make([]*model.Folder, 0, len(folders))
+	for _, f := range folders {
+		domainFolders = append(domainFolders, toDomainFolder(&f))
+	}
+	return domainFolders, nil
+}
+
+func (r *MysqlUserRepository) CreateFolder(folder *model.Folder) error {
+	result := r.db.Create(fromDomainFolder(folder))
+	if result.Error != nil {
+		if isDuplicateKey(result.Error) {
+			return model.ErrFolderNameTaken
+		}
+		return result.Error
+	}
+	return nil
+}
+
+func (r *MysqlUserRepository) UpdateFolder(folder *model.Folder) error {
+	result := r.db.Model(&Folder{}).Where("user_id = ? AND id = ?", folder.UserID, folder.ID).
+		Select("ParentID", "Name").
+		Updates(fromDomainFolder(folder))
+	if result.Error != nil {
+		if isDuplicateKey(result.Error) {
+			return model.ErrFolderNameTaken
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrFolderNotFound
+	}
+	return nil
+}
+
+func (r *MysqlUserRepository) DeleteFolder(userID, folderID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var folder Folder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&folder, "user_id = ? AND id = ?", userID, folderID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrFolderNotFound
+			}
+			return err
+		}
+
+		// files or folders moved in since the caller checked the folder is empty
+		var files, folders int64
+		if err := tx.Model(&File{}).Where("user_id = ? AND folder_id = ?", userID, folderID).Count(&files).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Folder{}).Where("user_id = ? AND parent_id = ?", userID, folderID).Count(&folders).Error; err != nil {
+			return err
+		}
+		if files > 0 || folders > 0 {
+			return model.ErrFolderNotEmpty
+		}
+
+		return tx.Delete(&folder).Error
+	})
+}
@@ -0,0 +1,160 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Role is the GORM model for a role
+type Role struct {
+	Name        string `gorm:"primaryKey;size:64"`
+	Description string
+	CreatedAt   time.Time
+}
+
+// Group is the GORM model for a group, roles and members are many-to-many associations
+type Group struct {
+	ID          string `gorm:"primaryKey"`
+	Name        string `gorm:"uniqueIndex;size:255"`
+	Description string
+	Roles       []*Role `gorm:"many2many:group_roles"`
+	Members     []*User `gorm:"many2many:group_members"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// MysqlGroupRepository is the GORM implementation of the group repository
+type MysqlGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlGroupRepository creates a new repository instance, runs migrations
+func NewMysqlGroupRepository(db *gorm.DB) *MysqlGroupRepository {
+	if err := db.AutoMigrate(&Role{}, &Group{}); err != nil {
+		panic(err)
+	}
+	return &MysqlGroupRepository{db: db}
+}
+
+// toDomainGroup converts a GORM group to a domain group
+func toDomainGroup(g *Group) *model.Group {
+	roles := make([]string, 0, len(g.Roles))
+	for _, r := range g.Roles {
+		roles = append(roles, r.Name)
+	}
+	group, _ := model.NewGroup(g.Name, g.Description, roles)
+	group.ID = g.ID
+	return group
+}
+
+// fromDomainGroup converts a domain group to a GORM group
+func fromDomainGroup(g *model.Group) *Group {
+	roles := make([]*Role, 0, len(g.GetRoles()))
+	for _, name := range g.GetRoles() {
+		roles = append(roles, &Role{Name: name})
+	}
+	return &Group{
+		ID:          g.ID,
+		Name:        g.ToDTO().Name, // DTO contains the private fields
+		Description: g.ToDTO().Description,
+		Roles:       roles,
+	}
+}
+
+func (r *MysqlGroupRepository) Create(group *model.Group) (string, error) {
+	group.ID = uuid.NewString()
+
+	// roles are referenced, never created or updated through a group
+	result := r.db.Omit("Roles.*").Create(fromDomainGroup(group))
+	if result.Error != nil {
+		if isDuplicateKey(result.Error) {
+			return "", domain.ErrGroupAlreadyExists
+		}
+		return "", result.Error
+	}
+	return group.ID, nil
+}
+
+func (r *MysqlGroupRepository) Get(id string) (*model.Group, error) {
+	var group Group
+	result := r.db.Preload("Roles").First(&group, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGroupNotFound
+		}
+		return nil, result.Error
+	}
+	return toDomainGroup(&group), nil
+}
+
+func (r *MysqlGroupRepository) List() ([]*model.Group, error) {
+	var groups []Group
+	result := r.db.Preload("Roles").Order("name").Find(&groups)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	domainGroups := make([]*model.Group, 0, len(groups))
+	for _, g := range groups {
+		domainGroups = append(domainGroups, toDomainGroup(&g))
+	}
+	return domainGroups, nil
+}
+
+func (r *MysqlGroupRepository) Update(group *model.Group) error {
+	updated := fromDomainGroup(group)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&Group{}, "id = ?", group.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrGroupNotFound
+			}
+			return err
+		}
+
+		err := tx.Model(&Group{ID: group.ID}).Updates(map[string]any{
+			"name":        updated.Name,
+			"description": updated.Description,
+		}).Error
+		if err != nil {
+			if isDuplicateKey(err) {
+				return domain.ErrGroupAlreadyExists
+			}
+			return err
+		}
+
+		return tx.Model(&Group{ID: group.ID}).Association("Roles").Replace(updated.Roles)
+	})
+}
+
+func (r *MysqlGroupRepository) Delete(id string) error {
+	// drop role assignments and memberships along with the group
+	result := r.db.Select("Roles", "Members").Delete(&Group{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrGroupNotFound
+	}
+	return nil
+}
+
+func (r *MysqlGroupRepository) AddMember(groupID, userID string) error {
+	return r.db.Exec("INSERT IGNORE INTO group_members (group_id, user_id) VALUES (?, ?)", groupID, userID).Error
+}
+
+func (r *MysqlGroupRepository) RemoveMember(groupID, userID string) error {
+	result := r.db.Exec("DELETE FROM group_members WHERE group_id = ? AND user_id = ?", groupID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrGroupMemberNotFound
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONList is a JSON array of strings stored in a MySQL JSON column
+type JSONList []string
+
+func (l JSONList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	encoded, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func (l *JSONList) Scan(value any) error {
+	var encoded []byte
+	switch v := value.(type) {
+	case nil:
+		// rows created before the column existed
+		*l = JSONList{}
+		return nil
+	case []byte:
+		encoded = v
+	case string:
+		encoded = []byte(v)
+	default:
+		return fmt.Errorf("unsupported JSON column value %T", value)
+	}
+	return json.Unmarshal(encoded, l)
+}
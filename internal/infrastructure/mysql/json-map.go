@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMap is a JSON object stored in a MySQL JSON column
+type JSONMap map[string]any
+
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+func (m *JSONMap) Scan(value any) error {
+	var encoded []byte
+	switch v := value.(type) {
+	case nil:
+		// rows created before the column existed
+		*m = JSONMap{}
+		return nil
+	case []byte:
+		encoded = v
+	case string:
+		encoded = []byte(v)
+	default:
+		return fmt.Errorf("unsupported JSON column value %T", value)
+	}
+	return json.Unmarshal(encoded, m)
+}
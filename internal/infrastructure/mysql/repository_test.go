@@ -0,0 +1,169 @@
+package mysql
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// newTestDB opens the MySQL database at MYSQL_TEST_DSN, e.g. a local container, like the service does. The test
+// is skipped without one.
+func newTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+	db, err := gorm.Open(gormMysql.Open(dsn), &gorm.Config{TranslateError: true})
+	require.NoError(t, err)
+	return db
+}
+
+func TestIsDuplicateKey(t *testing.T) {
+	assert.True(t, isDuplicateKey(gorm.ErrDuplicatedKey))
+	assert.True(t, isDuplicateKey(fmt.Errorf("creating: %w", &mysqlDriver.MySQLError{Number: 1062})))
+	assert.False(t, isDuplicateKey(&mysqlDriver.MySQLError{Number: 1452}))
+	assert.False(t, isDuplicateKey(gorm.ErrRecordNotFound))
+}
+
+func TestMysqlRoleRepository_CreateDuplicate(t *testing.T) {
+	db := newTestDB(t)
+	repository := NewMysqlRoleRepository(db)
+	name := "role-" + uuid.NewString()[:8]
+	t.Cleanup(func() { db.Delete(&Role{}, "name = ?", name) })
+
+	require.NoError(t, repository.Create(&model.Role{Name: name}))
+
+	assert.ErrorIs(t, repository.Create(&model.Role{Name: name}), domain.ErrRoleAlreadyExists)
+}
+
+func TestMysqlGroupRepository_Duplicate(t *testing.T) {
+	db := newTestDB(t)
+	NewMysqlUserRepository(db)
+	repository := NewMysqlGroupRepository(db)
+	name, other := "group-"+uuid.NewString(), "group-"+uuid.NewString()
+
+	first, err := model.NewGroup(name, "", nil)
+	require.NoError(t, err)
+	firstID, err := repository.Create(first)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Delete(&Group{}, "id = ?", firstID) })
+
+	duplicate, _ := model.NewGroup(name, "", nil)
+	_, err = repository.Create(duplicate)
+	assert.ErrorIs(t, err, domain.ErrGroupAlreadyExists)
+
+	second, _ := model.NewGroup(other, "", nil)
+	secondID, err := repository.Create(second)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Delete(&Group{}, "id = ?", secondID) })
+
+	renamed, _ := model.NewGroup(name, "", nil)
+	renamed.ID = secondID
+	assert.ErrorIs(t, repository.Update(renamed), domain.ErrGroupAlreadyExists)
+}
+
+func TestMysqlUserRepository_Migration(t *testing.T) {
+	db := newTestDB(t)
+	NewMysqlUserRepository(db)
+
+	assert.True(t, db.Migrator().HasIndex(&User{}, "idx_users_email"))
+}
+
+// newTestUser creates a user with the email address, deleted after the test
+func newTestUser(t *testing.T, db *gorm.DB, repository *MysqlUserRepository, email string) *model.User {
+	user, err := model.NewUser("Test User", email, "1990-01-01")
+	require.NoError(t, err)
+	_, err = repository.Create(user)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Delete(&ContactPoint{}, "user_id = ?", user.ID)
+		db.Unscoped().Delete(&File{}, "user_id = ?", user.ID)
+		db.Delete(&Folder{}, "user_id = ?", user.ID)
+		db.Delete(&StorageUsage{}, "user_id = ?", user.ID)
+		db.Unscoped().Delete(&User{}, "id = ?", user.ID)
+	})
+	return user
+}
+
+func TestMysqlUserRepository_DuplicateEmail(t *testing.T) {
+	db := newTestDB(t)
+	repository := NewMysqlUserRepository(db)
+	email := "shared-" + uuid.NewString() + "@example.com"
+
+	t.Run("Primary Email", func(t *testing.T) {
+		newTestUser(t, db, repository, email)
+		other := newTestUser(t, db, repository, "other-"+uuid.NewString()+"@example.com")
+
+		duplicate, _ := model.NewUser("Other User", email, "1990-01-01")
+		_, err := repository.Create(duplicate)
+		assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+
+		require.NoError(t, other.SetEmail(email))
+		assert.ErrorIs(t, repository.Update(other.ID, other), model.ErrContactPointAlreadyExists)
+	})
+
+	t.Run("Verified Contact Point", func(t *testing.T) {
+		email := "contact-" + uuid.NewString() + "@example.com"
+		owner := newTestUser(t, db, repository, "owner-"+uuid.NewString()+"@example.com")
+		other := newTestUser(t, db, repository, "other-"+uuid.NewString()+"@example.com")
+		verify := func(user *model.User) error {
+			contact, err := model.NewContactPoint(string(model.ContactEmail), email, "")
+			require.NoError(t, err)
+			contact.ID = uuid.NewString()
+			require.NoError(t, user.AddContactPoint(contact))
+			require.NoError(t, user.VerifyContactPoint(contact.ID))
+			return repository.Update(user.ID, user)
+		}
+
+		require.NoError(t, verify(owner))
+		assert.ErrorIs(t, verify(other), model.ErrContactPointAlreadyExists)
+
+		// the contact point of the owner is left alone
+		found, err := repository.GetByEmail(email)
+		require.NoError(t, err)
+		assert.Equal(t, owner.ID, found.ID)
+	})
+}
+
+func TestMysqlUserRepository_DuplicateFileName(t *testing.T) {
+	db := newTestDB(t)
+	repository := NewMysqlUserRepository(db)
+	user := newTestUser(t, db, repository, "files-"+uuid.NewString()+"@example.com")
+	newFile := func(name, folderID string) *model.File {
+		id := uuid.NewString()
+		return &model.File{ID: id, UserID: user.ID, Name: name, StorageKey: id, FolderID: folderID}
+	}
+
+	report := newFile("report.pdf", "")
+	require.NoError(t, repository.AddFile(report, &model.StorageQuota{}))
+	assert.ErrorIs(t, repository.AddFile(newFile("report.pdf", ""), &model.StorageQuota{}), model.ErrFileNameTaken)
+
+	// the name is unique per folder
+	folder, err := model.NewFolder(uuid.NewString(), user.ID, "", "Documents", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repository.CreateFolder(folder))
+	assert.NoError(t, repository.AddFile(newFile("report.pdf", folder.ID), &model.StorageQuota{}))
+
+	notes := newFile("notes.txt", "")
+	require.NoError(t, repository.AddFile(notes, &model.StorageQuota{}))
+	notes.Name = "report.pdf"
+	assert.ErrorIs(t, repository.UpdateFile(notes), model.ErrFileNameTaken)
+
+	// the name of a deleted file can be reused
+	require.NoError(t, repository.DeleteFile(user.ID, report.ID))
+	assert.NoError(t, repository.AddFile(newFile("report.pdf", ""), &model.StorageQuota{}))
+
+	duplicate, err := model.NewFolder(uuid.NewString(), user.ID, "", "Documents", time.Now())
+	require.NoError(t, err)
+	assert.ErrorIs(t, repository.CreateFolder(duplicate), model.ErrFolderNameTaken)
+}
@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+// MysqlRoleRepository is the GORM implementation of the role repository
+type MysqlRoleRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlRoleRepository creates a new repository instance, runs migrations
+func NewMysqlRoleRepository(db *gorm.DB) *MysqlRoleRepository {
+	if err := db.AutoMigrate(&Role{}); err != nil {
+		panic(err)
+	}
+	return &MysqlRoleRepository{db: db}
+}
+
+func (r *MysqlRoleRepository) Create(role *model.Role) error {
+	result := r.db.Create(&Role{Name: role.Name, Description: role.Description})
+	if result.Error != nil {
+		if isDuplicateKey(result.Error) {
+			return domain.ErrRoleAlreadyExists
+		}
+		return result.Error
+	}
+	return nil
+}
+
+func (r *MysqlRoleRepository) Get(name string) (*model.Role, error) {
+	var role Role
+	result := r.db.First(&role, "name = ?", name)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrRoleNotFound
+		}
+		return nil, result.Error
+	}
+	return &model.Role{Name: role.Name, Description: role.Description}, nil
+}
+
+func (r *MysqlRoleRepository) List() ([]*model.Role, error) {
+	var roles []Role
+	result := r.db.Order("name").Find(&roles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	domainRoles := make([]*model.Role, 0, len(roles))
+	for _, role := range roles {
+		domainRoles = append(domainRoles, &model.Role{Name: role.Name, Description: role.Description})
+	}
+	return domainRoles, nil
+}
+
+func (r *MysqlRoleRepository) Delete(name string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var assignments int64
+		err := tx.Table("group_roles").Where("role_name = ?", name).Count(&assignments).Error
+		if err != nil {
+			return err
+		}
+		if assignments > 0 {
+			return domain.ErrRoleInUse
+		}
+
+		result := tx.Delete(&Role{}, "name = ?", name)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrRoleNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+// parseIndexes parses the GORM tags of the model like AutoMigrate does and returns its indexes by name
+func parseIndexes(t *testing.T, model any) (*schema.Schema, map[string]*schema.Index) {
+	s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+	indexes := map[string]*schema.Index{}
+	for _, index := range s.ParseIndexes() {
+		indexes[index.Name] = index
+	}
+	return s, indexes
+}
+
+// indexColumns lists the columns of the index in order
+func indexColumns(index *schema.Index) []string {
+	var columns []string
+	for _, field := range index.Fields {
+		columns = append(columns, field.DBName)
+	}
+	return columns
+}
+
+func TestUserSchema(t *testing.T) {
+	s, indexes := parseIndexes(t, &User{})
+
+	require.Contains(t, indexes, "idx_users_email")
+	assert.Equal(t, "UNIQUE", indexes["idx_users_email"].Class)
+	assert.Equal(t, []string{"email"}, indexColumns(indexes["idx_users_email"]))
+	assert.Equal(t, 255, s.LookUpField("Email").Size)
+}
+
+func TestContactPointSchema(t *testing.T) {
+	s, indexes := parseIndexes(t, &ContactPoint{})
+
+	require.Contains(t, indexes, "idx_contact_points_verified_email")
+	assert.Equal(t, "UNIQUE", indexes["idx_contact_points_verified_email"].Class)
+	// the column is computed by the database, never written
+	field := s.LookUpField("VerifiedEmail")
+	assert.False(t, field.Creatable)
+	assert.False(t, field.Updatable)
+}
+
+func TestFileSchema(t *testing.T) {
+	s, indexes := parseIndexes(t, &File{})
+
+	require.Contains(t, indexes, "idx_file_name")
+	assert.Equal(t, "UNIQUE", indexes["idx_file_name"].Class)
+	assert.Equal(t, []string{"user_id", "folder_id", "name"}, indexColumns(indexes["idx_file_name"]))
+	assert.Equal(t, 255, s.LookUpField("Name").Size)
+}
@@ -0,0 +1,170 @@
+package mysql
+
+import (
+	"errors"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StorageUsage is the GORM model for the bytes and files stored by a user, kept in step with the files table
+type StorageUsage struct {
+	UserID     string `gorm:"primaryKey;size:255"`
+	Bytes      int64
+	Files      int64
+	QuotaBytes *int64
+	QuotaFiles *int64
+}
+
+// toDomainStorageUsage converts a GORM storage usage to a domain storage usage
+func toDomainStorageUsage(u *StorageUsage) *model.StorageUsage {
+	usage := &model.StorageUsage{UserID: u.UserID, Bytes: u.Bytes, Files: u.Files}
+	if u.QuotaBytes != nil && u.QuotaFiles != nil {
+		usage.Quota = &model.StorageQuota{Bytes: *u.QuotaBytes, Files: *u.QuotaFiles}
+	}
+	return usage
+}
+
+// fromDomainStorageUsage converts a domain storage usage to a GORM storage usage
+func fromDomainStorageUsage(u *model.StorageUsage) *StorageUsage {
+	usage := &StorageUsage{UserID: u.UserID, Bytes: u.Bytes, Files: u.Files}
+	if u.Quota != nil {
+		usage.QuotaBytes = &u.Quota.Bytes
+		usage.QuotaFiles = &u.Quota.Files
+	}
+	return usage
+}
+
+// lockStorageUsage reads the usage of a user for update, so concurrent uploads are accounted for one at a time.
+// The usage of a user without a record yet is counted from the files and file versions tables.
+func lockStorageUsage(tx *gorm.DB, userID string) (*StorageUsage, error) {
+	var usage StorageUsage
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&usage, "user_id = ?", userID).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &usage, err
+	}
+
+	usage = StorageUsage{UserID: userID}
+	err = tx.Model(&File{}).Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0) AS bytes, COUNT(*) AS files").Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	var versionBytes int64
+	err = tx.Model(&FileVersion{}).Where("user_id = ?", userID).Select("COALESCE(SUM(size), 0)").Scan(&versionBytes).Error
+	if err != nil {
+		return nil, err
+	}
+	usage.Bytes += versionBytes
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&usage).Error; err != nil {
+		return nil, err
+	}
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&usage, "user_id = ?", userID).Error
+	return &usage, err
+}
+
+func (r *MysqlUserRepository) AddFile(file *model.File, defaultQuota *model.StorageQuota) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := lockStorageUsage(tx, file.UserID)
+		if err != nil {
+			return err
+		}
+
+		usage := toDomainStorageUsage(locked)
+		if err := usage.Add(file.Size, defaultQuota); err != nil {
+			return err
+		}
+		if err := tx.Save(fromDomainStorageUsage(usage)).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(fromDomainFile(file)).Error; err != nil {
+			if isDuplicateKey(err) {
+				return model.ErrFileNameTaken
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *MysqlUserRepository) DeleteFile(userID, fileID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := lockStorageUsage(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		var file File
+		if err := tx.First(&file, "user_id = ? AND id = ?", userID, fileID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrFileNotFound
+			}
+			return err
+		}
+		if err := tx.Unscoped().Delete(&file).Error; err != nil {
+			return err
+		}
+		var versionBytes int64
+		err = tx.Model(&FileVersion{}).Where("user_id = ? AND file_id = ?", userID, fileID).
+			Select("COALESCE(SUM(size), 0)").Scan(&versionBytes).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ? AND file_id = ?", userID, fileID).Delete(&FileVersion{}).Error; err != nil {
+			return err
+		}
+
+		usage := toDomainStorageUsage(locked)
+		usage.Remove(file.Size)
+		usage.Shrink(versionBytes)
+		return tx.Save(fromDomainStorageUsage(usage)).Error
+	})
+}
+
+func (r *MysqlUserRepository) GetStorageUsage(userID string) (*model.StorageUsage, error) {
+	var usage *StorageUsage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		usage, err = lockStorageUsage(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainStorageUsage(usage), nil
+}
+
+func (r *MysqlUserRepository) SetStorageQuota(userID string, quota *model.StorageQuota) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := lockStorageUsage(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		usage := toDomainStorageUsage(locked)
+		usage.Quota = quota
+		// Save skips nil fields of a new record only, an existing one gets them as NULL
+		return tx.Select("*").Save(fromDomainStorageUsage(usage)).Error
+	})
+}
+
+func (r *MysqlUserRepository) GetStorageTotals() (*model.StorageUsage, error) {
+	var totals StorageUsage
+	err := r.db.Model(&File{}).Select("COALESCE(SUM(size), 0) AS bytes, COUNT(*) AS files").Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	var versionBytes int64
+	err = r.db.Model(&FileVersion{}).Select("COALESCE(SUM(size), 0)").Scan(&versionBytes).Error
+	if err != nil {
+		return nil, err
+	}
+	totals.Bytes += versionBytes
+	return toDomainStorageUsage(&totals), nil
+}
+
+// resetStorageUsage accounts for the deletion of all the files of a user
+func resetStorageUsage(tx *gorm.DB, userID string) error {
+	return tx.Model(&StorageUsage{}).Where("user_id = ?", userID).Updates(map[string]any{"bytes": 0, "files": 0}).Error
+}
@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Upload is the GORM model for a resumable upload in progress
+type Upload struct {
+	ID           string `gorm:"primaryKey"`
+	UserID       string `gorm:"index;size:255"`
+	Filename     string `gorm:"size:255"`
+	FolderID     string `gorm:"size:36"`
+	DeclaredType string `gorm:"size:255"`
+	Digest       string `gorm:"size:64"`
+	Length       int64
+	Offset       int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	ExpiresAt    time.Time `gorm:"index"`
+}
+
+// MysqlUploadRepository is the GORM implementation of the upload repository
+type MysqlUploadRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlUploadRepository creates a new repository instance, runs migrations
+func NewMysqlUploadRepository(db *gorm.DB) *MysqlUploadRepository {
+	if err := db.AutoMigrate(&Upload{}); err != nil {
+		panic(err)
+	}
+	return &MysqlUploadRepository{db: db}
+}
+
+// toDomainUpload converts a GORM upload to a domain upload
+func toDomainUpload(u *Upload) *model.Upload {
+	return &model.Upload{
+		ID:           u.ID,
+		UserID:       u.UserID,
+		Filename:     u.Filename,
+		FolderID:     u.FolderID,
+		DeclaredType: u.DeclaredType,
+		Digest:       u.Digest,
+		Length:       u.Length,
+		Offset:       u.Offset,
+		CreatedAt:    u.CreatedAt,
+		ExpiresAt:    u.ExpiresAt,
+	}
+}
+
+// fromDomainUpload converts a domain upload to a GORM upload
+func fromDomainUpload(u *model.Upload) *Upload {
+	return &Upload{
+		ID:           u.ID,
+		UserID:       u.UserID,
+		Filename:     u.Filename,
+		FolderID:     u.FolderID,
+		DeclaredType: u.DeclaredType,
+		Digest:       u.Digest,
+		Length:       u.Length,
+		Offset:       u.Offset,
+		CreatedAt:    u.CreatedAt,
+		ExpiresAt:    u.ExpiresAt,
+	}
+}
+
+func (r *MysqlUploadRepository) Create(upload *model.Upload) (string, error) {
+	upload.ID = uuid.NewString()
+	if err := r.db.Create(fromDomainUpload(upload)).Error; err != nil {
+		return "", err
+	}
+	return upload.ID, nil
+}
+
+func (r *MysqlUploadRepository) Get(userID, uploadID string) (*model.Upload, error) {
+	var upload Upload
+	result := r.db.First(&upload, "user_id = ? AND id = ?", userID, uploadID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUploadNotFound
+		}
+		return nil, result.Error
+	}
+	return toDomainUpload(&upload), nil
+}
+
+func (r *MysqlUploadRepository) Update(upload *model.Upload) error {
+	return r.db.Save(fromDomainUpload(upload)).Error
+}
+
+func (r *MysqlUploadRepository) Delete(uploadID string) error {
+	return r.db.Delete(&Upload{}, "id = ?", uploadID).Error
+}
+
+func (r *MysqlUploadRepository) List(userID string) ([]*model.Upload, error) {
+	var uploads []Upload
+	if err := r.db.Where("user_id = ?", userID).Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+	list := make([]*model.Upload, 0, len(uploads))
+	for i := range uploads {
+		list = append(list, toDomainUpload(&uploads[i]))
+	}
+	return list, nil
+}
+
+func (r *MysqlUploadRepository) ListExpired(now time.Time) ([]*model.Upload, error) {
+	var uploads []Upload
+	if err := r.db.Where("expires_at <= ?", now).Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+	expired := make([]*model.Upload, 0, len(uploads))
+	for i := range uploads {
+		expired = append(expired, toDomainUpload(&uploads[i]))
+	}
+	return expired, nil
+}
@@ -2,6 +2,9 @@ package mysql
 
 import (
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/bizio/abc-user-service/internal/domain"
 	"github.com/bizio/abc-user-service/internal/domain/model"
@@ -12,21 +15,80 @@ import (
 // User is the GORM model for a user
 type User struct {
 	gorm.Model
-	ID    string `gorm:"primaryKey"`
-	Name  string
-	Email string `gorm:"uniqueIndex,size:255"`
-	DOB   string
-	Files []*File `gorm:"foreignKey:UserID"`
+	ID            string `gorm:"primaryKey"`
+	Name          string
+	Email         string `gorm:"uniqueIndex;size:255"`
+	EmailVerified bool
+	DOB           string
+	Status        string `gorm:"size:32;default:active"` // users created before statuses existed are active
+	StatusReason  string
+	Attributes    JSONMap         `gorm:"type:json"`
+	AvatarID      string          `gorm:"size:36"`
+	AvatarType    string          `gorm:"size:32"`
+	Groups        []*Group        `gorm:"many2many:group_members"`
+	Contacts      []*ContactPoint `gorm:"foreignKey:UserID"`
+	Addresses     []*Address      `gorm:"foreignKey:UserID"`
+	Files         []*File         `gorm:"foreignKey:UserID"`
 }
 
-// File is the GORM model for a file
+// ContactPoint is the GORM model for a secondary email address or a phone number
+type ContactPoint struct {
+	ID       string `gorm:"primaryKey"`
+	UserID   string `gorm:"size:255;uniqueIndex:idx_contact_point"`
+	Type     string `gorm:"size:16;uniqueIndex:idx_contact_point"`
+	Value    string `gorm:"size:255;uniqueIndex:idx_contact_point"`
+	Label    string
+	Primary  bool `gorm:"column:is_primary"` // PRIMARY is a reserved word
+	Verified bool
+	// VerifiedEmail is the value of a verified email address, NULL otherwise, so an address is verified by a
+	// single user even if two of them verify it at once
+	VerifiedEmail *string `gorm:"->;type:varchar(255) GENERATED ALWAYS AS (IF(verified AND type = 'email', value, NULL)) STORED;uniqueIndex"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Address is the GORM model for a postal address
+type Address struct {
+	ID         string `gorm:"primaryKey"`
+	UserID     string `gorm:"index;size:255"`
+	Type       string `gorm:"size:16"`
+	Lines      string `gorm:"type:text"` // one line per row, lines can't contain line breaks
+	Locality   string
+	Region     string
+	PostalCode string `gorm:"size:16"`
+	Country    string `gorm:"size:2;index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// File is the GORM model for a file. The names of the files in the same folder are unique, the deleted files are
+// removed so that their names can be reused.
 type File struct {
 	gorm.Model
-	ID     string `gorm:"primaryKey"`
-	UserID string `gorm:"size:255"`
-	Name   string
-	Path   string
-	Size   int64
+	ID           string `gorm:"primaryKey"`
+	UserID       string `gorm:"size:255;index:idx_file_digest;uniqueIndex:idx_file_name,priority:1"`
+	Name         string `gorm:"size:255;uniqueIndex:idx_file_name,priority:3"`
+	StorageKey   string `gorm:"size:255"`
+	Path         string
+	Size         int64
+	ContentType  string `gorm:"size:255"`
+	DeclaredType string `gorm:"size:255"`
+	Digest       string `gorm:"size:64;index:idx_file_digest"`
+	Corrupted    bool
+	ScanStatus   string   `gorm:"size:16;index"`
+	Version      int      `gorm:"default:1"`
+	Description  string   `gorm:"type:text"`
+	Tags         JSONList `gorm:"type:json"`
+	Labels       JSONMap  `gorm:"type:json"`
+	FolderID     string   `gorm:"size:36;index;uniqueIndex:idx_file_name,priority:2"`
+}
+
+// Erasure is the GORM model for the record of a user's erasure
+type Erasure struct {
+	ID          string `gorm:"primaryKey"`
+	UserID      string `gorm:"index;size:255"`
+	FilesErased int
+	ErasedAt    time.Time
 }
 
 // MysqlUserRepository is the GORM implementation of the user repository
@@ -36,7 +98,7 @@ type MysqlUserRepository struct {
 
 // NewMysqlUserRepository creates a new repository instance, runs migrations
 func NewMysqlUserRepository(db *gorm.DB) *MysqlUserRepository {
-	if err := db.AutoMigrate(&User{}, &File{}); err != nil {
+	if err := db.AutoMigrate(&User{}, &ContactPoint{}, &Address{}, &File{}, &FileVersion{}, &Folder{}, &Erasure{}, &StorageUsage{}); err != nil {
 		panic(err)
 	}
 	return &MysqlUserRepository{db: db}
@@ -46,6 +108,46 @@ func NewMysqlUserRepository(db *gorm.DB) *MysqlUserRepository {
 func toDomainUser(u *User) *model.User {
 	domainUser, _ := model.NewUser(u.Name, u.Email, u.DOB)
 	domainUser.ID = u.ID
+	if u.EmailVerified {
+		domainUser.VerifyEmail()
+	}
+	domainUser.RestoreStatus(model.UserStatus(u.Status), u.StatusReason)
+	var roles []string
+	for _, g := range u.Groups {
+		for _, r := range g.Roles {
+			roles = append(roles, r.Name)
+		}
+	}
+	domainUser.SetRoles(roles)
+	_ = domainUser.SetAttributes(u.Attributes) // names were validated when stored
+	contacts := make([]*model.ContactPoint, 0, len(u.Contacts))
+	for _, c := range u.Contacts {
+		contacts = append(contacts, &model.ContactPoint{
+			ID:       c.ID,
+			Type:     model.ContactType(c.Type),
+			Value:    c.Value,
+			Label:    c.Label,
+			Primary:  c.Primary,
+			Verified: c.Verified,
+		})
+	}
+	domainUser.SetContactPoints(contacts)
+	addresses := make([]*model.Address, 0, len(u.Addresses))
+	for _, a := range u.Addresses {
+		addresses = append(addresses, &model.Address{
+			ID:         a.ID,
+			Type:       model.AddressType(a.Type),
+			Lines:      strings.Split(a.Lines, "\n"),
+			Locality:   a.Locality,
+			Region:     a.Region,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+		})
+	}
+	domainUser.SetAddresses(addresses)
+	if u.AvatarID != "" {
+		domainUser.SetAvatar(&model.Avatar{ID: u.AvatarID, ContentType: u.AvatarType})
+	}
 	for _, f := range u.Files {
 		domainUser.AddFile(toDomainFile(f))
 	}
@@ -58,44 +160,121 @@ func fromDomainUser(u *model.User) *User {
 	for _, f := range u.GetFiles() {
 		files = append(files, fromDomainFile(f))
 	}
-	return &User{
-		ID:    u.ID,
-		Name:  u.ToDTO().Name, // DTO contains the private fields
-		Email: u.ToDTO().Email,
-		DOB:   u.ToDTO().DOB,
-		Files: files,
+	contacts := make([]*ContactPoint, 0, len(u.GetContactPoints()))
+	for _, c := range u.GetContactPoints() {
+		contacts = append(contacts, &ContactPoint{
+			ID:       c.ID,
+			UserID:   u.ID,
+			Type:     string(c.Type),
+			Value:    c.Value,
+			Label:    c.Label,
+			Primary:  c.Primary,
+			Verified: c.Verified,
+		})
+	}
+	addresses := make([]*Address, 0, len(u.GetAddresses()))
+	for _, a := range u.GetAddresses() {
+		addresses = append(addresses, &Address{
+			ID:         a.ID,
+			UserID:     u.ID,
+			Type:       string(a.Type),
+			Lines:      strings.Join(a.Lines, "\n"),
+			Locality:   a.Locality,
+			Region:     a.Region,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+		})
 	}
+	persistenceUser := &User{
+		ID:            u.ID,
+		Name:          u.ToDTO().Name, // DTO contains the private fields
+		Email:         u.ToDTO().Email,
+		EmailVerified: u.IsEmailVerified(),
+		DOB:           u.ToDTO().DOB,
+		Status:        u.ToDTO().Status,
+		StatusReason:  u.ToDTO().StatusReason,
+		Attributes:    u.GetAttributes(),
+		Contacts:      contacts,
+		Addresses:     addresses,
+		Files:         files,
+	}
+	if avatar, err := u.GetAvatar(); err == nil {
+		persistenceUser.AvatarID = avatar.ID
+		persistenceUser.AvatarType = avatar.ContentType
+	}
+	return persistenceUser
 }
 
 // toDomainFile converts a GORM file to a domain file
 func toDomainFile(f *File) *model.File {
 	return &model.File{
-		ID:     f.ID,
-		UserID: f.UserID,
-		Name:   f.Name,
-		Path:   f.Path,
-		Size:   f.Size,
+		ID:           f.ID,
+		UserID:       f.UserID,
+		Name:         f.Name,
+		StorageKey:   f.StorageKey,
+		Path:         f.Path,
+		Size:         f.Size,
+		ContentType:  f.ContentType,
+		DeclaredType: f.DeclaredType,
+		Digest:       f.Digest,
+		Corrupted:    f.Corrupted,
+		ScanStatus:   f.ScanStatus,
+		Version:      f.Version,
+		Description:  f.Description,
+		Tags:         f.Tags,
+		Labels:       toDomainLabels(f.Labels),
+		FolderID:     f.FolderID,
 	}
 }
 
 // fromDomainFile converts a domain file to a GORM file
 func fromDomainFile(f *model.File) *File {
 	return &File{
-		ID:     f.ID,
-		UserID: f.UserID,
-		Name:   f.Name,
-		Path:   f.Path,
-		Size:   f.Size,
+		ID:           f.ID,
+		UserID:       f.UserID,
+		Name:         f.Name,
+		StorageKey:   f.StorageKey,
+		Path:         f.Path,
+		Size:         f.Size,
+		ContentType:  f.ContentType,
+		DeclaredType: f.DeclaredType,
+		Digest:       f.Digest,
+		Corrupted:    f.Corrupted,
+		ScanStatus:   f.ScanStatus,
+		Version:      f.Version,
+		Description:  f.Description,
+		Tags:         f.Tags,
+		Labels:       fromDomainLabels(f.Labels),
+		FolderID:     f.FolderID,
 	}
 }
 
+// toDomainLabels converts the labels stored as a JSON object, their values are strings
+func toDomainLabels(labels JSONMap) map[string]string {
+	domainLabels := make(map[string]string, len(labels))
+	for name, value := range labels {
+		if s, ok := value.(string); ok {
+			domainLabels[name] = s
+		}
+	}
+	return domainLabels
+}
+
+func fromDomainLabels(labels map[string]string) JSONMap {
+	persistenceLabels := make(JSONMap, len(labels))
+	for name, value := range labels {
+		persistenceLabels[name] = value
+	}
+	return persistenceLabels
+}
+
 func (r *MysqlUserRepository) Create(user *model.User) (string, error) {
 	user.ID = uuid.NewString()
 	persistenceUser := fromDomainUser(user)
 
 	result := r.db.Create(persistenceUser)
 	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		if isDuplicateKey(result.Error) {
 			return "", domain.ErrUserAlreadyExists
 		}
 		return "", result.Error
@@ -105,7 +284,7 @@ func (r *MysqlUserRepository) Create(user *model.User) (string, error) {
 
 func (r *MysqlUserRepository) Get(id string) (*model.User, error) {
 	var user User
-	result := r.db.Preload("Files").First(&user, "id = ?", id)
+	result := r.db.Preload("Files").Preload("Groups.Roles").Preload("Contacts").Preload("Addresses").First(&user, "id = ?", id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
@@ -117,7 +296,11 @@ func (r *MysqlUserRepository) Get(id string) (*model.User, error) {
 
 func (r *MysqlUserRepository) GetByEmail(email string) (*model.User, error) {
 	var user User
-	result := r.db.Preload("Files").First(&user, "email = ?", email)
+	// verified secondary addresses identify the user as well as the primary one
+	verifiedContacts := r.db.Model(&ContactPoint{}).Select("user_id").
+		Where("type = ? AND value = ? AND verified = ?", string(model.ContactEmail), email, true)
+	result := r.db.Preload("Files").Preload("Groups.Roles").Preload("Contacts").Preload("Addresses").
+		Where("email = ?", email).Or("id IN (?)", verifiedContacts).First(&user)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
@@ -127,9 +310,23 @@ func (r *MysqlUserRepository) GetByEmail(email string) (*model.User, error) {
 	return toDomainUser(&user), nil
 }
 
-func (r *MysqlUserRepository) List() ([]*model.User, error) {
+func (r *MysqlUserRepository) List(filter *domain.UserFilter) ([]*model.User, error) {
+	query := r.db.Preload("Files").Preload("Groups.Roles").Preload("Contacts").Preload("Addresses")
+	if filter != nil && filter.EmailVerified != nil {
+		query = query.Where("email_verified = ?", *filter.EmailVerified)
+	}
+	if filter != nil && filter.GroupID != "" {
+		query = query.Where("id IN (?)", r.db.Table("group_members").Select("user_id").Where("group_id = ?", filter.GroupID))
+	}
+	if filter != nil {
+		// attribute names are restricted to JSON path members by the domain
+		for name, value := range filter.Attributes {
+			query = query.Where("JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ?", "$."+name, value)
+		}
+	}
+
 	var users []User
-	result := r.db.Preload("Files").Find(&users)
+	result := query.Find(&users)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -155,10 +352,80 @@ func (r *MysqlUserRepository) Update(id string, user *model.User) error {
 
 	existingUser.Name = updatedPersistenceUser.Name
 	existingUser.Email = updatedPersistenceUser.Email
+	existingUser.EmailVerified = updatedPersistenceUser.EmailVerified
 	existingUser.DOB = updatedPersistenceUser.DOB
-	existingUser.Files = updatedPersistenceUser.Files
+	existingUser.Status = updatedPersistenceUser.Status
+	existingUser.StatusReason = updatedPersistenceUser.StatusReason
+	existingUser.Attributes = updatedPersistenceUser.Attributes
+	existingUser.Addresses = updatedPersistenceUser.Addresses
+	existingUser.AvatarID = updatedPersistenceUser.AvatarID
+	existingUser.AvatarType = updatedPersistenceUser.AvatarType
+	// the files are saved by AddFile and UpdateFile: upserting them here would bring back the files deleted since
+	// the user was read, or clash with the names of the files added since
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// contact points and addresses removed from the user are deleted, the addresses are upserted by Save
+		contactIDs := make([]string, 0, len(updatedPersistenceUser.Contacts))
+		for _, c := range updatedPersistenceUser.Contacts {
+			contactIDs = append(contactIDs, c.ID)
+		}
+		if err := deleteRemoved(tx, &ContactPoint{}, id, contactIDs); err != nil {
+			return err
+		}
+		addressIDs := make([]string, 0, len(existingUser.Addresses))
+		for _, a := range existingUser.Addresses {
+			addressIDs = append(addressIDs, a.ID)
+		}
+		if err := deleteRemoved(tx, &Address{}, id, addressIDs); err != nil {
+			return err
+		}
+
+		if err := saveContactPoints(tx, id, updatedPersistenceUser.Contacts); err != nil {
+			if isDuplicateKey(err) {
+				return model.ErrContactPointAlreadyExists
+			}
+			return err
+		}
+
+		// the email of the user is another user's, e.g. claimed by a concurrent request
+		err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(&existingUser).Error
+		if isDuplicateKey(err) {
+			return model.ErrContactPointAlreadyExists
+		}
+		return err
+	})
+}
+
+// saveContactPoints updates the contact points of the user and inserts the new ones. They aren't upserted: an
+// upsert would take over the row of another user that has the same verified email, rather than fail.
+func saveContactPoints(tx *gorm.DB, userID string, contacts []*ContactPoint) error {
+	var existingIDs []string
+	if err := tx.Model(&ContactPoint{}).Where("user_id = ?", userID).Pluck("id", &existingIDs).Error; err != nil {
+		return err
+	}
+	for _, c := range contacts {
+		if !slices.Contains(existingIDs, c.ID) {
+			if err := tx.Create(c).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		err := tx.Model(&ContactPoint{}).Where("id = ? AND user_id = ?", c.ID, userID).
+			Select("Type", "Value", "Label", "Primary", "Verified").Updates(c).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	return r.db.Session(&gorm.Session{FullSaveAssociations: true}).Save(&existingUser).Error
+// deleteRemoved deletes the rows of a user that are not in the kept IDs
+func deleteRemoved(tx *gorm.DB, value any, userID string, keptIDs []string) error {
+	query := tx.Where("user_id = ?", userID)
+	if len(keptIDs) > 0 {
+		query = query.Where("id NOT IN ?", keptIDs)
+	}
+	return query.Delete(value).Error
 }
 
 func (r *MysqlUserRepository) Delete(id string) error {
@@ -166,6 +433,110 @@ func (r *MysqlUserRepository) Delete(id string) error {
 	return r.db.Select("Files").Delete(&User{ID: id}).Error
 }
 
+func (r *MysqlUserRepository) GetIncludingDeleted(id string) (*model.User, error) {
+	var user User
+	result := r.db.Unscoped().
+		Preload("Files", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Groups.Roles").Preload("Contacts").Preload("Addresses").
+		First(&user, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, result.Error
+	}
+	return toDomainUser(&user), nil
+}
+
+func (r *MysqlUserRepository) ListDeletedIDs() ([]string, error) {
+	var ids []string
+	result := r.db.Unscoped().Model(&User{}).Where("deleted_at IS NOT NULL").Pluck("id", &ids)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return ids, nil
+}
+
+func (r *MysqlUserRepository) Erase(user *model.User, erasure *model.Erasure) error {
+	erased := fromDomainUser(user)
+	erasure.ID = uuid.NewString()
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// overwrite personal data, keeping the row soft-deleted
+		err := tx.Unscoped().Model(&User{}).Where("id = ?", user.ID).Updates(map[string]any{
+			"name":           erased.Name,
+			"email":          erased.Email,
+			"email_verified": false,
+			"dob":            erased.DOB,
+			"status":         erased.Status,
+			"status_reason":  erased.StatusReason,
+			"attributes":     erased.Attributes,
+			"avatar_id":      "",
+			"avatar_type":    "",
+			"deleted_at":     gorm.Expr("COALESCE(deleted_at, ?)", erasure.ErasedAt),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("user_id = ?", user.ID).Delete(&File{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("user_id = ?", user.ID).Delete(&FileVersion{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("user_id = ?", user.ID).Delete(&Folder{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = resetStorageUsage(tx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ?", user.ID).Delete(&ContactPoint{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ?", user.ID).Delete(&Address{}).Error
+		if err != nil {
+			return err
+		}
+
+		// the exports, uploads and verification tokens hold personal data too, their files are gone already
+		err = tx.Where("user_id = ?", user.ID).Delete(&Export{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ?", user.ID).Delete(&Upload{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ?", user.ID).Delete(&VerificationToken{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&User{ID: user.ID}).Association("Groups").Clear()
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&Erasure{
+			ID:          erasure.ID,
+			UserID:      erasure.UserID,
+			FilesErased: erasure.FilesErased,
+			ErasedAt:    erasure.ErasedAt,
+		}).Error
+	})
+}
+
 func (r *MysqlUserRepository) GetFiles(userID string) ([]*model.File, error) {
 	var files []File
 	result := r.db.Where("user_id = ?", userID).Find(&files)
@@ -184,13 +555,52 @@ func (r *MysqlUserRepository) GetFile(userID, fileID string) (*model.File, error
 	result := r.db.First(&file, "user_id = ? AND id = ?", userID, fileID)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, domain.ErrUserNotFound // Or a more specific file not found error
+			return nil, model.ErrFileNotFound
 		}
 		return nil, result.Error
 	}
 	return toDomainFile(&file), nil
 }
 
+func (r *MysqlUserRepository) UpdateFile(file *model.File) error {
+	// the size is accounted for in the storage usage, it can't change
+	result := r.db.Model(&File{}).Where("user_id = ? AND id = ?", file.UserID, file.ID).
+		Select("Name", "StorageKey", "Path", "ContentType", "DeclaredType", "Digest", "Corrupted", "ScanStatus",
+			"Description", "Tags", "Labels", "FolderID").
+		Updates(fromDomainFile(file))
+	if result.Error != nil {
+		if isDuplicateKey(result.Error) {
+			return model.ErrFileNameTaken
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrFileNotFound
+	}
+	return nil
+}
+
+func (r *MysqlUserRepository) ListFilesPendingScan(limit int) ([]*model.File, error) {
+	var files []File
+	result := r.db.Where("scan_status = ?", model.ScanPending).Order("created_at").Limit(limit).Find(&files)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var domainFiles []*model.File
+	for _, f := range files {
+		domainFiles = append(domainFiles, toDomainFile(&f))
+	}
+	return domainFiles, nil
+}
+
 func (r *MysqlUserRepository) DeleteFiles(userID string) error {
-	return r.db.Where("user_id = ?", userID).Delete(&File{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&File{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&FileVersion{}).Error; err != nil {
+			return err
+		}
+		return resetStorageUsage(tx, userID)
+	})
 }
@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerificationToken is the GORM model for an email verification token
+type VerificationToken struct {
+	ID        string `gorm:"primaryKey"`
+	UserID    string `gorm:"index;size:255"`
+	Email     string `gorm:"size:255"`
+	Hash      string `gorm:"uniqueIndex;size:64"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// MysqlVerificationTokenRepository is the GORM implementation of the verification token repository
+type MysqlVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewMysqlVerificationTokenRepository creates a new repository instance, runs migrations
+func NewMysqlVerificationTokenRepository(db *gorm.DB) *MysqlVerificationTokenRepository {
+	if err := db.AutoMigrate(&VerificationToken{}); err != nil {
+		panic(err)
+	}
+	return &MysqlVerificationTokenRepository{db: db}
+}
+
+func (r *MysqlVerificationTokenRepository) Create(token *model.VerificationToken) error {
+	token.ID = uuid.NewString()
+	return r.db.Create(&VerificationToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Email:     token.Email,
+		Hash:      token.Hash,
+		ExpiresAt: token.ExpiresAt,
+	}).Error
+}
+
+func (r *MysqlVerificationTokenRepository) GetByHash(hash string) (*model.VerificationToken, error) {
+	var token VerificationToken
+	result := r.db.First(&token, "hash = ?", hash)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, model.ErrInvalidVerificationToken
+		}
+		return nil, result.Error
+	}
+	return &model.VerificationToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		Email:     token.Email,
+		Hash:      token.Hash,
+		ExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+func (r *MysqlVerificationTokenRepository) DeleteByUser(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&VerificationToken{}).Error
+}
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// DefaultAttributeSchema accepts any attributes, it is used when no schema is configured
+const DefaultAttributeSchema = `{"type": "object"}`
+
+const schemaURL = "attributes.schema.json"
+
+// JSONSchema validates user attributes against a JSON Schema document
+type JSONSchema struct {
+	schema *jsonschema.Schema
+	digest string
+}
+
+// NewJSONSchema compiles a JSON Schema document, remote references are not resolved
+func NewJSONSchema(document []byte) (*JSONSchema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("invalid attribute schema: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(nil)
+	if err := compiler.AddResource(schemaURL, doc); err != nil {
+		return nil, fmt.Errorf("invalid attribute schema: %w", err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attribute schema: %w", err)
+	}
+
+	// whitespace doesn't change the schema
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, document); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(compact.Bytes())
+
+	return &JSONSchema{schema: compiled, digest: hex.EncodeToString(sum[:])}, nil
+}
+
+// LoadJSONSchema reads the schema from a file, an empty path loads DefaultAttributeSchema
+func LoadJSONSchema(path string) (*JSONSchema, error) {
+	if path == "" {
+		return NewJSONSchema([]byte(DefaultAttributeSchema))
+	}
+	document, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONSchema(document)
+}
+
+func (s *JSONSchema) Validate(attributes map[string]any) error {
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+
+	err := s.schema.Validate(attributes)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidAttributes, describe(validationErr))
+	}
+	return err
+}
+
+func (s *JSONSchema) Digest() string {
+	return s.digest
+}
+
+// describe flattens a validation error to one line per violated attribute
+func describe(err *jsonschema.ValidationError) string {
+	var violations []string
+	for _, unit := range err.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		violations = append(violations, fmt.Sprintf("%s: %s", location, unit.Error))
+	}
+	return strings.Join(violations, "; ")
+}
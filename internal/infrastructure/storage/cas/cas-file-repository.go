@@ -0,0 +1,246 @@
+package cas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime/multipart"
+	"os"
+	"path"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+)
+
+const (
+	PathTemplate = "/user/%s/files/"
+	// blobOwner is the user the blobs are stored for in the underlying storage
+	blobOwner = "_blobs"
+)
+
+// CASFileRepository stores the content of files under its SHA-256, once however many files of any user have it.
+// Blobs are reference-counted and deleted with the last file pointing to them.
+type CASFileRepository struct {
+	blobs   domain.FileRepository
+	index   domain.BlobRepository
+	tempDir string
+}
+
+// NewCASFileRepository stores the blobs in the blob storage, spooling content to the temporary directory while
+// it's hashed
+func NewCASFileRepository(blobs domain.FileRepository, index domain.BlobRepository, tempDir string) *CASFileRepository {
+	return &CASFileRepository{blobs: blobs, index: index, tempDir: tempDir}
+}
+
+func (s *CASFileRepository) Upload(userID, filename string, fileHeader *multipart.FileHeader) (string, string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("error opening file: %s", err)
+		return "", "", err
+	}
+	defer file.Close()
+
+	return s.save(userID, filename, file)
+}
+
+func (s *CASFileRepository) Save(userID, filename string, content io.Reader) (string, error) {
+	filePath, _, err := s.save(userID, filename, content)
+	return filePath, err
+}
+
+// save hashes the content and stores it unless a blob with the same digest exists already
+func (s *CASFileRepository) save(userID, filename string, content io.Reader) (string, string, error) {
+	filePath, err := s.generatePath(userID, filename)
+	if err != nil {
+		return "", "", err
+	}
+
+	spool, err := os.CreateTemp(s.tempDir, "blob-*")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(spool, io.TeeReader(content, hash))
+	if err != nil {
+		log.Printf("error spooling file: %s", err)
+		return "", "", err
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	stored, previous, err := s.index.Reference(userID, filename, digest, size)
+	if err != nil {
+		return "", "", err
+	}
+	// the file had other content before
+	if previous != "" {
+		s.collect(previous)
+	}
+	if !stored {
+		if err := s.store(digest, spool); err != nil {
+			log.Printf("error storing blob %s: %s", digest, err)
+			if _, err := s.index.Dereference(userID, filename); err == nil {
+				s.collect(digest)
+			}
+			return "", "", err
+		}
+	}
+
+	return filePath, digest, nil
+}
+
+func (s *CASFileRepository) store(digest string, spool *os.File) error {
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := s.blobs.Save(blobOwner, digest, spool); err != nil {
+		return err
+	}
+	return s.index.MarkStored(digest)
+}
+
+func (s *CASFileRepository) Get(userID, filename string) (io.ReadCloser, error) {
+	filePath, err := s.generatePath(userID, filename)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := s.index.GetReference(userID, filename)
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobReferenceNotFound) {
+			return nil, fmt.Errorf("%w: %s", fs.ErrNotExist, filePath)
+		}
+		return nil, err
+	}
+	return s.blobs.Get(blobOwner, digest)
+}
+
+func (s *CASFileRepository) List(userID string) ([]string, error) {
+	if !domain.IsPathElement(userID) {
+		return nil, fmt.Errorf("%w: user ID %q", domain.ErrUnsafePath, userID)
+	}
+	filenames, err := s.index.ListReferences(userID)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(filenames))
+	for _, filename := range filenames {
+		filePath, err := s.generatePath(userID, filename)
+		if err != nil {
+			log.Printf("error listing file %q of user %s: %s", filename, userID, err)
+			continue
+		}
+		files = append(files, filePath)
+	}
+	return files, nil
+}
+
+// ModTime returns when the file was last pointed to its blob
+func (s *CASFileRepository) ModTime(userID, filename string) (time.Time, error) {
+	filePath, err := s.generatePath(userID, filename)
+	if err != nil {
+		return time.Time{}, err
+	}
+	modified, err := s.index.ReferencedAt(userID, filename)
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobReferenceNotFound) {
+			return time.Time{}, fmt.Errorf("%w: %s", fs.ErrNotExist, filePath)
+		}
+		return time.Time{}, err
+	}
+	return modified, nil
+}
+
+func (s *CASFileRepository) Delete(userID, filename string) error {
+	if _, err := s.generatePath(userID, filename); err != nil {
+		return err
+	}
+	digest, err := s.index.Dereference(userID, filename)
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobReferenceNotFound) {
+			return nil
+		}
+		return err
+	}
+	s.collect(digest)
+	return nil
+}
+
+func (s *CASFileRepository) DeleteFiles(userID string) error {
+	if !domain.IsPathElement(userID) {
+		return fmt.Errorf("%w: user ID %q", domain.ErrUnsafePath, userID)
+	}
+	digests, err := s.index.DereferenceAll(userID)
+	if err != nil {
+		return err
+	}
+	for _, digest := range digests {
+		s.collect(digest)
+	}
+	return nil
+}
+
+// CollectGarbage deletes the blobs no file points to and the stored content no blob is known for, e.g. left
+// behind by a crash. It returns the number of blobs deleted.
+func (s *CASFileRepository) CollectGarbage() (int, error) {
+	digests, err := s.index.ListUnreferenced()
+	if err != nil {
+		return 0, err
+	}
+	stored, err := s.blobs.List(blobOwner)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+	for _, location := range stored {
+		digests = append(digests, path.Base(location))
+	}
+
+	collected := 0
+	seen := map[string]bool{}
+	for _, digest := range digests {
+		if seen[digest] {
+			continue
+		}
+		seen[digest] = true
+
+		ok, err := s.index.Collect(digest, s.removeBlob(digest))
+		if err != nil {
+			return collected, err
+		}
+		if ok {
+			collected++
+		}
+	}
+	return collected, nil
+}
+
+// collect deletes the blob if no file points to it anymore. A blob that can't be deleted now is left for the
+// garbage collector.
+func (s *CASFileRepository) collect(digest string) {
+	if _, err := s.index.Collect(digest, s.removeBlob(digest)); err != nil {
+		log.Printf("error collecting blob %s: %s", digest, err)
+	}
+}
+
+func (s *CASFileRepository) removeBlob(digest string) func() error {
+	return func() error {
+		return s.blobs.Delete(blobOwner, digest)
+	}
+}
+
+// generatePath is the path reported for a file of the user. Both the user ID and the file name must be a single
+// path element, like in the other storages, so a reference can't be recorded under a path of another user.
+func (s *CASFileRepository) generatePath(userID, filename string) (string, error) {
+	if !domain.IsPathElement(userID) {
+		return "", fmt.Errorf("%w: user ID %q", domain.ErrUnsafePath, userID)
+	}
+	if !domain.IsPathElement(filename) {
+		return "", fmt.Errorf("%w: file name %q", domain.ErrUnsafePath, filename)
+	}
+	return fmt.Sprintf(PathTemplate, userID) + filename, nil
+}
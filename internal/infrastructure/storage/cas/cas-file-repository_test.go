@@ -0,0 +1,282 @@
+package cas
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/local"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBlobRepository keeps the references in memory, like the MySQL repository does in tables
+type memoryBlobRepository struct {
+	mu     sync.Mutex
+	refs   map[[2]string]string
+	times  map[[2]string]time.Time
+	counts map[string]int
+	stored map[string]bool
+}
+
+func newMemoryBlobRepository() *memoryBlobRepository {
+	return &memoryBlobRepository{refs: map[[2]string]string{}, times: map[[2]string]time.Time{},
+		counts: map[string]int{}, stored: map[string]bool{}}
+}
+
+func (r *memoryBlobRepository) Reference(userID, filename, digest string, size int64) (bool, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.counts[digest]; !ok {
+		r.counts[digest] = 0
+		r.stored[digest] = false
+	}
+	previous := r.refs[[2]string{userID, filename}]
+	if previous == digest {
+		return r.stored[digest], "", nil
+	}
+	r.counts[digest]++
+	if previous != "" {
+		r.counts[previous]--
+	}
+	r.refs[[2]string{userID, filename}] = digest
+	r.times[[2]string{userID, filename}] = time.Now()
+	return r.stored[digest], previous, nil
+}
+
+func (r *memoryBlobRepository) MarkStored(digest string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stored[digest] = true
+	return nil
+}
+
+func (r *memoryBlobRepository) Dereference(userID, filename string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	digest, ok := r.refs[[2]string{userID, filename}]
+	if !ok {
+		return "", domain.ErrBlobReferenceNotFound
+	}
+	delete(r.refs, [2]string{userID, filename})
+	r.counts[digest]--
+	return digest, nil
+}
+
+func (r *memoryBlobRepository) DereferenceAll(userID string) ([]string, error) {
+	var digests []string
+	for key := range r.refs {
+		if key[0] == userID {
+			digest, _ := r.Dereference(key[0], key[1])
+			digests = append(digests, digest)
+		}
+	}
+	return digests, nil
+}
+
+func (r *memoryBlobRepository) GetReference(userID, filename string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	digest, ok := r.refs[[2]string{userID, filename}]
+	if !ok {
+		return "", domain.ErrBlobReferenceNotFound
+	}
+	return digest, nil
+}
+
+func (r *memoryBlobRepository) ReferencedAt(userID, filename string) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.refs[[2]string{userID, filename}]; !ok {
+		return time.Time{}, domain.ErrBlobReferenceNotFound
+	}
+	return r.times[[2]string{userID, filename}], nil
+}
+
+func (r *memoryBlobRepository) ListReferences(userID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var filenames []string
+	for key := range r.refs {
+		if key[0] == userID {
+			filenames = append(filenames, key[1])
+		}
+	}
+	return filenames, nil
+}
+
+func (r *memoryBlobRepository) ListUnreferenced() ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var digests []string
+	for digest, count := range r.counts {
+		if count <= 0 {
+			digests = append(digests, digest)
+		}
+	}
+	return digests, nil
+}
+
+func (r *memoryBlobRepository) Collect(digest string, remove func() error) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.counts[digest] > 0 {
+		return false, nil
+	}
+	if err := remove(); err != nil {
+		return false, err
+	}
+	delete(r.counts, digest)
+	delete(r.stored, digest)
+	return true, nil
+}
+
+const orphanDigest = "4f5f8f7e2b7a3b7e0c2d0f7f8e0c8b8c0a5d7a2f7f8f4c8a2e7c6a6e0b5d0f3a"
+
+func newTestRepository(t *testing.T) (*CASFileRepository, *memoryBlobRepository, string) {
+	dir := t.TempDir()
+	index := newMemoryBlobRepository()
+	return NewCASFileRepository(local.NewLocalFileRepository(dir), index, t.TempDir()), index, dir
+}
+
+// storedBlobs lists the blobs in the underlying storage
+func storedBlobs(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(filepath.Join(dir, "user", blobOwner, "files"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func read(t *testing.T, repository *CASFileRepository, userID, filename string) string {
+	content, err := repository.Get(userID, filename)
+	require.NoError(t, err)
+	defer content.Close()
+	b, err := io.ReadAll(content)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestCASFileRepository(t *testing.T) {
+	t.Run("Identical Content Is Stored Once", func(t *testing.T) {
+		repository, index, dir := newTestRepository(t)
+
+		_, err := repository.Save("user-1", "contract.pdf", strings.NewReader("contract"))
+		require.NoError(t, err)
+		_, err = repository.Save("user-2", "signed.pdf", strings.NewReader("contract"))
+		require.NoError(t, err)
+
+		assert.Len(t, storedBlobs(t, dir), 1)
+		assert.Equal(t, "contract", read(t, repository, "user-2", "signed.pdf"))
+		digest, _ := index.GetReference("user-1", "contract.pdf")
+		assert.Equal(t, 2, index.counts[digest])
+	})
+
+	t.Run("Blob Is Deleted With The Last Reference", func(t *testing.T) {
+		repository, _, dir := newTestRepository(t)
+		repository.Save("user-1", "contract.pdf", strings.NewReader("contract"))
+		repository.Save("user-2", "contract.pdf", strings.NewReader("contract"))
+
+		require.NoError(t, repository.Delete("user-1", "contract.pdf"))
+		assert.Len(t, storedBlobs(t, dir), 1)
+		assert.Equal(t, "contract", read(t, repository, "user-2", "contract.pdf"))
+
+		require.NoError(t, repository.DeleteFiles("user-2"))
+		assert.Empty(t, storedBlobs(t, dir))
+		_, err := repository.Get("user-2", "contract.pdf")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("Overwritten Content Is Released", func(t *testing.T) {
+		repository, _, dir := newTestRepository(t)
+		repository.Save("user-1", "notes.txt", strings.NewReader("draft"))
+
+		_, err := repository.Save("user-1", "notes.txt", strings.NewReader("final"))
+
+		require.NoError(t, err)
+		assert.Len(t, storedBlobs(t, dir), 1)
+		assert.Equal(t, "final", read(t, repository, "user-1", "notes.txt"))
+	})
+
+	t.Run("Upload Returns The Digest", func(t *testing.T) {
+		repository, _, _ := newTestRepository(t)
+
+		_, digest, err := repository.save("user-1", "hello.txt", strings.NewReader("hello"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", digest)
+	})
+
+	t.Run("ModTime Is The Time Of The Reference", func(t *testing.T) {
+		repository, _, _ := newTestRepository(t)
+		repository.Save("user-1", "contract.pdf", strings.NewReader("contract"))
+
+		modified, err := repository.ModTime("user-1", "contract.pdf")
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), modified, time.Minute)
+		_, err = repository.ModTime("user-1", "missing.txt")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("Delete Missing File", func(t *testing.T) {
+		repository, _, _ := newTestRepository(t)
+
+		err := repository.Delete("user-1", "missing.txt")
+
+		assert.NoError(t, err)
+	})
+
+	for _, tt := range storagetest.UnsafePaths {
+		t.Run("Unsafe "+tt.Name, func(t *testing.T) {
+			repository, index, dir := newTestRepository(t)
+
+			_, err := repository.Save(tt.UserID, tt.Filename, strings.NewReader("x"))
+			assert.ErrorIs(t, err, domain.ErrUnsafePath)
+			_, err = repository.Get(tt.UserID, tt.Filename)
+			assert.ErrorIs(t, err, domain.ErrUnsafePath)
+			_, err = repository.ModTime(tt.UserID, tt.Filename)
+			assert.ErrorIs(t, err, domain.ErrUnsafePath)
+			assert.ErrorIs(t, repository.Delete(tt.UserID, tt.Filename), domain.ErrUnsafePath)
+			assert.Empty(t, index.refs)
+			assert.Empty(t, storedBlobs(t, dir))
+		})
+	}
+
+	t.Run("Unsafe User Files", func(t *testing.T) {
+		repository, _, _ := newTestRepository(t)
+
+		_, err := repository.List("..")
+		assert.ErrorIs(t, err, domain.ErrUnsafePath)
+		assert.ErrorIs(t, repository.DeleteFiles("../.."), domain.ErrUnsafePath)
+	})
+
+	t.Run("Garbage Collection", func(t *testing.T) {
+		repository, index, dir := newTestRepository(t)
+		repository.Save("user-1", "kept.txt", strings.NewReader("kept"))
+		// a blob whose content was stored but never referenced, and one no file points to anymore
+		blobs := local.NewLocalFileRepository(dir)
+		blobs.Save(blobOwner, orphanDigest, strings.NewReader("contract"))
+		index.counts["unreferenced"] = 0
+		blobs.Save(blobOwner, "unreferenced", strings.NewReader("unreferenced"))
+
+		collected, err := repository.CollectGarbage()
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, collected)
+		assert.Len(t, storedBlobs(t, dir), 1)
+		assert.Equal(t, "kept", read(t, repository, "user-1", "kept.txt"))
+	})
+}
@@ -0,0 +1,177 @@
+package encrypted
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime/multipart"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+)
+
+// rotationBatchSize is how many data keys are re-wrapped at a time
+const rotationBatchSize = 100
+
+// EncryptedFileRepository encrypts the files stored by another repository. Each file gets its own AES-256-GCM
+// data key, wrapped by a master key of the keyring and stored with the key ID in the data key repository.
+type EncryptedFileRepository struct {
+	files   domain.FileRepository
+	keys    domain.DataKeyRepository
+	keyring *Keyring
+}
+
+func NewEncryptedFileRepository(files domain.FileRepository, keys domain.DataKeyRepository, keyring *Keyring) *EncryptedFileRepository {
+	return &EncryptedFileRepository{files: files, keys: keys, keyring: keyring}
+}
+
+func (s *EncryptedFileRepository) Upload(userID, filename string, fileHeader *multipart.FileHeader) (string, string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("error opening file: %s", err)
+		return "", "", err
+	}
+	defer file.Close()
+
+	// the digest is of the plaintext, as it's served
+	hash := sha256.New()
+	filePath, err := s.Save(userID, filename, io.TeeReader(file, hash))
+	if err != nil {
+		return "", "", err
+	}
+	return filePath, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *EncryptedFileRepository) Save(userID, filename string, content io.Reader) (string, error) {
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	keyID, wrapped, err := s.keyring.Wrap(dataKey, associatedData(userID, filename))
+	if err != nil {
+		return "", err
+	}
+
+	// the key is stored first: content stored without its key could never be read, a key without content is
+	// only unused
+	previous, err := s.keys.Get(userID, filename)
+	if err != nil && !errors.Is(err, domain.ErrDataKeyNotFound) {
+		return "", err
+	}
+	err = s.keys.Save(&model.DataKey{UserID: userID, Filename: filename, KeyID: keyID, WrappedKey: wrapped})
+	if err != nil {
+		return "", err
+	}
+
+	filePath, err := s.files.Save(userID, filename, newEncryptReader(content, aead))
+	if err != nil {
+		s.restoreKey(userID, filename, previous)
+		return "", err
+	}
+	return filePath, nil
+}
+
+// restoreKey puts back the key the file had before a failed save, or deletes the key if it had none
+func (s *EncryptedFileRepository) restoreKey(userID, filename string, previous *model.DataKey) {
+	var err error
+	if previous != nil {
+		err = s.keys.Save(previous)
+	} else {
+		err = s.keys.Delete(userID, filename)
+	}
+	if err != nil {
+		log.Printf("error restoring data key of file %s of user %s: %s", filename, userID, err)
+	}
+}
+
+// Get decrypts the content of the file as it's read. Files stored before encryption was turned on have no data
+// key and are read as they are.
+func (s *EncryptedFileRepository) Get(userID, filename string) (io.ReadCloser, error) {
+	key, err := s.keys.Get(userID, filename)
+	if errors.Is(err, domain.ErrDataKeyNotFound) {
+		return s.files.Get(userID, filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := s.keyring.Unwrap(key.KeyID, key.WrappedKey, associatedData(userID, filename))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.files.Get(userID, filename)
+	if err != nil {
+		return nil, err
+	}
+	return newDecryptReader(content, aead), nil
+}
+
+func (s *EncryptedFileRepository) List(userID string) ([]string, error) {
+	return s.files.List(userID)
+}
+
+func (s *EncryptedFileRepository) ModTime(userID, filename string) (time.Time, error) {
+	return s.files.ModTime(userID, filename)
+}
+
+func (s *EncryptedFileRepository) Delete(userID, filename string) error {
+	// the key of content that's already gone is deleted all the same
+	if err := s.files.Delete(userID, filename); err != nil {
+		return err
+	}
+	return s.keys.Delete(userID, filename)
+}
+
+func (s *EncryptedFileRepository) DeleteFiles(userID string) error {
+	if err := s.files.DeleteFiles(userID); err != nil {
+		return err
+	}
+	return s.keys.DeleteAll(userID)
+}
+
+// RotateKeys re-wraps the data keys wrapped with other master keys with the current one. Content isn't
+// re-encrypted, the old master keys can be dropped once it's done. It returns the number of keys re-wrapped.
+func (s *EncryptedFileRepository) RotateKeys() (int, error) {
+	rotated := 0
+	current := s.keyring.CurrentKeyID()
+	for {
+		keys, err := s.keys.ListWrappedWithOtherKey(current, rotationBatchSize)
+		if err != nil || len(keys) == 0 {
+			return rotated, err
+		}
+
+		for _, key := range keys {
+			data := associatedData(key.UserID, key.Filename)
+			dataKey, err := s.keyring.Unwrap(key.KeyID, key.WrappedKey, data)
+			if err != nil {
+				// a key that can't be unwrapped would be listed again and again
+				return rotated, err
+			}
+			if key.KeyID, key.WrappedKey, err = s.keyring.Wrap(dataKey, data); err != nil {
+				return rotated, err
+			}
+			if err := s.keys.Save(key); err != nil {
+				return rotated, err
+			}
+			rotated++
+		}
+	}
+}
+
+// associatedData binds a wrapped data key to its file, so it can't be swapped with the key of another one
+func associatedData(userID, filename string) []byte {
+	return []byte(userID + "\x00" + filename)
+}
@@ -0,0 +1,254 @@
+package encrypted
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/local"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDataKeyRepository keeps the data keys in memory, like the MySQL repository does in a table
+type memoryDataKeyRepository map[[2]string]model.DataKey
+
+func (r memoryDataKeyRepository) Save(key *model.DataKey) error {
+	r[[2]string{key.UserID, key.Filename}] = *key
+	return nil
+}
+
+func (r memoryDataKeyRepository) Get(userID, filename string) (*model.DataKey, error) {
+	key, ok := r[[2]string{userID, filename}]
+	if !ok {
+		return nil, domain.ErrDataKeyNotFound
+	}
+	return &key, nil
+}
+
+func (r memoryDataKeyRepository) Delete(userID, filename string) error {
+	delete(r, [2]string{userID, filename})
+	return nil
+}
+
+func (r memoryDataKeyRepository) DeleteAll(userID string) error {
+	for key := range r {
+		if key[0] == userID {
+			delete(r, key)
+		}
+	}
+	return nil
+}
+
+func (r memoryDataKeyRepository) ListWrappedWithOtherKey(keyID string, limit int) ([]*model.DataKey, error) {
+	var keys []*model.DataKey
+	for _, key := range r {
+		if key.KeyID != keyID && len(keys) < limit {
+			keys = append(keys, &key)
+		}
+	}
+	return keys, nil
+}
+
+// failingDataKeyRepository fails to save data keys
+type failingDataKeyRepository struct {
+	memoryDataKeyRepository
+}
+
+func (r failingDataKeyRepository) Save(key *model.DataKey) error {
+	return errors.New("db down")
+}
+
+// failingReader returns the error once the content is read
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func newMasterKey(t *testing.T) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func randomContent(t *testing.T, size int) []byte {
+	content := make([]byte, size)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+	return content
+}
+
+func read(t *testing.T, repository *EncryptedFileRepository, userID, filename string) []byte {
+	content, err := repository.Get(userID, filename)
+	require.NoError(t, err)
+	defer content.Close()
+	b, err := io.ReadAll(content)
+	require.NoError(t, err)
+	return b
+}
+
+func TestStream(t *testing.T) {
+	aead, _ := newAEAD(make([]byte, 32))
+	encrypt := func(plaintext []byte) []byte {
+		ciphertext, err := io.ReadAll(newEncryptReader(bytes.NewReader(plaintext), aead))
+		require.NoError(t, err)
+		return ciphertext
+	}
+	decrypt := func(ciphertext []byte) ([]byte, error) {
+		return io.ReadAll(newDecryptReader(io.NopCloser(bytes.NewReader(ciphertext)), aead))
+	}
+
+	for _, size := range []int{0, 1, segmentSize - 1, segmentSize, segmentSize + 1, 3*segmentSize + 7} {
+		plaintext := randomContent(t, size)
+		ciphertext := encrypt(plaintext)
+		segments := max(1, (size+segmentSize-1)/segmentSize)
+		assert.Len(t, ciphertext, size+segments*aead.Overhead(), "size %d", size)
+
+		decrypted, err := decrypt(ciphertext)
+		require.NoError(t, err, "size %d", size)
+		assert.Equal(t, plaintext, decrypted, "size %d", size)
+	}
+
+	t.Run("Altered", func(t *testing.T) {
+		ciphertext := encrypt([]byte("identity document"))
+		ciphertext[3] ^= 1
+
+		_, err := decrypt(ciphertext)
+
+		assert.ErrorIs(t, err, ErrUnreadable)
+	})
+
+	t.Run("Truncated At A Segment Boundary", func(t *testing.T) {
+		ciphertext := encrypt(randomContent(t, 2*segmentSize))
+
+		_, err := decrypt(ciphertext[:segmentSize+aead.Overhead()])
+
+		assert.ErrorIs(t, err, ErrUnreadable)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := decrypt(nil)
+
+		assert.ErrorIs(t, err, ErrTruncated)
+	})
+}
+
+func TestEncryptedFileRepository(t *testing.T) {
+	dir := t.TempDir()
+	keys := memoryDataKeyRepository{}
+	oldKey, newKey := newMasterKey(t), newMasterKey(t)
+	keyring, err := NewKeyring(map[string]string{"2025-01": oldKey}, "")
+	require.NoError(t, err)
+	repository := NewEncryptedFileRepository(local.NewLocalFileRepository(dir), keys, keyring)
+	plaintext := randomContent(t, segmentSize+100)
+
+	t.Run("Content Is Encrypted At Rest", func(t *testing.T) {
+		_, err := repository.Save("user-1", "passport.pdf", bytes.NewReader(plaintext))
+		require.NoError(t, err)
+
+		stored, err := os.ReadFile(filepath.Join(dir, "user", "user-1", "files", "passport.pdf"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored), string(plaintext[:64]))
+		assert.Equal(t, "2025-01", keys[[2]string{"user-1", "passport.pdf"}].KeyID)
+		assert.Equal(t, plaintext, read(t, repository, "user-1", "passport.pdf"))
+	})
+
+	t.Run("Swapped Data Keys Are Refused", func(t *testing.T) {
+		_, err := repository.Save("user-2", "passport.pdf", bytes.NewReader(plaintext))
+		require.NoError(t, err)
+		keys[[2]string{"user-2", "passport.pdf"}] = keys[[2]string{"user-1", "passport.pdf"}]
+
+		_, err = repository.Get("user-2", "passport.pdf")
+
+		assert.ErrorIs(t, err, ErrUnreadableDataKey)
+		require.NoError(t, repository.DeleteFiles("user-2"))
+		assert.NotContains(t, keys, [2]string{"user-2", "passport.pdf"})
+	})
+
+	t.Run("Files Stored Before Encryption Are Read As They Are", func(t *testing.T) {
+		_, err := local.NewLocalFileRepository(dir).Save("user-1", "old.txt", bytes.NewReader([]byte("plain")))
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte("plain"), read(t, repository, "user-1", "old.txt"))
+	})
+
+	t.Run("Key Rotation", func(t *testing.T) {
+		rotating, err := NewKeyring(map[string]string{"2025-01": oldKey, "2025-07": newKey}, "2025-07")
+		require.NoError(t, err)
+		stored, _ := os.ReadFile(filepath.Join(dir, "user", "user-1", "files", "passport.pdf"))
+
+		rotated, err := NewEncryptedFileRepository(local.NewLocalFileRepository(dir), keys, rotating).RotateKeys()
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, rotated)
+		assert.Equal(t, "2025-07", keys[[2]string{"user-1", "passport.pdf"}].KeyID)
+		// content isn't re-encrypted and the old master key is no longer needed
+		restored, _ := os.ReadFile(filepath.Join(dir, "user", "user-1", "files", "passport.pdf"))
+		assert.Equal(t, stored, restored)
+		newOnly, err := NewKeyring(map[string]string{"2025-07": newKey}, "")
+		require.NoError(t, err)
+		repository := NewEncryptedFileRepository(local.NewLocalFileRepository(dir), keys, newOnly)
+		assert.Equal(t, plaintext, read(t, repository, "user-1", "passport.pdf"))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, repository.Delete("user-1", "passport.pdf"))
+
+		assert.Empty(t, keys)
+	})
+
+	t.Run("No Content Is Stored Without Its Key", func(t *testing.T) {
+		failing := NewEncryptedFileRepository(local.NewLocalFileRepository(dir), failingDataKeyRepository{keys}, keyring)
+
+		_, err := failing.Save("user-1", "contract.pdf", bytes.NewReader(plaintext))
+
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "user", "user-1", "files", "contract.pdf"))
+	})
+
+	t.Run("The Key Is Removed When The Content Fails", func(t *testing.T) {
+		_, err := repository.Save("user-1", "contract.pdf", failingReader{})
+
+		assert.Error(t, err)
+		assert.NotContains(t, keys, [2]string{"user-1", "contract.pdf"})
+	})
+
+	t.Run("The Previous Key Is Restored When The Content Fails", func(t *testing.T) {
+		_, err := repository.Save("user-1", "notes.txt", bytes.NewReader(plaintext))
+		require.NoError(t, err)
+		previous := keys[[2]string{"user-1", "notes.txt"}]
+
+		_, err = repository.Save("user-1", "notes.txt", failingReader{})
+
+		assert.Error(t, err)
+		assert.Equal(t, previous, keys[[2]string{"user-1", "notes.txt"}])
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	key := newMasterKey(t)
+
+	_, err := NewKeyring(nil, "")
+	assert.ErrorIs(t, err, ErrNoMasterKeys)
+	_, err = NewKeyring(map[string]string{"a": key, "b": key}, "")
+	assert.ErrorIs(t, err, ErrAmbiguousMasterKey)
+	_, err = NewKeyring(map[string]string{"a": key}, "b")
+	assert.ErrorIs(t, err, ErrUnknownMasterKey)
+	_, err = NewKeyring(map[string]string{"a": base64.StdEncoding.EncodeToString([]byte("short"))}, "")
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+
+	keyFile := filepath.Join(t.TempDir(), "keys")
+	os.WriteFile(keyFile, []byte("# master keys\n2025-01:"+key+"\n\n"), 0o600)
+	keys, err := ReadKeyFile(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"2025-01": key}, keys)
+}
@@ -0,0 +1,136 @@
+package encrypted
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoMasterKeys       = errors.New("no master keys: set them in the configuration or a key file")
+	ErrInvalidMasterKey   = errors.New("invalid master key: use an ID and a base64 256-bit key, as id:key")
+	ErrUnknownMasterKey   = errors.New("unknown master key")
+	ErrUnreadableDataKey  = errors.New("data key can't be unwrapped: it was altered or the master key is wrong")
+	ErrAmbiguousMasterKey = errors.New("the current master key must be set when there are several")
+)
+
+// Keyring holds the master keys that wrap the data keys of files. New data keys are wrapped with the current
+// one, the others are kept to unwrap the data keys wrapped before a rotation.
+type Keyring struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring of base64 256-bit keys by ID. The current key can be omitted if there's only one.
+func NewKeyring(keys map[string]string, current string) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoMasterKeys
+	}
+	if current == "" {
+		if len(keys) > 1 {
+			return nil, ErrAmbiguousMasterKey
+		}
+		for id := range keys {
+			current = id
+		}
+	}
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMasterKey, current)
+	}
+
+	keyring := &Keyring{current: current, keys: map[string]cipher.AEAD{}}
+	for id, encoded := range keys {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(key) != 32 || id == "" || len(id) > 64 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMasterKey, id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		keyring.keys[id] = aead
+	}
+	return keyring, nil
+}
+
+// ReadKeyFile reads master keys from a file with a key per line, as id:key. Blank lines and lines starting with #
+// are skipped.
+func ReadKeyFile(name string) (map[string]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, key, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, ErrInvalidMasterKey
+		}
+		keys[strings.TrimSpace(id)] = key
+	}
+	return keys, scanner.Err()
+}
+
+// CurrentKeyID is the ID of the master key new data keys are wrapped with
+func (k *Keyring) CurrentKeyID() string {
+	return k.current
+}
+
+// KeyIDs returns the IDs of all the master keys
+func (k *Keyring) KeyIDs() []string {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Wrap encrypts a data key with the current master key. The associated data binds the wrapped key to its file.
+func (k *Keyring) Wrap(dataKey, associatedData []byte) (string, []byte, error) {
+	aead := k.keys[k.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", nil, err
+	}
+	return k.current, aead.Seal(nonce, nonce, dataKey, associatedData), nil
+}
+
+// Unwrap decrypts a data key wrapped with the master key with the ID
+func (k *Keyring) Unwrap(keyID string, wrapped, associatedData []byte) ([]byte, error) {
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMasterKey, keyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrUnreadableDataKey
+	}
+	dataKey, err := aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], associatedData)
+	if err != nil {
+		return nil, ErrUnreadableDataKey
+	}
+	return dataKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,157 @@
+package encrypted
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// segmentSize is the plaintext of each sealed segment, content is encrypted and decrypted one segment at a time
+const segmentSize = 64 << 10
+
+var (
+	ErrTruncated  = errors.New("encrypted content is truncated")
+	ErrTooLarge   = errors.New("content is too large to encrypt with a single data key")
+	ErrUnreadable = errors.New("encrypted content can't be decrypted: it was altered or the key is wrong")
+)
+
+// segmentNonce makes each segment's nonce unique under the file's data key. The counter keeps segments from
+// being reordered and the flag of the last one from being truncated or extended.
+func segmentNonce(counter uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint32(nonce[7:11], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// encryptReader encrypts what it reads from the plaintext, so content can be streamed to storage
+type encryptReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	plain   []byte
+	sealed  []byte
+	pending []byte
+	counter uint32
+	done    bool
+}
+
+func newEncryptReader(plaintext io.Reader, aead cipher.AEAD) *encryptReader {
+	return &encryptReader{
+		src:    bufio.NewReaderSize(plaintext, segmentSize),
+		aead:   aead,
+		plain:  make([]byte, segmentSize),
+		sealed: make([]byte, 0, segmentSize+aead.Overhead()),
+	}
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.seal(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *encryptReader) seal() error {
+	n, err := io.ReadFull(r.src, r.plain)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	last := err != nil
+	if !last {
+		if _, err := r.src.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if r.counter == math.MaxUint32 && !last {
+		return ErrTooLarge
+	}
+
+	r.pending = r.aead.Seal(r.sealed[:0], segmentNonce(r.counter, last), r.plain[:n], nil)
+	r.counter++
+	r.done = last
+	return nil
+}
+
+// decryptReader decrypts stored content as it's read. Every segment is authenticated before any of it is
+// returned, content that was altered, reordered or truncated is an error.
+type decryptReader struct {
+	closer  io.Closer
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	sealed  []byte
+	plain   []byte
+	pending []byte
+	counter uint32
+	done    bool
+}
+
+func newDecryptReader(ciphertext io.ReadCloser, aead cipher.AEAD) *decryptReader {
+	size := segmentSize + aead.Overhead()
+	return &decryptReader{
+		closer: ciphertext,
+		src:    bufio.NewReaderSize(ciphertext, size),
+		aead:   aead,
+		sealed: make([]byte, size),
+		plain:  make([]byte, 0, segmentSize),
+	}
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *decryptReader) open() error {
+	n, err := io.ReadFull(r.src, r.sealed)
+	switch {
+	case err == io.EOF:
+		// the last segment is flagged, content can't end before it
+		return ErrTruncated
+	case err != nil && err != io.ErrUnexpectedEOF:
+		return err
+	}
+	last := err != nil
+	if !last {
+		if _, err := r.src.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	plain, err := r.aead.Open(r.plain[:0], segmentNonce(r.counter, last), r.sealed[:n], nil)
+	if err != nil {
+		return ErrUnreadable
+	}
+	r.pending = plain
+	r.counter++
+	r.done = last
+	return nil
+}
+
+func (r *decryptReader) Close() error {
+	return r.closer.Close()
+}
@@ -0,0 +1,41 @@
+package local
+
+import (
+	"io"
+	"os"
+	"path"
+)
+
+const ArchiveDir = "/archives/"
+
+type LocalArchiveRepository struct {
+	basePath string
+}
+
+func NewLocalArchiveRepository(basePath string) *LocalArchiveRepository {
+	err := os.MkdirAll(path.Clean(basePath+ArchiveDir), os.ModePerm)
+	if err != nil {
+		panic(err)
+	}
+	return &LocalArchiveRepository{basePath: basePath}
+}
+
+func (s *LocalArchiveRepository) Create(name string) (io.WriteCloser, error) {
+	return os.Create(s.generatePath(name))
+}
+
+func (s *LocalArchiveRepository) Open(name string) (io.ReadCloser, error) {
+	return os.Open(s.generatePath(name))
+}
+
+func (s *LocalArchiveRepository) Delete(name string) error {
+	err := os.Remove(s.generatePath(name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalArchiveRepository) generatePath(name string) string {
+	return path.Join(s.basePath, ArchiveDir, path.Base(name))
+}
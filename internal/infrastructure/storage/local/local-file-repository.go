@@ -1,12 +1,21 @@
 package local
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"mime/multipart"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
 )
 
 const PathTemplate = "/user/%s/files/"
@@ -23,21 +32,33 @@ func NewLocalFileRepository(basePath string) *LocalFileRepository {
 	return &LocalFileRepository{basePath: basePath}
 }
 
-func (s *LocalFileRepository) Upload(userID string, fileHeader *multipart.FileHeader) (string, error) {
-	filePath := s.generatePath(userID, fileHeader.Filename)
+func (s *LocalFileRepository) Upload(userID, filename string, fileHeader *multipart.FileHeader) (string, string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("error opening file: %s", err)
+		return "", "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	filePath, err := s.Save(userID, filename, io.TeeReader(file, hash))
+	if err != nil {
+		return "", "", err
+	}
+	return filePath, hex.EncodeToString(hash.Sum(nil)), nil
+}
 
-	err := os.MkdirAll(path.Dir(filePath), os.ModePerm)
+func (s *LocalFileRepository) Save(userID, filename string, content io.Reader) (string, error) {
+	filePath, err := s.generatePath(userID, filename)
 	if err != nil {
-		log.Printf("error creating directory: %s", err)
 		return "", err
 	}
 
-	file, err := fileHeader.Open()
+	err = os.MkdirAll(path.Dir(filePath), os.ModePerm)
 	if err != nil {
-		log.Printf("error opening file: %s", err)
+		log.Printf("error creating directory: %s", err)
 		return "", err
 	}
-	defer file.Close()
 
 	dst, err := os.Create(filePath)
 	if err != nil {
@@ -46,7 +67,7 @@ func (s *LocalFileRepository) Upload(userID string, fileHeader *multipart.FileHe
 	}
 	defer dst.Close()
 
-	_, err = io.Copy(dst, file)
+	_, err = io.Copy(dst, content)
 	if err != nil {
 		log.Printf("error copying file: %s", err)
 		return "", err
@@ -55,30 +76,60 @@ func (s *LocalFileRepository) Upload(userID string, fileHeader *multipart.FileHe
 	return filePath, nil
 }
 
-func (s *LocalFileRepository) Get(userID, filename string) (*os.File, error) {
-	filePath := s.generatePath(userID, filename)
+func (s *LocalFileRepository) Get(userID, filename string) (io.ReadCloser, error) {
+	filePath, err := s.generatePath(userID, filename)
+	if err != nil {
+		return nil, err
+	}
 	return os.OpenFile(filePath, os.O_RDONLY, os.ModePerm)
 }
 
 func (s *LocalFileRepository) List(userID string) ([]string, error) {
-	list, err := os.ReadDir(s.generatePath(userID, ""))
+	dir, err := s.userDir(userID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, err
 	}
 	files := make([]string, len(list))
 	for i, entry := range list {
-		files[i] = s.generatePath(userID, entry.Name())
+		files[i] = path.Join(dir, entry.Name())
 	}
 
 	return files, nil
 }
 
+func (s *LocalFileRepository) ModTime(userID, filename string) (time.Time, error) {
+	filePath, err := s.generatePath(userID, filename)
+	if err != nil {
+		return time.Time{}, err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
 func (s *LocalFileRepository) Delete(userID, filename string) error {
-	return os.Remove(s.generatePath(userID, filename))
+	filePath, err := s.generatePath(userID, filename)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
 }
 
 func (s *LocalFileRepository) DeleteFiles(userID string) error {
-	list, err := os.ReadDir(s.generatePath(userID, ""))
+	dir, err := s.userDir(userID)
+	if err != nil {
+		return err
+	}
+	list, err := os.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
@@ -87,12 +138,38 @@ func (s *LocalFileRepository) DeleteFiles(userID string) error {
 	}
 
 	for _, entry := range list {
-		os.Remove(s.generatePath(userID, entry.Name()))
+		os.Remove(path.Join(dir, entry.Name()))
 	}
 
 	return nil
 }
 
-func (s *LocalFileRepository) generatePath(userID, filename string) string {
-	return path.Clean(s.basePath + fmt.Sprintf(PathTemplate, userID) + filename)
+// generatePath is the path of a file in the directory of the user. Both the user ID and the file name must be
+// a single path element, and the resolved path is refused if it isn't confined to the directory anyway.
+func (s *LocalFileRepository) generatePath(userID, filename string) (string, error) {
+	dir, err := s.userDir(userID)
+	if err != nil {
+		return "", err
+	}
+	if !domain.IsPathElement(filename) {
+		return "", fmt.Errorf("%w: file name %q", domain.ErrUnsafePath, filename)
+	}
+	return confine(dir, path.Join(dir, filename))
+}
+
+func (s *LocalFileRepository) userDir(userID string) (string, error) {
+	if !domain.IsPathElement(userID) {
+		return "", fmt.Errorf("%w: user ID %q", domain.ErrUnsafePath, userID)
+	}
+	base := path.Clean(s.basePath)
+	return confine(base, path.Clean(base+fmt.Sprintf(PathTemplate, userID)))
+}
+
+// confine returns the path if it resolves under the directory, or an error matching domain.ErrUnsafePath
+func confine(dir, name string) (string, error) {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(name))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("%w: %s is outside %s", domain.ErrUnsafePath, name, dir)
+	}
+	return name, nil
 }
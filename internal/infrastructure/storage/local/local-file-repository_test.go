@@ -0,0 +1,99 @@
+package local
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalFileRepository_Paths(t *testing.T) {
+	repository := NewLocalFileRepository(t.TempDir())
+
+	t.Run("Save And Get", func(t *testing.T) {
+		filePath, err := repository.Save("user-1", "file-123", bytes.NewReader([]byte("hello")))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(repository.basePath, "user", "user-1", "files", "file-123"), filePath)
+
+		content, err := repository.Get("user-1", "file-123")
+		require.NoError(t, err)
+		defer content.Close()
+		body, _ := io.ReadAll(content)
+		assert.Equal(t, "hello", string(body))
+	})
+
+	t.Run("ModTime", func(t *testing.T) {
+		_, err := repository.Save("user-1", "file-456", strings.NewReader("hello"))
+		require.NoError(t, err)
+
+		modified, err := repository.ModTime("user-1", "file-456")
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), modified, time.Minute)
+		_, err = repository.ModTime("user-1", "missing")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("Delete Missing File", func(t *testing.T) {
+		assert.NoError(t, repository.Delete("user-1", "missing"))
+	})
+
+	for _, tt := range storagetest.UnsafePaths {
+		t.Run(tt.Name, func(t *testing.T) {
+			_, err := repository.Save(tt.UserID, tt.Filename, strings.NewReader("x"))
+			assert.ErrorIs(t, err, domain.ErrUnsafePath)
+			_, err = repository.Get(tt.UserID, tt.Filename)
+			assert.ErrorIs(t, err, domain.ErrUnsafePath)
+			_, err = repository.ModTime(tt.UserID, tt.Filename)
+			assert.ErrorIs(t, err, domain.ErrUnsafePath)
+			assert.ErrorIs(t, repository.Delete(tt.UserID, tt.Filename), domain.ErrUnsafePath)
+		})
+	}
+
+	t.Run("Unsafe User Files", func(t *testing.T) {
+		_, err := repository.List("..")
+		assert.ErrorIs(t, err, domain.ErrUnsafePath)
+		assert.ErrorIs(t, repository.DeleteFiles("../.."), domain.ErrUnsafePath)
+	})
+}
+
+// FuzzLocalFileRepository_GeneratePath checks that whatever the user ID and file name, a path is either refused
+// or a file directly in the directory of the user
+func FuzzLocalFileRepository_GeneratePath(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"user-1", "file-123"},
+		{"user-1", "../file-123"},
+		{"..", "files"},
+		{"user-1/../user-2", "file-123"},
+		{"user-1", "a/../../b"},
+		{"user-1", `..\..\b`},
+		{".", "."},
+		{"user-1", "контракт.pdf"},
+		{"user-1", "file\x00"},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	repository := &LocalFileRepository{basePath: "/srv/storage"}
+
+	f.Fuzz(func(t *testing.T, userID, filename string) {
+		filePath, err := repository.generatePath(userID, filename)
+		if err != nil {
+			assert.ErrorIs(t, err, domain.ErrUnsafePath)
+			return
+		}
+		dir := filepath.Join("/srv/storage", "user", userID, "files")
+		if filepath.Dir(filePath) != dir || filepath.Base(filePath) != filename {
+			t.Fatalf("path %q of user %q and file %q is outside %q", filePath, userID, filename, dir)
+		}
+		if rel, err := filepath.Rel("/srv/storage", filePath); err != nil || strings.HasPrefix(rel, "..") {
+			t.Fatalf("path %q is outside the base directory", filePath)
+		}
+	})
+}
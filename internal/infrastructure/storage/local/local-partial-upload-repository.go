@@ -0,0 +1,68 @@
+package local
+
+import (
+	"io"
+	"os"
+	"path"
+)
+
+const UploadDir = "/uploads/"
+
+// LocalPartialUploadRepository keeps the content of resumable uploads in a file each
+type LocalPartialUploadRepository struct {
+	basePath string
+}
+
+func NewLocalPartialUploadRepository(basePath string) *LocalPartialUploadRepository {
+	err := os.MkdirAll(path.Clean(basePath+UploadDir), os.ModePerm)
+	if err != nil {
+		panic(err)
+	}
+	return &LocalPartialUploadRepository{basePath: basePath}
+}
+
+func (s *LocalPartialUploadRepository) Create(uploadID string) error {
+	f, err := os.OpenFile(s.generatePath(uploadID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (s *LocalPartialUploadRepository) Append(uploadID string, offset int64, content io.Reader) (int64, error) {
+	f, err := os.OpenFile(s.generatePath(uploadID), os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	// bytes written after the last recorded offset, e.g. before a crash, are dropped
+	if err := f.Truncate(offset); err != nil {
+		return 0, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(f, content)
+	if err != nil {
+		return written, err
+	}
+	return written, f.Sync()
+}
+
+func (s *LocalPartialUploadRepository) Open(uploadID string) (io.ReadCloser, error) {
+	return os.Open(s.generatePath(uploadID))
+}
+
+func (s *LocalPartialUploadRepository) Delete(uploadID string) error {
+	err := os.Remove(s.generatePath(uploadID))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalPartialUploadRepository) generatePath(uploadID string) string {
+	return path.Join(s.basePath, UploadDir, path.Base(uploadID))
+}
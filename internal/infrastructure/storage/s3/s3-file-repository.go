@@ -0,0 +1,497 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bizio/abc-user-service/internal/domain"
+)
+
+const (
+	PathTemplate = "user/%s/files/"
+	// DefaultPartSize is the size of the parts of a multipart upload, S3 requires at least 5 MiB but for the last one
+	DefaultPartSize = 8 << 20
+	// deleteBatchSize is the most keys S3 deletes in a single request
+	deleteBatchSize = 1000
+)
+
+var (
+	ErrMissingBucket       = errors.New("s3: a bucket is required")
+	ErrMissingRegion       = errors.New("s3: a region is required")
+	ErrInvalidEncryption   = errors.New("s3: invalid server-side encryption: use AES256 or aws:kms")
+	ErrUnexpectedKMSKeyID  = errors.New("s3: a KMS key ID requires aws:kms server-side encryption")
+	ErrInvalidEndpoint     = errors.New("s3: invalid endpoint: use an absolute http or https URL")
+	ErrPartSizeTooSmall    = errors.New("s3: the part size must be at least 5 MiB")
+	ErrInvalidListResponse = errors.New("s3: invalid list response")
+	ErrIncompleteDelete    = errors.New("s3: some objects couldn't be deleted")
+)
+
+// Config tells where the files are stored and how
+type Config struct {
+	// Endpoint is the URL of the S3 API, AWS's one for the region if empty
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix is prepended to the keys, so the bucket can be shared
+	Prefix string
+	// PathStyle puts the bucket in the path rather than in the host name, as MinIO expects
+	PathStyle       bool
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// ServerSideEncryption is AES256 for keys managed by S3, aws:kms for keys managed by KMS or empty for the
+	// bucket's default
+	ServerSideEncryption string
+	// KMSKeyID is the KMS key of aws:kms encryption, the account's default key if empty
+	KMSKeyID string
+	// PartSize is the size of the parts content is uploaded in, DefaultPartSize if 0
+	PartSize int64
+}
+
+// S3FileRepository stores files in a bucket of S3 or of a compatible object storage
+type S3FileRepository struct {
+	client   *client
+	prefix   string
+	header   http.Header // server-side encryption headers of new objects
+	partSize int64
+	parts    sync.Pool // buffers of a part, reused as they're large
+}
+
+func NewS3FileRepository(cfg Config, httpClient *http.Client) (*S3FileRepository, error) {
+	if cfg.Bucket == "" {
+		return nil, ErrMissingBucket
+	}
+	if cfg.Region == "" {
+		return nil, ErrMissingRegion
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidEndpoint
+	}
+
+	header := http.Header{}
+	switch cfg.ServerSideEncryption {
+	case "":
+	case "AES256", "aws:kms":
+		header.Set("X-Amz-Server-Side-Encryption", cfg.ServerSideEncryption)
+	default:
+		return nil, ErrInvalidEncryption
+	}
+	if cfg.KMSKeyID != "" {
+		if cfg.ServerSideEncryption != "aws:kms" {
+			return nil, ErrUnexpectedKMSKeyID
+		}
+		header.Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", cfg.KMSKeyID)
+	}
+
+	partSize := cfg.PartSize
+	if partSize == 0 {
+		partSize = DefaultPartSize
+	}
+	if partSize < 5<<20 {
+		return nil, ErrPartSizeTooSmall
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &S3FileRepository{
+		client: &client{
+			http:            httpClient,
+			endpoint:        u,
+			region:          cfg.Region,
+			bucket:          cfg.Bucket,
+			pathStyle:       cfg.PathStyle,
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			sessionToken:    cfg.SessionToken,
+			now:             time.Now,
+		},
+		prefix:   strings.Trim(cfg.Prefix, "/"),
+		header:   header,
+		partSize: partSize,
+	}, nil
+}
+
+func (s *S3FileRepository) Upload(userID, filename string, fileHeader *multipart.FileHeader) (string, string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("error opening file: %s", err)
+		return "", "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	filePath, err := s.Save(userID, filename, io.TeeReader(file, hash))
+	if err != nil {
+		return "", "", err
+	}
+	return filePath, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Save streams the content to the bucket. Content that fits in a part is stored with a single request, larger
+// content with a multipart upload, so it never has to be held in memory as a whole.
+func (s *S3FileRepository) Save(userID, filename string, content io.Reader) (string, error) {
+	key, err := s.generateKey(userID, filename)
+	if err != nil {
+		return "", err
+	}
+
+	buffer := s.getPart()
+	defer s.parts.Put(buffer)
+	part := (*buffer)[:s.partSize:s.partSize]
+	n, err := io.ReadFull(content, part)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		if err := s.putObject(key, part[:n]); err != nil {
+			log.Printf("error storing object %s: %s", key, err)
+			return "", err
+		}
+		return s.location(key), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.multipartUpload(key, part, content); err != nil {
+		log.Printf("error storing object %s: %s", key, err)
+		return "", err
+	}
+	return s.location(key), nil
+}
+
+// getPart returns a buffer of a part from the pool, or a new one if there's none
+func (s *S3FileRepository) getPart() *[]byte {
+	buffer, _ := s.parts.Get().(*[]byte)
+	if buffer == nil || int64(cap(*buffer)) < s.partSize {
+		part := make([]byte, s.partSize)
+		buffer = &part
+	}
+	return buffer
+}
+
+// Get returns the content of the file, an error matching fs.ErrNotExist if there isn't one
+func (s *S3FileRepository) Get(userID, filename string) (io.ReadCloser, error) {
+	key, err := s.generateKey(userID, filename)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.do(http.MethodGet, key, nil, nil, nil, 0)
+	if err != nil {
+		var resErr *ResponseError
+		if errors.As(err, &resErr) && resErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", fs.ErrNotExist, key)
+		}
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+func (s *S3FileRepository) List(userID string) ([]string, error) {
+	prefix, err := s.userPrefix(userID)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.listKeys(prefix)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, len(keys))
+	for i, key := range keys {
+		files[i] = s.location(key)
+	}
+	return files, nil
+}
+
+// ModTime returns the Last-Modified time of the object, the time its upload completed
+func (s *S3FileRepository) ModTime(userID, filename string) (time.Time, error) {
+	key, err := s.generateKey(userID, filename)
+	if err != nil {
+		return time.Time{}, err
+	}
+	res, err := s.client.do(http.MethodHead, key, nil, nil, nil, 0)
+	if err != nil {
+		var resErr *ResponseError
+		if errors.As(err, &resErr) && resErr.StatusCode == http.StatusNotFound {
+			return time.Time{}, fmt.Errorf("%w: %s", fs.ErrNotExist, key)
+		}
+		return time.Time{}, err
+	}
+	res.Body.Close()
+	return http.ParseTime(res.Header.Get("Last-Modified"))
+}
+
+func (s *S3FileRepository) Delete(userID, filename string) error {
+	key, err := s.generateKey(userID, filename)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.do(http.MethodDelete, key, nil, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (s *S3FileRepository) DeleteFiles(userID string) error {
+	prefix, err := s.userPrefix(userID)
+	if err != nil {
+		return err
+	}
+	keys, err := s.listKeys(prefix)
+	if err != nil {
+		return err
+	}
+
+	for len(keys) > 0 {
+		batch := keys[:min(len(keys), deleteBatchSize)]
+		keys = keys[len(batch):]
+		if err := s.deleteObjects(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *S3FileRepository) putObject(key string, content []byte) error {
+	res, err := s.client.do(http.MethodPut, key, nil, s.header, bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// multipartUpload uploads the first part and the rest of the content part by part. An upload that fails is
+// aborted, so the parts already uploaded don't linger in the bucket.
+func (s *S3FileRepository) multipartUpload(key string, first []byte, content io.Reader) error {
+	uploadID, err := s.createMultipartUpload(key)
+	if err != nil {
+		return err
+	}
+
+	parts, err := s.uploadParts(key, uploadID, first, content)
+	if err == nil {
+		err = s.completeMultipartUpload(key, uploadID, parts)
+	}
+	if err != nil {
+		if abortErr := s.abortMultipartUpload(key, uploadID); abortErr != nil {
+			log.Printf("error aborting multipart upload of %s: %s", key, abortErr)
+		}
+		return err
+	}
+	return nil
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (s *S3FileRepository) uploadParts(key, uploadID string, part []byte, content io.Reader) ([]completedPart, error) {
+	var parts []completedPart
+	for number := 1; len(part) > 0; number++ {
+		query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+		res, err := s.client.do(http.MethodPut, key, query, nil, bytes.NewReader(part), int64(len(part)))
+		if err != nil {
+			return nil, err
+		}
+		res.Body.Close()
+		parts = append(parts, completedPart{PartNumber: number, ETag: res.Header.Get("ETag")})
+
+		part = part[:cap(part)]
+		n, err := io.ReadFull(content, part)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+		part = part[:n]
+	}
+	return parts, nil
+}
+
+func (s *S3FileRepository) createMultipartUpload(key string) (string, error) {
+	res, err := s.client.do(http.MethodPost, key, url.Values{"uploads": {""}}, s.header, nil, 0)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.UploadID, nil
+}
+
+func (s *S3FileRepository) completeMultipartUpload(key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+
+	res, err := s.client.do(http.MethodPost, key, url.Values{"uploadId": {uploadID}}, nil, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// S3 can fail the completion after it started responding with 200, the body tells
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.XMLName.Local == "Error" {
+		return &ResponseError{StatusCode: res.StatusCode, Code: result.Code, Message: result.Message}
+	}
+	return nil
+}
+
+func (s *S3FileRepository) abortMultipartUpload(key, uploadID string) error {
+	res, err := s.client.do(http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// listKeys returns the keys of all the objects with the prefix, page by page
+func (s *S3FileRepository) listKeys(prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		res, err := s.client.do(http.MethodGet, "", query, nil, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated {
+			return keys, nil
+		}
+		if result.NextContinuationToken == "" {
+			return nil, ErrInvalidListResponse
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *S3FileRepository) deleteObjects(keys []string) error {
+	type object struct {
+		Key string `xml:"Key"`
+	}
+	request := struct {
+		XMLName xml.Name `xml:"Delete"`
+		Quiet   bool     `xml:"Quiet"`
+		Objects []object `xml:"Object"`
+	}{Quiet: true}
+	for _, key := range keys {
+		request.Objects = append(request.Objects, object{Key: key})
+	}
+	body, err := xml.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// S3 requires the digest of the body of this request
+	sum := md5.Sum(body)
+	header := http.Header{"Content-Md5": {base64.StdEncoding.EncodeToString(sum[:])}}
+	res, err := s.client.do(http.MethodPost, "", url.Values{"delete": {""}}, header, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// in quiet mode only the objects that couldn't be deleted are listed
+	var result struct {
+		Errors []struct {
+			Key     string `xml:"Key"`
+			Message string `xml:"Message"`
+		} `xml:"Error"`
+	}
+	if err := xml.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	for _, e := range result.Errors {
+		log.Printf("error deleting object %s: %s", e.Key, e.Message)
+	}
+	if len(result.Errors) > 0 {
+		return ErrIncompleteDelete
+	}
+	return nil
+}
+
+// location is the path recorded for a stored file
+func (s *S3FileRepository) location(key string) string {
+	return "s3://" + s.client.bucket + "/" + key
+}
+
+// generateKey is the key of a file under the prefix of the user. The file name must be a single path element, so
+// the key can't name another user's file or anything outside the prefix.
+func (s *S3FileRepository) generateKey(userID, filename string) (string, error) {
+	prefix, err := s.userPrefix(userID)
+	if err != nil {
+		return "", err
+	}
+	if !domain.IsPathElement(filename) {
+		return "", fmt.Errorf("%w: file name %q", domain.ErrUnsafePath, filename)
+	}
+	return prefix + filename, nil
+}
+
+// userPrefix is the key prefix of the files of the user, ending with a slash. The user ID must be a single path
+// element.
+func (s *S3FileRepository) userPrefix(userID string) (string, error) {
+	if !domain.IsPathElement(userID) {
+		return "", fmt.Errorf("%w: user ID %q", domain.ErrUnsafePath, userID)
+	}
+	key := path.Clean("/" + s.prefix + "/" + fmt.Sprintf(PathTemplate, userID))
+	return strings.TrimPrefix(key, "/") + "/", nil
+}
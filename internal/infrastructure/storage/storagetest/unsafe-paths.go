@@ -0,0 +1,21 @@
+// Package storagetest holds the cases the tests of the file storages share
+package storagetest
+
+// UnsafePath is a user ID and file name a file storage must refuse with domain.ErrUnsafePath
+type UnsafePath struct {
+	Name     string
+	UserID   string
+	Filename string
+}
+
+// UnsafePaths would resolve outside the storage of the user, or name another user's file, if they weren't refused
+var UnsafePaths = []UnsafePath{
+	{Name: "Parent File", UserID: "user-1", Filename: "../../user-2/files/file-123"},
+	{Name: "Parent User", UserID: "../user-2", Filename: "file-123"},
+	{Name: "Dot Dot", UserID: "user-1", Filename: ".."},
+	{Name: "Absolute", UserID: "user-1", Filename: "/etc/passwd"},
+	{Name: "Backslash", UserID: "user-1", Filename: `..\..\file-123`},
+	{Name: "NUL", UserID: "user-1", Filename: "file\x00.txt"},
+	{Name: "Empty File", UserID: "user-1", Filename: ""},
+	{Name: "Empty User", UserID: "", Filename: "file-123"},
+}
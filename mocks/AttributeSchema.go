@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// AttributeSchema is an autogenerated mock type for the AttributeSchema type
+type AttributeSchema struct {
+	mock.Mock
+}
+
+// Digest provides a mock function with no fields
+func (_m *AttributeSchema) Digest() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Digest")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Validate provides a mock function with given fields: attributes
+func (_m *AttributeSchema) Validate(attributes map[string]interface{}) error {
+	ret := _m.Called(attributes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAttributeSchema creates a new instance of AttributeSchema. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttributeSchema(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttributeSchema {
+	mock := &AttributeSchema{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttributeSchemaRepository is an autogenerated mock type for the AttributeSchemaRepository type
+type AttributeSchemaRepository struct {
+	mock.Mock
+}
+
+// Accept provides a mock function with given fields: digest, acceptedAt
+func (_m *AttributeSchemaRepository) Accept(digest string, acceptedAt time.Time) error {
+	ret := _m.Called(digest, acceptedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accept")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(digest, acceptedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAcceptedDigest provides a mock function with no fields
+func (_m *AttributeSchemaRepository) GetAcceptedDigest() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAcceptedDigest")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttributeSchemaRepository creates a new instance of AttributeSchemaRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttributeSchemaRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttributeSchemaRepository {
+	mock := &AttributeSchemaRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
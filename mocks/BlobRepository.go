@@ -0,0 +1,283 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobRepository is an autogenerated mock type for the BlobRepository type
+type BlobRepository struct {
+	mock.Mock
+}
+
+// Collect provides a mock function with given fields: digest, remove
+func (_m *BlobRepository) Collect(digest string, remove func() error) (bool, error) {
+	ret := _m.Called(digest, remove)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Collect")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, func() error) (bool, error)); ok {
+		return rf(digest, remove)
+	}
+	if rf, ok := ret.Get(0).(func(string, func() error) bool); ok {
+		r0 = rf(digest, remove)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, func() error) error); ok {
+		r1 = rf(digest, remove)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Dereference provides a mock function with given fields: userID, filename
+func (_m *BlobRepository) Dereference(userID string, filename string) (string, error) {
+	ret := _m.Called(userID, filename)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Dereference")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(userID, filename)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(userID, filename)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, filename)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DereferenceAll provides a mock function with given fields: userID
+func (_m *BlobRepository) DereferenceAll(userID string) ([]string, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DereferenceAll")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReference provides a mock function with given fields: userID, filename
+func (_m *BlobRepository) GetReference(userID string, filename string) (string, error) {
+	ret := _m.Called(userID, filename)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReference")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(userID, filename)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(userID, filename)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, filename)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReferences provides a mock function with given fields: userID
+func (_m *BlobRepository) ListReferences(userID string) ([]string, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReferences")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUnreferenced provides a mock function with no fields
+func (_m *BlobRepository) ListUnreferenced() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnreferenced")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkStored provides a mock function with given fields: digest
+func (_m *BlobRepository) MarkStored(digest string) error {
+	ret := _m.Called(digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkStored")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(digest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reference provides a mock function with given fields: userID, filename, digest, size
+func (_m *BlobRepository) Reference(userID string, filename string, digest string, size int64) (bool, string, error) {
+	ret := _m.Called(userID, filename, digest, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reference")
+	}
+
+	var r0 bool
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, string, int64) (bool, string, error)); ok {
+		return rf(userID, filename, digest, size)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, int64) bool); ok {
+		r0 = rf(userID, filename, digest, size)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, int64) string); ok {
+		r1 = rf(userID, filename, digest, size)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, string, int64) error); ok {
+		r2 = rf(userID, filename, digest, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReferencedAt provides a mock function with given fields: userID, filename
+func (_m *BlobRepository) ReferencedAt(userID string, filename string) (time.Time, error) {
+	ret := _m.Called(userID, filename)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReferencedAt")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (time.Time, error)); ok {
+		return rf(userID, filename)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) time.Time); ok {
+		r0 = rf(userID, filename)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, filename)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewBlobRepository creates a new instance of BlobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlobRepository {
+	mock := &BlobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	model "github.com/bizio/abc-user-service/internal/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CredentialRepository is an autogenerated mock type for the CredentialRepository type
+type CredentialRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: userID
+func (_m *CredentialRepository) Delete(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userID
+func (_m *CredentialRepository) Get(userID string) (*model.Credential, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.Credential, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Credential); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordFailure provides a mock function with given fields: userID, now, maxAttempts, lockout
+func (_m *CredentialRepository) RecordFailure(userID string, now time.Time, maxAttempts int, lockout time.Duration) error {
+	ret := _m.Called(userID, now, maxAttempts, lockout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, int, time.Duration) error); ok {
+		r0 = rf(userID, now, maxAttempts, lockout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordSuccess provides a mock function with given fields: userID
+func (_m *CredentialRepository) RecordSuccess(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordSuccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: credential
+func (_m *CredentialRepository) Save(credential *model.Credential) error {
+	ret := _m.Called(credential)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Credential) error); ok {
+		r0 = rf(credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCredentialRepository creates a new instance of CredentialRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCredentialRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CredentialRepository {
+	mock := &CredentialRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
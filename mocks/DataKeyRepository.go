@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "github.com/bizio/abc-user-service/internal/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DataKeyRepository is an autogenerated mock type for the DataKeyRepository type
+type DataKeyRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: userID, filename
+func (_m *DataKeyRepository) Delete(userID string, filename string) error {
+	ret := _m.Called(userID, filename)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, filename)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAll provides a mock function with given fields: userID
+func (_m *DataKeyRepository) DeleteAll(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userID, filename
+func (_m *DataKeyRepository) Get(userID string, filename string) (*model.DataKey, error) {
+	ret := _m.Called(userID, filename)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.DataKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.DataKey, error)); ok {
+		return rf(userID, filename)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.DataKey); ok {
+		r0 = rf(userID, filename)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DataKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, filename)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWrappedWithOtherKey provides a mock function with given fields: keyID, limit
+func (_m *DataKeyRepository) ListWrappedWithOtherKey(keyID string, limit int) ([]*model.DataKey, error) {
+	ret := _m.Called(keyID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWrappedWithOtherKey")
+	}
+
+	var r0 []*model.DataKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]*model.DataKey, error)); ok {
+		return rf(keyID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []*model.DataKey); ok {
+		r0 = rf(keyID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DataKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(keyID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: key
+func (_m *DataKeyRepository) Save(key *model.DataKey) error {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.DataKey) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDataKeyRepository creates a new instance of DataKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDataKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DataKeyRepository {
+	mock := &DataKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,183 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	model "github.com/bizio/abc-user-service/internal/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportRepository is an autogenerated mock type for the ExportRepository type
+type ExportRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: export
+func (_m *ExportRepository) Create(export *model.Export) (string, error) {
+	ret := _m.Called(export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Export) (string, error)); ok {
+		return rf(export)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Export) string); ok {
+		r0 = rf(export)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Export) error); ok {
+		r1 = rf(export)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: userID, exportID
+func (_m *ExportRepository) Delete(userID string, exportID string) error {
+	ret := _m.Called(userID, exportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, exportID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userID, exportID
+func (_m *ExportRepository) Get(userID string, exportID string) (*model.Export, error) {
+	ret := _m.Called(userID, exportID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.Export, error)); ok {
+		return rf(userID, exportID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.Export); ok {
+		r0 = rf(userID, exportID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, exportID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: userID
+func (_m *ExportRepository) List(userID string) ([]*model.Export, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*model.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.Export, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.Export); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExpired provides a mock function with given fields: now
+func (_m *ExportRepository) ListExpired(now time.Time) ([]*model.Export, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpired")
+	}
+
+	var r0 []*model.Export
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]*model.Export, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []*model.Export); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Export)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: export
+func (_m *ExportRepository) Update(export *model.Export) error {
+	ret := _m.Called(export)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Export) error); ok {
+		r0 = rf(export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExportRepository creates a new instance of ExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportRepository {
+	mock := &ExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,11 +3,12 @@
 package mocks
 
 import (
+	io "io"
 	multipart "mime/multipart"
 
 	mock "github.com/stretchr/testify/mock"
 
-	os "os"
+	time "time"
 )
 
 // FileRepository is an autogenerated mock type for the FileRepository type
@@ -52,23 +53,23 @@ func (_m *FileRepository) DeleteFiles(userID string) error {
 }
 
 // Get provides a mock function with given fields: userID, filename
-func (_m *FileRepository) Get(userID string, filename string) (*os.File, error) {
+func (_m *FileRepository) Get(userID string, filename string) (io.ReadCloser, error) {
 	ret := _m.Called(userID, filename)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 *os.File
+	var r0 io.ReadCloser
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*os.File, error)); ok {
+	if rf, ok := ret.Get(0).(func(string, string) (io.ReadCloser, error)); ok {
 		return rf(userID, filename)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) *os.File); ok {
+	if rf, ok := ret.Get(0).(func(string, string) io.ReadCloser); ok {
 		r0 = rf(userID, filename)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*os.File)
+			r0 = ret.Get(0).(io.ReadCloser)
 		}
 	}
 
@@ -111,27 +112,55 @@ func (_m *FileRepository) List(userID string) ([]string, error) {
 	return r0, r1
 }
 
-// Upload provides a mock function with given fields: userID, file
-func (_m *FileRepository) Upload(userID string, file *multipart.FileHeader) (string, error) {
-	ret := _m.Called(userID, file)
+// ModTime provides a mock function with given fields: userID, filename
+func (_m *FileRepository) ModTime(userID string, filename string) (time.Time, error) {
+	ret := _m.Called(userID, filename)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Upload")
+		panic("no return value specified for ModTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (time.Time, error)); ok {
+		return rf(userID, filename)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) time.Time); ok {
+		r0 = rf(userID, filename)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, filename)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: userID, filename, content
+func (_m *FileRepository) Save(userID string, filename string, content io.Reader) (string, error) {
+	ret := _m.Called(userID, filename, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *multipart.FileHeader) (string, error)); ok {
-		return rf(userID, file)
+	if rf, ok := ret.Get(0).(func(string, string, io.Reader) (string, error)); ok {
+		return rf(userID, filename, content)
 	}
-	if rf, ok := ret.Get(0).(func(string, *multipart.FileHeader) string); ok {
-		r0 = rf(userID, file)
+	if rf, ok := ret.Get(0).(func(string, string, io.Reader) string); ok {
+		r0 = rf(userID, filename, content)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *multipart.FileHeader) error); ok {
-		r1 = rf(userID, file)
+	if rf, ok := ret.Get(1).(func(string, string, io.Reader) error); ok {
+		r1 = rf(userID, filename, content)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -139,6 +168,41 @@ func (_m *FileRepository) Upload(userID string, file *multipart.FileHeader) (str
 	return r0, r1
 }
 
+// Upload provides a mock function with given fields: userID, filename, file
+func (_m *FileRepository) Upload(userID string, filename string, file *multipart.FileHeader) (string, string, error) {
+	ret := _m.Called(userID, filename, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, *multipart.FileHeader) (string, string, error)); ok {
+		return rf(userID, filename, file)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, *multipart.FileHeader) string); ok {
+		r0 = rf(userID, filename, file)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, *multipart.FileHeader) string); ok {
+		r1 = rf(userID, filename, file)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, *multipart.FileHeader) error); ok {
+		r2 = rf(userID, filename, file)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewFileRepository creates a new instance of FileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewFileRepository(t interface {
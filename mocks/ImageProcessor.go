@@ -0,0 +1,57 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/bizio/abc-user-service/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImageProcessor is an autogenerated mock type for the ImageProcessor type
+type ImageProcessor struct {
+	mock.Mock
+}
+
+// Thumbnails provides a mock function with given fields: content, sizes
+func (_m *ImageProcessor) Thumbnails(content []byte, sizes []int) ([]*domain.Thumbnail, error) {
+	ret := _m.Called(content, sizes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Thumbnails")
+	}
+
+	var r0 []*domain.Thumbnail
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, []int) ([]*domain.Thumbnail, error)); ok {
+		return rf(content, sizes)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, []int) []*domain.Thumbnail); ok {
+		r0 = rf(content, sizes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Thumbnail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, []int) error); ok {
+		r1 = rf(content, sizes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewImageProcessor creates a new instance of ImageProcessor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImageProcessor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImageProcessor {
+	mock := &ImageProcessor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,122 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PartialUploadRepository is an autogenerated mock type for the PartialUploadRepository type
+type PartialUploadRepository struct {
+	mock.Mock
+}
+
+// Append provides a mock function with given fields: uploadID, offset, content
+func (_m *PartialUploadRepository) Append(uploadID string, offset int64, content io.Reader) (int64, error) {
+	ret := _m.Called(uploadID, offset, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Append")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, io.Reader) (int64, error)); ok {
+		return rf(uploadID, offset, content)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, io.Reader) int64); ok {
+		r0 = rf(uploadID, offset, content)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, io.Reader) error); ok {
+		r1 = rf(uploadID, offset, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: uploadID
+func (_m *PartialUploadRepository) Create(uploadID string) error {
+	ret := _m.Called(uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: uploadID
+func (_m *PartialUploadRepository) Delete(uploadID string) error {
+	ret := _m.Called(uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Open provides a mock function with given fields: uploadID
+func (_m *PartialUploadRepository) Open(uploadID string) (io.ReadCloser, error) {
+	ret := _m.Called(uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (io.ReadCloser, error)); ok {
+		return rf(uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(string) io.ReadCloser); ok {
+		r0 = rf(uploadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPartialUploadRepository creates a new instance of PartialUploadRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPartialUploadRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PartialUploadRepository {
+	mock := &PartialUploadRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// URLSigner is an autogenerated mock type for the URLSigner type
+type URLSigner struct {
+	mock.Mock
+}
+
+// Sign provides a mock function with given fields: payload
+func (_m *URLSigner) Sign(payload string) string {
+	ret := _m.Called(payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(payload)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Verify provides a mock function with given fields: payload, signature
+func (_m *URLSigner) Verify(payload string, signature string) bool {
+	ret := _m.Called(payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(payload, signature)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewURLSigner creates a new instance of URLSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewURLSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *URLSigner {
+	mock := &URLSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,183 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	model "github.com/bizio/abc-user-service/internal/domain/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UploadRepository is an autogenerated mock type for the UploadRepository type
+type UploadRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: upload
+func (_m *UploadRepository) Create(upload *model.Upload) (string, error) {
+	ret := _m.Called(upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Upload) (string, error)); ok {
+		return rf(upload)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Upload) string); ok {
+		r0 = rf(upload)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Upload) error); ok {
+		r1 = rf(upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: uploadID
+func (_m *UploadRepository) Delete(uploadID string) error {
+	ret := _m.Called(uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(uploadID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: userID, uploadID
+func (_m *UploadRepository) Get(userID string, uploadID string) (*model.Upload, error) {
+	ret := _m.Called(userID, uploadID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Upload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*model.Upload, error)); ok {
+		return rf(userID, uploadID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *model.Upload); ok {
+		r0 = rf(userID, uploadID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Upload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, uploadID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: userID
+func (_m *UploadRepository) List(userID string) ([]*model.Upload, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*model.Upload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.Upload, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.Upload); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Upload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExpired provides a mock function with given fields: now
+func (_m *UploadRepository) ListExpired(now time.Time) ([]*model.Upload, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpired")
+	}
+
+	var r0 []*model.Upload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]*model.Upload, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []*model.Upload); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Upload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: upload
+func (_m *UploadRepository) Update(upload *model.Upload) error {
+	ret := _m.Called(upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Upload) error); ok {
+		r0 = rf(upload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewUploadRepository creates a new instance of UploadRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUploadRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UploadRepository {
+	mock := &UploadRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,8 +3,10 @@
 package mocks
 
 import (
-	model "github.com/bizio/abc-user-service/internal/domain/model"
+	domain "github.com/bizio/abc-user-service/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/bizio/abc-user-service/internal/domain/model"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -12,6 +14,24 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// AddFile provides a mock function with given fields: file, defaultQuota
+func (_m *UserRepository) AddFile(file *model.File, defaultQuota *model.StorageQuota) error {
+	ret := _m.Called(file, defaultQuota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.File, *model.StorageQuota) error); ok {
+		r0 = rf(file, defaultQuota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: user
 func (_m *UserRepository) Create(user *model.User) (string, error) {
 	ret := _m.Called(user)
@@ -40,6 +60,24 @@ func (_m *UserRepository) Create(user *model.User) (string, error) {
 	return r0, r1
 }
 
+// CreateFolder provides a mock function with given fields: folder
+func (_m *UserRepository) CreateFolder(folder *model.Folder) error {
+	ret := _m.Called(folder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Folder) error); ok {
+		r0 = rf(folder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: id
 func (_m *UserRepository) Delete(id string) error {
 	ret := _m.Called(id)
@@ -58,6 +96,42 @@ func (_m *UserRepository) Delete(id string) error {
 	return r0
 }
 
+// DeleteFile provides a mock function with given fields: userID, fileID
+func (_m *UserRepository) DeleteFile(userID string, fileID string) error {
+	ret := _m.Called(userID, fileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, fileID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFileVersion provides a mock function with given fields: userID, versionID
+func (_m *UserRepository) DeleteFileVersion(userID string, versionID string) error {
+	ret := _m.Called(userID, versionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFileVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, versionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteFiles provides a mock function with given fields: userID
 func (_m *UserRepository) DeleteFiles(userID string) error {
 	ret := _m.Called(userID)
@@ -76,6 +150,42 @@ func (_m *UserRepository) DeleteFiles(userID string) error {
 	return r0
 }
 
+// DeleteFolder provides a mock function with given fields: userID, folderID
+func (_m *UserRepository) DeleteFolder(userID string, folderID string) error {
+	ret := _m.Called(userID, folderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(userID, folderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Erase provides a mock function with given fields: user, erasure
+func (_m *UserRepository) Erase(user *model.User, erasure *model.Erasure) error {
+	ret := _m.Called(user, erasure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Erase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.User, *model.Erasure) error); ok {
+		r0 = rf(user, erasure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: id
 func (_m *UserRepository) Get(id string) (*model.User, error) {
 	ret := _m.Called(id)
@@ -166,6 +276,66 @@ func (_m *UserRepository) GetFile(userID string, fileID string) (*model.File, er
 	return r0, r1
 }
 
+// GetFileVersion provides a mock function with given fields: userID, fileID, versionID
+func (_m *UserRepository) GetFileVersion(userID string, fileID string, versionID string) (*model.FileVersion, error) {
+	ret := _m.Called(userID, fileID, versionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileVersion")
+	}
+
+	var r0 *model.FileVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*model.FileVersion, error)); ok {
+		return rf(userID, fileID, versionID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *model.FileVersion); ok {
+		r0 = rf(userID, fileID, versionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.FileVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(userID, fileID, versionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFileVersions provides a mock function with given fields: userID, fileID
+func (_m *UserRepository) GetFileVersions(userID string, fileID string) ([]*model.FileVersion, error) {
+	ret := _m.Called(userID, fileID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileVersions")
+	}
+
+	var r0 []*model.FileVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]*model.FileVersion, error)); ok {
+		return rf(userID, fileID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []*model.FileVersion); ok {
+		r0 = rf(userID, fileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, fileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFiles provides a mock function with given fields: userID
 func (_m *UserRepository) GetFiles(userID string) ([]*model.File, error) {
 	ret := _m.Called(userID)
@@ -196,24 +366,174 @@ func (_m *UserRepository) GetFiles(userID string) ([]*model.File, error) {
 	return r0, r1
 }
 
-// List provides a mock function with no fields
-func (_m *UserRepository) List() ([]*model.User, error) {
+// GetFolders provides a mock function with given fields: userID
+func (_m *UserRepository) GetFolders(userID string) ([]*model.Folder, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFolders")
+	}
+
+	var r0 []*model.Folder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.Folder, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.Folder); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Folder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIncludingDeleted provides a mock function with given fields: id
+func (_m *UserRepository) GetIncludingDeleted(id string) (*model.User, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIncludingDeleted")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.User, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageTotals provides a mock function with no fields
+func (_m *UserRepository) GetStorageTotals() (*model.StorageUsage, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageTotals")
+	}
+
+	var r0 *model.StorageUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*model.StorageUsage, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *model.StorageUsage); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StorageUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageUsage provides a mock function with given fields: userID
+func (_m *UserRepository) GetStorageUsage(userID string) (*model.StorageUsage, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStorageUsage")
+	}
+
+	var r0 *model.StorageUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.StorageUsage, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.StorageUsage); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StorageUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: filter
+func (_m *UserRepository) List(filter *domain.UserFilter) ([]*model.User, error) {
+	ret := _m.Called(filter)
+
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
 	var r0 []*model.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*model.User, error)); ok {
+	if rf, ok := ret.Get(0).(func(*domain.UserFilter) ([]*model.User, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.UserFilter) []*model.User); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.UserFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeletedIDs provides a mock function with no fields
+func (_m *UserRepository) ListDeletedIDs() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeletedIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() []*model.User); ok {
+	if rf, ok := ret.Get(0).(func() []string); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.User)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
@@ -226,6 +546,72 @@ func (_m *UserRepository) List() ([]*model.User, error) {
 	return r0, r1
 }
 
+// ListFilesPendingScan provides a mock function with given fields: limit
+func (_m *UserRepository) ListFilesPendingScan(limit int) ([]*model.File, error) {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFilesPendingScan")
+	}
+
+	var r0 []*model.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]*model.File, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []*model.File); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceFile provides a mock function with given fields: file, previous, restored, defaultQuota
+func (_m *UserRepository) ReplaceFile(file *model.File, previous *model.FileVersion, restored *model.FileVersion, defaultQuota *model.StorageQuota) error {
+	ret := _m.Called(file, previous, restored, defaultQuota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.File, *model.FileVersion, *model.FileVersion, *model.StorageQuota) error); ok {
+		r0 = rf(file, previous, restored, defaultQuota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetStorageQuota provides a mock function with given fields: userID, quota
+func (_m *UserRepository) SetStorageQuota(userID string, quota *model.StorageQuota) error {
+	ret := _m.Called(userID, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStorageQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *model.StorageQuota) error); ok {
+		r0 = rf(userID, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: id, user
 func (_m *UserRepository) Update(id string, user *model.User) error {
 	ret := _m.Called(id, user)
@@ -244,6 +630,42 @@ func (_m *UserRepository) Update(id string, user *model.User) error {
 	return r0
 }
 
+// UpdateFile provides a mock function with given fields: file
+func (_m *UserRepository) UpdateFile(file *model.File) error {
+	ret := _m.Called(file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.File) error); ok {
+		r0 = rf(file)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFolder provides a mock function with given fields: folder
+func (_m *UserRepository) UpdateFolder(folder *model.Folder) error {
+	ret := _m.Called(folder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFolder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Folder) error); ok {
+		r0 = rf(folder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -0,0 +1,36 @@
+package v1
+
+import "time"
+
+type Export struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"userID"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	DownloadURL string     `json:"downloadURL,omitempty"`
+}
+
+type ExportUserRequest struct {
+	UserID string `json:"id" uri:"id" binding:"required"`
+}
+
+type ExportUserResponse struct {
+	Export *Export `json:"export"`
+}
+
+type GetExportRequest struct {
+	UserID   string `json:"id" uri:"id" binding:"required"`
+	ExportID string `json:"exportID" uri:"exportID" binding:"required"`
+}
+
+type GetExportResponse struct {
+	Export *Export `json:"export"`
+}
+
+type DownloadExportRequest struct {
+	UserID   string `uri:"id" binding:"required"`
+	ExportID string `uri:"exportID" binding:"required"`
+	Token    string `form:"token" binding:"required"`
+}
@@ -0,0 +1,31 @@
+package v1
+
+// DownloadFilesRequest streams all the files of a user as a ZIP archive, with a manifest.json entry if Manifest
+type DownloadFilesRequest struct {
+	UserID   string `uri:"id" binding:"required"`
+	Manifest bool   `form:"manifest"`
+}
+
+// FilesArchiveManifest is the last entry of an archive of a user's files, the sizes and digests are those of the
+// content written to the archive
+type FilesArchiveManifest struct {
+	UserID  string          `json:"userID"`
+	Files   []*ArchivedFile `json:"files"`
+	Skipped []*SkippedFile  `json:"skipped,omitempty"`
+}
+
+type ArchivedFile struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Entry       string `json:"entry"` // name in the archive, unique and safe to extract
+	Size        int64  `json:"size"`
+	ContentType string `json:"contentType,omitempty"`
+	Digest      string `json:"digest"` // hex SHA-256 of the content
+}
+
+// SkippedFile is a file whose content isn't in the archive, e.g. it is pending a malware scan
+type SkippedFile struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,45 @@
+package v1
+
+import "time"
+
+// FileVersion is the prior content of a file
+type FileVersion struct {
+	ID           string    `json:"id"`
+	FileID       string    `json:"fileID"`
+	Version      int       `json:"version"`
+	Size         int64     `json:"size"`
+	ContentType  string    `json:"contentType,omitempty"`
+	DeclaredType string    `json:"declaredType,omitempty"`
+	Digest       string    `json:"digest,omitempty"`
+	ScanStatus   string    `json:"scanStatus,omitempty"`
+	ReplacedAt   time.Time `json:"replacedAt"`
+}
+
+type ListFileVersionsRequest struct {
+	UserID string `uri:"id" binding:"required"`
+	FileID string `uri:"fileID" binding:"required"`
+}
+
+// ListFileVersionsResponse lists the prior versions of a file, the newest first
+type ListFileVersionsResponse struct {
+	File     *File          `json:"file"`
+	Versions []*FileVersion `json:"versions"`
+}
+
+type DownloadFileVersionRequest struct {
+	UserID    string `uri:"id" binding:"required"`
+	FileID    string `uri:"fileID" binding:"required"`
+	VersionID string `uri:"versionID" binding:"required"`
+}
+
+// RestoreFileVersionRequest makes the content of a version the current one, the replaced content becomes a
+// version in turn
+type RestoreFileVersionRequest struct {
+	UserID    string `uri:"id" binding:"required"`
+	FileID    string `uri:"fileID" binding:"required"`
+	VersionID string `uri:"versionID" binding:"required"`
+}
+
+type RestoreFileVersionResponse struct {
+	File *File `json:"file"`
+}
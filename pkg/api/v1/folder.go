@@ -0,0 +1,55 @@
+package v1
+
+type Folder struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parentID,omitempty"` // empty for the folders at the root
+	Name     string `json:"name"`
+	Path     string `json:"path"` // e.g. /documents/2024
+}
+
+// FolderNode is a folder in the tree view of a user's files, the root has no ID and the path /
+type FolderNode struct {
+	ID      string        `json:"id,omitempty"`
+	Name    string        `json:"name"`
+	Path    string        `json:"path"`
+	Folders []*FolderNode `json:"folders"`
+	Files   []*File       `json:"files"`
+}
+
+type ListFoldersRequest struct {
+	UserID string `uri:"id" binding:"required"`
+}
+
+type ListFoldersResponse struct {
+	Folders []*Folder `json:"folders"`
+}
+
+// CreateFolderRequest creates a folder in the parent folder, at the root if there is none
+type CreateFolderRequest struct {
+	UserID   string `json:"-" uri:"id" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	ParentID string `json:"parentID"`
+}
+
+type CreateFolderResponse struct {
+	Folder *Folder `json:"folder"`
+}
+
+// UpdateFolderRequest renames the folder or moves it to another parent if set, an empty parent ID is the root
+type UpdateFolderRequest struct {
+	UserID   string  `json:"-" uri:"id" binding:"required"`
+	FolderID string  `json:"-" uri:"folderID" binding:"required"`
+	Name     *string `json:"name"`
+	ParentID *string `json:"parentID"`
+}
+
+type UpdateFolderResponse struct {
+	Folder *Folder `json:"folder"`
+}
+
+// DeleteFolderRequest deletes an empty folder, or the folder with its subfolders and files if Recursive
+type DeleteFolderRequest struct {
+	UserID    string `uri:"id" binding:"required"`
+	FolderID  string `uri:"folderID" binding:"required"`
+	Recursive bool   `form:"recursive"`
+}
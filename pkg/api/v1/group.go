@@ -0,0 +1,79 @@
+package v1
+
+type Role struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Group struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Roles       []string `json:"roles"`
+}
+
+type ListRolesResponse struct {
+	Roles []*Role `json:"roles"`
+	Count int32   `json:"count"`
+}
+
+type CreateRoleRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type CreateRoleResponse struct {
+	Role *Role `json:"role"`
+}
+
+type DeleteRoleRequest struct {
+	Name string `json:"name" uri:"name" binding:"required"`
+}
+
+type ListGroupsResponse struct {
+	Groups []*Group `json:"groups"`
+	Count  int32    `json:"count"`
+}
+
+type GetGroupRequest struct {
+	ID string `json:"id" uri:"id" binding:"required"`
+}
+
+type GetGroupResponse struct {
+	Group *Group `json:"group"`
+}
+
+type CreateGroupRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Roles       []string `json:"roles"`
+}
+
+type CreateGroupResponse struct {
+	Group *Group `json:"group"`
+}
+
+// UpdateGroupRequest changes the fields that are set, a null or missing roles list keeps the current roles
+type UpdateGroupRequest struct {
+	ID          string   `json:"-" uri:"id" binding:"required"`
+	Name        string   `json:"name" binding:"omitempty"`
+	Description *string  `json:"description"`
+	Roles       []string `json:"roles"`
+}
+
+type UpdateGroupResponse struct {
+	Group *Group `json:"group"`
+}
+
+type DeleteGroupRequest struct {
+	ID string `json:"id" uri:"id" binding:"required"`
+}
+
+type GroupMemberRequest struct {
+	GroupID string `json:"id" uri:"id" binding:"required"`
+	UserID  string `json:"userID" uri:"userID" binding:"required"`
+}
+
+type GroupMemberResponse struct {
+	User *User `json:"user"`
+}
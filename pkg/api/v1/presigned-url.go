@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+// CreatePresignedURLRequest mints a URL to download a file or to upload into the user's space without an
+// Authorization header
+type CreatePresignedURLRequest struct {
+	UserID string `json:"-" uri:"id" binding:"required"`
+	// Action is download or upload
+	Action string `json:"action" binding:"required"`
+	// FileID is the file to download
+	FileID string `json:"fileID"`
+	// ExpiresIn is how long the URL is valid in seconds, the configured default if 0
+	ExpiresIn int64 `json:"expiresIn"`
+	// IP binds the URL to the address of a client
+	IP string `json:"ip"`
+}
+
+type CreatePresignedURLResponse struct {
+	URL       string    `json:"url"`
+	Method    string    `json:"method"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// PresignedURLQuery is the query of a pre-signed URL, ClientIP is the address of the client using it
+type PresignedURLQuery struct {
+	UserID    string `form:"user" binding:"required"`
+	FileID    string `form:"fileID"`
+	Expires   int64  `form:"expires" binding:"required"`
+	IP        string `form:"ip"`
+	Signature string `form:"signature" binding:"required"`
+	ClientIP  string `form:"-"`
+}
+
+type PresignedDownloadRequest struct {
+	PresignedURLQuery
+}
+
+type PresignedUploadRequest struct {
+	PresignedURLQuery
+	File *multipart.FileHeader `form:"file" binding:"required"`
+	// Digest is the SHA-256 of the file as hex or sha-256=<base64>, the upload is rejected if the content doesn't match
+	Digest string `form:"digest"`
+	// FolderID is the folder of the file, the root if empty
+	FolderID string `form:"folderID"`
+}
@@ -0,0 +1,45 @@
+package v1
+
+// StorageUsage is what a user stores and how much the quota allows, 0 is unlimited
+type StorageUsage struct {
+	UserID          string `json:"userID"`
+	UsedBytes       int64  `json:"usedBytes"`
+	FileCount       int64  `json:"fileCount"`
+	QuotaBytes      int64  `json:"quotaBytes"`
+	QuotaFiles      int64  `json:"quotaFiles"`
+	QuotaOverridden bool   `json:"quotaOverridden"`
+}
+
+// StorageTotals is what all the users store together
+type StorageTotals struct {
+	UsedBytes int64 `json:"usedBytes"`
+	FileCount int64 `json:"fileCount"`
+}
+
+type GetStorageUsageRequest struct {
+	UserID string `uri:"id" binding:"required"`
+}
+
+type GetStorageUsageResponse struct {
+	Usage *StorageUsage `json:"usage"`
+}
+
+// SetStorageQuotaRequest overrides the default quota of a user, 0 is unlimited
+type SetStorageQuotaRequest struct {
+	UserID string `json:"-" uri:"id" binding:"required"`
+	Bytes  *int64 `json:"bytes" binding:"required"`
+	Files  *int64 `json:"files" binding:"required"`
+}
+
+type SetStorageQuotaResponse struct {
+	Usage *StorageUsage `json:"usage"`
+}
+
+// DeleteStorageQuotaRequest restores the default quota of a user
+type DeleteStorageQuotaRequest struct {
+	UserID string `uri:"id" binding:"required"`
+}
+
+type DeleteStorageQuotaResponse struct {
+	Usage *StorageUsage `json:"usage"`
+}
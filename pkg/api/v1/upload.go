@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"io"
+	"time"
+)
+
+// Upload is a resumable upload in progress, following the tus 1.0 protocol
+type Upload struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userID"`
+	Filename  string    `json:"filename"`
+	FolderID  string    `json:"folderID,omitempty"` // empty for the root
+	Length    int64     `json:"length"`
+	Offset    int64     `json:"offset"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreateUploadRequest is read from the Upload-Length and Upload-Metadata headers
+type CreateUploadRequest struct {
+	UserID string `uri:"id" binding:"required"`
+	Length int64
+	// Metadata are the decoded Upload-Metadata pairs, filename is required, folderID, filetype and digest are
+	// optional
+	Metadata map[string]string
+}
+
+type CreateUploadResponse struct {
+	Upload *Upload `json:"upload"`
+}
+
+type GetUploadRequest struct {
+	UserID   string `uri:"id" binding:"required"`
+	UploadID string `uri:"uploadID" binding:"required"`
+}
+
+type GetUploadResponse struct {
+	Upload *Upload `json:"upload"`
+}
+
+// PatchUploadRequest is read from the Upload-Offset header and the body
+type PatchUploadRequest struct {
+	UserID   string `uri:"id" binding:"required"`
+	UploadID string `uri:"uploadID" binding:"required"`
+	Offset   int64
+	// ContentLength is the size of the chunk, -1 if unknown
+	ContentLength int64
+	Content       io.Reader
+}
+
+type PatchUploadResponse struct {
+	Upload *Upload `json:"upload"`
+	// File is the stored file once the upload is complete
+	File *File `json:"file,omitempty"`
+}
+
+type DeleteUploadRequest struct {
+	UserID   string `uri:"id" binding:"required"`
+	UploadID string `uri:"uploadID" binding:"required"`
+}
@@ -1,20 +1,33 @@
 package v1
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+	"time"
+)
 
 // DTOs
 type User struct {
-	ID    string  `json:"id"`
-	Name  string  `json:"name"`
-	Email string  `json:"email"`
-	DOB   string  `json:"dob"`
-	Files []*File `json:"files"`
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Email         string          `json:"email"`
+	EmailVerified bool            `json:"emailVerified"`
+	DOB           string          `json:"dob"`
+	Status        string          `json:"status"`
+	StatusReason  string          `json:"statusReason,omitempty"`
+	Roles         []string        `json:"roles"`
+	Attributes    map[string]any  `json:"attributes"`
+	Contacts      []*ContactPoint `json:"contacts"`
+	Addresses     []*Address      `json:"addresses"`
+	AvatarURL     string          `json:"avatarUrl,omitempty"`
+	Files         []*File         `json:"files"`
 }
 
 type CreateUserRequest struct {
 	Name  string `json:"name" binding:"required"`
 	Email string `json:"email" binding:"required,email"`
 	DOB   string `json:"dob" binding:"required"`
+	// Attributes are validated against the deployment's attribute schema
+	Attributes map[string]any `json:"attributes"`
 }
 
 type CreateUserResponse struct {
@@ -26,12 +39,21 @@ type UpdateUserRequest struct {
 	Name  string `json:"name" binding:"omitempty"`
 	Email string `json:"email" binding:"omitempty,email"`
 	DOB   string `json:"dob" binding:"omitempty"`
+	// Attributes replace the current ones when set
+	Attributes map[string]any `json:"attributes"`
 }
 
 type UpdateUserResponse struct {
 	User *User `json:"user"`
 }
 
+type ListUsersRequest struct {
+	EmailVerified *bool  `form:"emailVerified"`
+	GroupID       string `form:"group"`
+	// Attributes filters on attribute values, e.g. ?attr[department]=sales
+	Attributes map[string]string `form:"-"`
+}
+
 type ListUsersResponse struct {
 	Users []*User `json:"users"`
 	Count int32   `json:"count"`
@@ -50,30 +72,265 @@ type DeleteUserRequest struct {
 }
 
 type File struct {
-	ID     string `json:"id"`
-	UserID string `json:"userID"`
-	Name   string `json:"name"`
-	Path   string `json:"path"`
-	Size   int64  `json:"size"`
+	ID           string            `json:"id"`
+	UserID       string            `json:"userID"`
+	Name         string            `json:"name"`
+	Path         string            `json:"path"`
+	Size         int64             `json:"size"`
+	ContentType  string            `json:"contentType,omitempty"`
+	DeclaredType string            `json:"declaredType,omitempty"`
+	Digest       string            `json:"digest,omitempty"` // hex SHA-256 of the content
+	Corrupted    bool              `json:"corrupted,omitempty"`
+	ScanStatus   string            `json:"scanStatus,omitempty"` // pending_scan or clean
+	Version      int               `json:"version,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	FolderID     string            `json:"folderID,omitempty"` // empty for the files at the root
 }
 
+// GetFilesRequest lists the files of a user, only those with all the tags and labels if any are given. With a
+// folder path only the files directly in the folder are listed, Tree adds the tree view of the folder.
 type GetFilesRequest struct {
-	UserID string `json:"id" uri:"id" binding:"required"`
+	UserID string   `json:"id" uri:"id" binding:"required"`
+	Tags   []string `form:"tag"`
+	// Labels filters on label values, e.g. ?label[project]=apollo
+	Labels map[string]string `form:"-"`
+	Folder string            `form:"folder"` // e.g. /documents/2024, / is the root
+	Tree   bool              `form:"tree"`
 }
 
 type GetFilesResponse struct {
-	Files []*File `json:"files"`
+	Files []*File     `json:"files"`
+	Tree  *FolderNode `json:"tree,omitempty"`
 }
 
 type UploadFileRequest struct {
 	UserID string                `form:"id" uri:"id" binding:"required"`
 	File   *multipart.FileHeader `form:"file" binding:"required"`
+	// Digest is the SHA-256 of the file as hex or sha-256=<base64>, the upload is rejected if the content doesn't match
+	Digest string `form:"digest"`
+	// FolderID is the folder of the file, the root if empty; a file with the same name in the folder gets the
+	// content as its next version
+	FolderID string `form:"folderID"`
 }
 
 type UploadFileResponse struct {
 	File *File `json:"file"`
+	// Duplicates are the IDs of the user's other files with the same content
+	Duplicates []string `json:"duplicates,omitempty"`
+}
+
+type DeleteFileRequest struct {
+	UserID string `uri:"id" binding:"required"`
+	FileID string `uri:"fileID" binding:"required"`
+}
+
+// UpdateFileRequest changes the fields that are set, tags and labels replace the current ones
+type UpdateFileRequest struct {
+	UserID      string            `json:"-" uri:"id" binding:"required"`
+	FileID      string            `json:"-" uri:"fileID" binding:"required"`
+	Name        *string           `json:"name"`
+	Description *string           `json:"description"`
+	Tags        []string          `json:"tags"`
+	Labels      map[string]string `json:"labels"`
+	FolderID    *string           `json:"folderID"` // moves the file, an empty ID is the root
+}
+
+type UpdateFileResponse struct {
+	File *File `json:"file"`
+}
+
+type DownloadFileRequest struct {
+	UserID string `uri:"id" binding:"required"`
+	FileID string `uri:"fileID" binding:"required"`
+}
+
+type VerifyFileRequest struct {
+	UserID string `uri:"id" binding:"required"`
+	FileID string `uri:"fileID" binding:"required"`
+}
+
+type VerifyFileResponse struct {
+	File *File `json:"file"`
+}
+
+type SetAvatarRequest struct {
+	UserID string                `form:"id" uri:"id" binding:"required"`
+	File   *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+type SetAvatarResponse struct {
+	User *User `json:"user"`
+}
+
+// GetAvatarRequest selects a thumbnail size in pixels, the largest one by default
+type GetAvatarRequest struct {
+	UserID string `uri:"id" binding:"required"`
+	Size   int    `form:"size"`
+}
+
+type DeleteAvatarRequest struct {
+	UserID string `uri:"id" binding:"required"`
 }
 
 type DeleteFilesRequest struct {
 	UserID string `json:"id" uri:"id" binding:"required"`
 }
+
+type Erasure struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"userID"`
+	FilesErased int       `json:"filesErased"`
+	ErasedAt    time.Time `json:"erasedAt"`
+}
+
+type EraseUserRequest struct {
+	ID string `json:"id" uri:"id" binding:"required"`
+}
+
+type EraseUserResponse struct {
+	Erasure *Erasure `json:"erasure"`
+}
+
+type VerifyEmailRequest struct {
+	UserID string `json:"-" uri:"id" binding:"required"`
+	Token  string `json:"token" binding:"required"`
+}
+
+type VerifyEmailResponse struct {
+	User *User `json:"user"`
+}
+
+type SendEmailVerificationRequest struct {
+	UserID string `json:"id" uri:"id" binding:"required"`
+}
+
+type TransitionUserStatusRequest struct {
+	ID     string `json:"-"`
+	Action string `json:"-"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+type TransitionUserStatusResponse struct {
+	User *User `json:"user"`
+}
+
+type SetPasswordRequest struct {
+	UserID   string `json:"-" uri:"id" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	UserID          string `json:"-" uri:"id" binding:"required"`
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required"`
+}
+
+type ContactPoint struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Label    string `json:"label,omitempty"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+type ListContactPointsRequest struct {
+	UserID string `json:"id" uri:"id" binding:"required"`
+}
+
+type ListContactPointsResponse struct {
+	Contacts []*ContactPoint `json:"contacts"`
+	Count    int32           `json:"count"`
+}
+
+type AddContactPointRequest struct {
+	UserID string `json:"-" uri:"id" binding:"required"`
+	Type   string `json:"type" binding:"required,oneof=email phone"`
+	Value  string `json:"value" binding:"required"`
+	Label  string `json:"label"`
+}
+
+type AddContactPointResponse struct {
+	Contact *ContactPoint `json:"contact"`
+}
+
+// UpdateContactPointRequest changes the fields that are set. Primary true promotes the contact point, a verified
+// email address then becomes the user's email. Verified can only be set on phone numbers.
+type UpdateContactPointRequest struct {
+	UserID    string  `json:"-" uri:"id" binding:"required"`
+	ContactID string  `json:"-" uri:"contactID" binding:"required"`
+	Label     *string `json:"label"`
+	Primary   *bool   `json:"primary"`
+	Verified  *bool   `json:"verified"`
+}
+
+type UpdateContactPointResponse struct {
+	User *User `json:"user"`
+}
+
+type ContactPointRequest struct {
+	UserID    string `json:"id" uri:"id" binding:"required"`
+	ContactID string `json:"contactID" uri:"contactID" binding:"required"`
+}
+
+type Address struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Lines      []string `json:"lines"`
+	Locality   string   `json:"locality"`
+	Region     string   `json:"region,omitempty"`
+	PostalCode string   `json:"postalCode,omitempty"`
+	Country    string   `json:"country"`
+}
+
+type ListAddressesRequest struct {
+	UserID string `json:"id" uri:"id" binding:"required"`
+}
+
+type ListAddressesResponse struct {
+	Addresses []*Address `json:"addresses"`
+	Count     int32      `json:"count"`
+}
+
+// AddAddressRequest adds a postal address. Country is an ISO 3166-1 alpha-2 code, the postal code is checked against
+// the format of the country.
+type AddAddressRequest struct {
+	UserID     string   `json:"-" uri:"id" binding:"required"`
+	Type       string   `json:"type" binding:"required,oneof=home work billing shipping other"`
+	Lines      []string `json:"lines" binding:"required"`
+	Locality   string   `json:"locality" binding:"required"`
+	Region     string   `json:"region"`
+	PostalCode string   `json:"postalCode"`
+	Country    string   `json:"country" binding:"required"`
+}
+
+type AddAddressResponse struct {
+	Address *Address `json:"address"`
+}
+
+// UpdateAddressRequest replaces all the fields of an address
+type UpdateAddressRequest struct {
+	UserID     string   `json:"-" uri:"id" binding:"required"`
+	AddressID  string   `json:"-" uri:"addressID" binding:"required"`
+	Type       string   `json:"type" binding:"required,oneof=home work billing shipping other"`
+	Lines      []string `json:"lines" binding:"required"`
+	Locality   string   `json:"locality" binding:"required"`
+	Region     string   `json:"region"`
+	PostalCode string   `json:"postalCode"`
+	Country    string   `json:"country" binding:"required"`
+}
+
+type UpdateAddressResponse struct {
+	Address *Address `json:"address"`
+}
+
+type AddressRequest struct {
+	UserID    string `json:"id" uri:"id" binding:"required"`
+	AddressID string `json:"addressID" uri:"addressID" binding:"required"`
+}
+
+type GetAddressResponse struct {
+	Address *Address `json:"address"`
+}
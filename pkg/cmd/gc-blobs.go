@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/cas"
+	env "github.com/caarlos0/env/v11"
+)
+
+var ErrDedupDisabled = errors.New("content-addressed storage is disabled: set FILE_DEDUP to collect its blobs")
+
+// RunBlobGC deletes the blobs of content-addressed storage that no file points to
+func RunBlobGC() error {
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		log.Printf("failed to parse environment variables: %s", err)
+		return err
+	}
+	if !cfg.FileDedup {
+		return ErrDedupDisabled
+	}
+
+	db, err := newDatabase(&cfg)
+	if err != nil {
+		log.Printf("failed to connect to mysql: %s", err)
+		return err
+	}
+
+	fileRepository, err := newFileRepository(&cfg, db)
+	if err != nil {
+		log.Printf("failed to create file storage: %s", err)
+		return err
+	}
+
+	collected, err := fileRepository.(*cas.CASFileRepository).CollectGarbage()
+	fmt.Printf("Deleted %d unreferenced blobs\n", collected)
+	return err
+}
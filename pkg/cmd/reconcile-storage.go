@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bizio/abc-user-service/internal/application/service"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/internal/infrastructure/mysql"
+	env "github.com/caarlos0/env/v11"
+)
+
+// RunStorageReconciliation compares the file storage with the files of the users and repairs what it finds
+// according to RECONCILE_ORPHANED_BLOBS, RECONCILE_MISSING_BLOBS and RECONCILE_ORPHAN_MIN_AGE
+func RunStorageReconciliation() error {
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		log.Printf("failed to parse environment variables: %s", err)
+		return err
+	}
+
+	policy, err := model.NewReconcilePolicy(cfg.ReconcileOrphans, cfg.ReconcileMissing, cfg.ReconcileOrphanAge)
+	if err != nil {
+		return err
+	}
+
+	db, err := newDatabase(&cfg)
+	if err != nil {
+		log.Printf("failed to connect to mysql: %s", err)
+		return err
+	}
+
+	fileRepository, err := newFileRepository(&cfg, db)
+	if err != nil {
+		log.Printf("failed to create file storage: %s", err)
+		return err
+	}
+
+	reconciliation, err := service.NewReconcileStorageApplicationService(
+		mysql.NewMysqlUserRepository(db), fileRepository, policy).Do()
+	if err != nil {
+		return err
+	}
+	for _, blob := range reconciliation.Orphaned {
+		fmt.Printf("%s, repaired: %t\n", blob, blob.Repaired)
+	}
+	for _, blob := range reconciliation.Missing {
+		fmt.Printf("%s, repaired: %t\n", blob, blob.Repaired)
+	}
+	fmt.Printf("Checked %d blobs of %d users: %d orphaned, %d missing, %d repaired\n", reconciliation.Blobs,
+		reconciliation.Users, len(reconciliation.Orphaned), len(reconciliation.Missing), reconciliation.Repaired())
+	return nil
+}
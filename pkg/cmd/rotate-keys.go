@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	env "github.com/caarlos0/env/v11"
+)
+
+var ErrEncryptionDisabled = errors.New("encryption is disabled: set ENCRYPTION_KEYS or ENCRYPTION_KEY_FILE to rotate its keys")
+
+// RunKeyRotation re-wraps the data keys of encrypted files with the current master key
+func RunKeyRotation() error {
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		log.Printf("failed to parse environment variables: %s", err)
+		return err
+	}
+
+	db, err := newDatabase(&cfg)
+	if err != nil {
+		log.Printf("failed to connect to mysql: %s", err)
+		return err
+	}
+
+	storage, err := newBlobStorage(&cfg)
+	if err != nil {
+		log.Printf("failed to create file storage: %s", err)
+		return err
+	}
+	encryption, err := newEncryptedFileRepository(&cfg, db, storage)
+	if err != nil {
+		log.Printf("failed to create encrypted file storage: %s", err)
+		return err
+	}
+	if encryption == nil {
+		return ErrEncryptionDisabled
+	}
+
+	rotated, err := encryption.RotateKeys()
+	fmt.Printf("Re-wrapped %d data keys with the current master key\n", rotated)
+	return err
+}
@@ -2,10 +2,27 @@ package cmd
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/internal/infrastructure/antivirus"
+	"github.com/bizio/abc-user-service/internal/infrastructure/auth"
+	"github.com/bizio/abc-user-service/internal/infrastructure/mail"
+	"github.com/bizio/abc-user-service/internal/infrastructure/mysql"
 	"github.com/bizio/abc-user-service/internal/infrastructure/rabbitmq"
+	"github.com/bizio/abc-user-service/internal/infrastructure/schema"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/cas"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/encrypted"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/local"
+	"github.com/bizio/abc-user-service/internal/infrastructure/storage/s3"
 	"github.com/bizio/abc-user-service/pkg/protocol/rest"
 	env "github.com/caarlos0/env/v11"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -14,16 +31,73 @@ import (
 )
 
 type Config struct {
-	HTTPPort            string `env:"HTTP_PORT"`
-	DatastoreDBHost     string `env:"DB_HOST"`
-	DatastoreDBPort     string `env:"DB_PORT"`
-	DatastoreDBUser     string `env:"DB_USER"`
-	DatastoreDBPassword string `env:"DB_PASSWORD"`
-	DatastoreDBName     string `env:"DB_NAME"`
-	QueueUser           string `env:"QUEUE_USER"`
-	QueuePassword       string `env:"QUEUE_PASSWORD"`
-	QueueHost           string `env:"QUEUE_HOST"`
-	QueuePort           string `env:"QUEUE_PORT"`
+	HTTPPort            string            `env:"HTTP_PORT"`
+	DatastoreDBHost     string            `env:"DB_HOST"`
+	DatastoreDBPort     string            `env:"DB_PORT"`
+	DatastoreDBUser     string            `env:"DB_USER"`
+	DatastoreDBPassword string            `env:"DB_PASSWORD"`
+	DatastoreDBName     string            `env:"DB_NAME"`
+	QueueUser           string            `env:"QUEUE_USER"`
+	QueuePassword       string            `env:"QUEUE_PASSWORD"`
+	QueueHost           string            `env:"QUEUE_HOST"`
+	QueuePort           string            `env:"QUEUE_PORT"`
+	ExportTTL           time.Duration     `env:"EXPORT_TTL" envDefault:"24h"`
+	VerificationTTL     time.Duration     `env:"VERIFICATION_TTL" envDefault:"24h"`
+	Mailer              string            `env:"MAILER" envDefault:"stdout"`
+	MailFrom            string            `env:"MAIL_FROM" envDefault:"no-reply@abc.local"`
+	MailFile            string            `env:"MAIL_FILE"`
+	SMTPHost            string            `env:"SMTP_HOST"`
+	SMTPPort            string            `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUser            string            `env:"SMTP_USER"`
+	SMTPPassword        string            `env:"SMTP_PASSWORD"`
+	JWTSecret           string            `env:"JWT_SECRET"`
+	JWTIssuer           string            `env:"JWT_ISSUER" envDefault:"abc-user-service"`
+	JWTTTL              time.Duration     `env:"JWT_TTL" envDefault:"1h"`
+	PasswordResetTTL    time.Duration     `env:"PASSWORD_RESET_TTL" envDefault:"1h"`
+	LoginMaxAttempts    int               `env:"LOGIN_MAX_ATTEMPTS" envDefault:"5"`
+	LoginLockout        time.Duration     `env:"LOGIN_LOCKOUT" envDefault:"15m"`
+	AttributeSchema     string            `env:"ATTRIBUTE_SCHEMA"` // path to a JSON Schema, any attributes are accepted if empty
+	UploadAllow         []string          `env:"UPLOAD_ALLOW"`     // media ranges, e.g. image/*, any type not denied is allowed if empty
+	UploadDeny          []string          `env:"UPLOAD_DENY" envDefault:"application/vnd.microsoft.portable-executable,application/x-elf,application/x-executable,application/x-sharedlib,application/x-mach-binary,text/x-shellscript,text/x-php,text/html,image/svg+xml"`
+	UploadMaxSize       int64             `env:"UPLOAD_MAX_SIZE" envDefault:"2097152"`
+	UploadMaxSizes      map[string]int64  `env:"UPLOAD_MAX_SIZES"`                     // per media range, e.g. image/*:5242880,video/mp4:104857600
+	FileScrubInterval   time.Duration     `env:"FILE_SCRUB_INTERVAL" envDefault:"24h"` // 0 disables the scrubber
+	UploadTTL           time.Duration     `env:"UPLOAD_TTL" envDefault:"24h"`
+	StorageQuotaBytes   int64             `env:"STORAGE_QUOTA_BYTES" envDefault:"1073741824"` // default per-user quota, 0 is unlimited
+	StorageQuotaFiles   int64             `env:"STORAGE_QUOTA_FILES" envDefault:"0"`
+	FileStorage         string            `env:"FILE_STORAGE" envDefault:"local"`
+	FileStorageDir      string            `env:"FILE_STORAGE_DIR"` // the temporary directory if empty
+	S3Endpoint          string            `env:"S3_ENDPOINT"`      // AWS's endpoint of the region if empty
+	S3Region            string            `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket            string            `env:"S3_BUCKET"`
+	S3Prefix            string            `env:"S3_PREFIX"`
+	S3PathStyle         bool              `env:"S3_PATH_STYLE"` // required by MinIO
+	S3AccessKeyID       string            `env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey   string            `env:"S3_SECRET_ACCESS_KEY"`
+	S3SessionToken      string            `env:"S3_SESSION_TOKEN"`
+	S3SSE               string            `env:"S3_SSE"` // AES256 or aws:kms, the bucket's default if empty
+	S3SSEKMSKeyID       string            `env:"S3_SSE_KMS_KEY_ID"`
+	S3PartSize          int64             `env:"S3_PART_SIZE" envDefault:"8388608"`
+	FileDedup           bool              `env:"FILE_DEDUP"`          // store identical content once across users
+	EncryptionKeys      map[string]string `env:"ENCRYPTION_KEYS"`     // master keys as id:base64, files are encrypted at rest if any
+	EncryptionKeyFile   string            `env:"ENCRYPTION_KEY_FILE"` // file with a master key per line, as id:base64
+	EncryptionKeyID     string            `env:"ENCRYPTION_KEY_ID"`   // master key new data keys are wrapped with, required if there are several
+	PresignSecret       string            `env:"PRESIGN_SECRET"`
+	PresignBaseURL      string            `env:"PRESIGN_BASE_URL"` // e.g. https://api.example.com, the URLs are relative if empty
+	PresignTTL          time.Duration     `env:"PRESIGN_TTL" envDefault:"15m"`
+	PresignMaxTTL       time.Duration     `env:"PRESIGN_MAX_TTL" envDefault:"168h"`
+	Scanner             string            `env:"SCANNER" envDefault:"none"` // none, clamd or fake, which only detects the EICAR test file
+	ClamdAddress        string            `env:"CLAMD_ADDRESS" envDefault:"tcp://localhost:3310"`
+	ClamdTimeout        time.Duration     `env:"CLAMD_TIMEOUT" envDefault:"30s"`
+	ScanAsync           bool              `env:"SCAN_ASYNC"` // files are downloadable once they are scanned
+	ScanInterval        time.Duration     `env:"SCAN_INTERVAL" envDefault:"10s"`
+	QuarantineDir       string            `env:"QUARANTINE_DIR"`                         // quarantine in the file storage if empty
+	VersionRetention    int               `env:"FILE_VERSION_RETENTION" envDefault:"10"` // prior versions kept per file, 0 keeps none
+	ReconcileOrphans    string            `env:"RECONCILE_ORPHANED_BLOBS" envDefault:"report"`
+	ReconcileMissing    string            `env:"RECONCILE_MISSING_BLOBS" envDefault:"report"`
+	ReconcileOrphanAge  time.Duration     `env:"RECONCILE_ORPHAN_MIN_AGE" envDefault:"24h"` // younger orphaned blobs can be uploads in progress
+	ReconcileInterval   time.Duration     `env:"RECONCILE_INTERVAL"`                        // 0 only reconciles with the reconcile-storage command
+	TrustedProxies      []string          `env:"TRUSTED_PROXIES"`                           // addresses or CIDR ranges, X-Forwarded-For is ignored if empty
 }
 
 // RunServer runs HTTP gateway
@@ -38,16 +112,7 @@ func RunServer() error {
 		return err
 	}
 
-	param := "charset=utf8mb4&parseTime=True&loc=Local"
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s",
-		cfg.DatastoreDBUser,
-		cfg.DatastoreDBPassword,
-		cfg.DatastoreDBHost,
-		cfg.DatastoreDBPort,
-		cfg.DatastoreDBName,
-		param)
-
-	db, err := gorm.Open(gormMysql.Open(dsn), &gorm.Config{})
+	db, err := newDatabase(&cfg)
 	if err != nil {
 		log.Printf("failed to connect to mysql: %s", err)
 		panic(err)
@@ -91,6 +156,252 @@ func RunServer() error {
 		}
 	}()
 
+	mailer, err := newMailer(&cfg)
+	if err != nil {
+		log.Printf("failed to create mailer: %s", err)
+		return err
+	}
+
+	fileRepository, err := newFileRepository(&cfg, db)
+	if err != nil {
+		log.Printf("failed to create file storage: %s", err)
+		return err
+	}
+
+	scanner, err := newScanner(&cfg)
+	if err != nil {
+		log.Printf("failed to create malware scanner: %s", err)
+		return err
+	}
+	quarantineRepository, err := newQuarantineStorage(&cfg)
+	if err != nil {
+		log.Printf("failed to create quarantine storage: %s", err)
+		return err
+	}
+
+	avatarRepository, err := newAvatarStorage(&cfg)
+	if err != nil {
+		log.Printf("failed to create avatar storage: %s", err)
+		return err
+	}
+
+	jwtSecret := []byte(cfg.JWTSecret)
+	if len(jwtSecret) == 0 {
+		log.Printf("JWT_SECRET is not set, using a random secret: access tokens won't survive a restart")
+		jwtSecret = make([]byte, 32)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			return err
+		}
+	}
+	tokenIssuer := auth.NewJWTIssuer(jwtSecret, cfg.JWTIssuer, cfg.JWTTTL)
+
+	presignSecret := []byte(cfg.PresignSecret)
+	if len(presignSecret) == 0 {
+		log.Printf("PRESIGN_SECRET is not set, using a random secret: pre-signed URLs won't survive a restart")
+		presignSecret = make([]byte, 32)
+		if _, err := rand.Read(presignSecret); err != nil {
+			return err
+		}
+	}
+	urlSigner := auth.NewHMACSigner(presignSecret)
+
+	attributeSchema, err := schema.LoadJSONSchema(cfg.AttributeSchema)
+	if err != nil {
+		log.Printf("failed to load attribute schema: %s", err)
+		return err
+	}
+
+	uploadPolicy, err := model.NewUploadPolicy(cfg.UploadAllow, cfg.UploadDeny, cfg.UploadMaxSize, cfg.UploadMaxSizes)
+	if err != nil {
+		log.Printf("failed to create upload policy: %s", err)
+		return err
+	}
+
+	storageQuota, err := model.NewStorageQuota(cfg.StorageQuotaBytes, cfg.StorageQuotaFiles)
+	if err != nil {
+		log.Printf("failed to create storage quota: %s", err)
+		return err
+	}
+
+	if cfg.VersionRetention < 0 {
+		return fmt.Errorf("invalid file version retention: '%d'", cfg.VersionRetention)
+	}
+
+	reconcilePolicy, err := model.NewReconcilePolicy(cfg.ReconcileOrphans, cfg.ReconcileMissing, cfg.ReconcileOrphanAge)
+	if err != nil {
+		return err
+	}
+
+	for _, proxy := range cfg.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return fmt.Errorf("invalid trusted proxy: '%s'", proxy)
+		}
+	}
+
+	settings := &rest.Settings{
+		ExportTTL:          cfg.ExportTTL,
+		VerificationTTL:    cfg.VerificationTTL,
+		PasswordResetTTL:   cfg.PasswordResetTTL,
+		LoginMaxAttempts:   cfg.LoginMaxAttempts,
+		LoginLockout:       cfg.LoginLockout,
+		UploadPolicy:       uploadPolicy,
+		FileScrubInterval:  cfg.FileScrubInterval,
+		UploadTTL:          cfg.UploadTTL,
+		StorageQuota:       storageQuota,
+		PresignedURLBase:   cfg.PresignBaseURL,
+		PresignedURLTTL:    cfg.PresignTTL,
+		PresignedURLMaxTTL: cfg.PresignMaxTTL,
+		ScanAsync:          cfg.ScanAsync,
+		ScanInterval:       cfg.ScanInterval,
+		VersionRetention:   cfg.VersionRetention,
+		ReconcilePolicy:    reconcilePolicy,
+		ReconcileInterval:  cfg.ReconcileInterval,
+		TrustedProxies:     cfg.TrustedProxies,
+	}
+
 	fmt.Printf("Starting HTTP/REST gateway on port %s...\n", cfg.HTTPPort)
-	return rest.RunServer(ctx, cfg.HTTPPort, db, channel)
+	return rest.RunServer(ctx, cfg.HTTPPort, db, channel, mailer, fileRepository, scanner, quarantineRepository,
+		avatarRepository, tokenIssuer, urlSigner, attributeSchema, settings)
+}
+
+// newMailer creates the configured mailer: smtp, file or stdout
+func newMailer(cfg *Config) (domain.Mailer, error) {
+	switch cfg.Mailer {
+	case "smtp":
+		return mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.MailFrom), nil
+	case "file":
+		f, err := os.OpenFile(cfg.MailFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return mail.NewWriterMailer(cfg.MailFrom, f), nil
+	case "stdout":
+		return mail.NewWriterMailer(cfg.MailFrom, os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("invalid mailer: '%s'", cfg.Mailer)
+	}
+}
+
+// newScanner creates the configured malware scanner: clamd, fake or none, which is nil
+func newScanner(cfg *Config) (domain.Scanner, error) {
+	switch cfg.Scanner {
+	case "clamd":
+		return antivirus.NewClamdScanner(cfg.ClamdAddress, cfg.ClamdTimeout)
+	case "fake":
+		return antivirus.NewFakeScanner(), nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("invalid scanner: '%s'", cfg.Scanner)
+	}
+}
+
+// newQuarantineStorage creates the storage of the infected files, apart from the users' files. It is the
+// configured file storage under a quarantine prefix, unless there is a quarantine directory.
+func newQuarantineStorage(cfg *Config) (domain.FileRepository, error) {
+	if cfg.QuarantineDir != "" {
+		return local.NewLocalFileRepository(cfg.QuarantineDir), nil
+	}
+	quarantineCfg := *cfg
+	quarantineCfg.FileStorageDir = filepath.Join(cfg.FileStorageDir, "quarantine")
+	if cfg.FileStorageDir == "" {
+		quarantineCfg.FileStorageDir = filepath.Join(os.TempDir(), "quarantine")
+	}
+	quarantineCfg.S3Prefix = cfg.S3Prefix + "quarantine/"
+	return newBlobStorage(&quarantineCfg)
+}
+
+// newAvatarStorage stores the avatars apart from the files, in the configured storage
+func newAvatarStorage(cfg *Config) (domain.FileRepository, error) {
+	avatarCfg := *cfg
+	avatarCfg.FileStorageDir = filepath.Join(cfg.FileStorageDir, "avatars")
+	if cfg.FileStorageDir == "" {
+		avatarCfg.FileStorageDir = filepath.Join(os.TempDir(), "avatars")
+	}
+	avatarCfg.S3Prefix = cfg.S3Prefix + "avatars/"
+	return newBlobStorage(&avatarCfg)
+}
+
+// newDatabase connects to the configured MySQL database
+func newDatabase(cfg *Config) (*gorm.DB, error) {
+	param := "charset=utf8mb4&parseTime=True&loc=Local"
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s",
+		cfg.DatastoreDBUser,
+		cfg.DatastoreDBPassword,
+		cfg.DatastoreDBHost,
+		cfg.DatastoreDBPort,
+		cfg.DatastoreDBName,
+		param)
+
+	// duplicate keys are reported as gorm.ErrDuplicatedKey, the repositories map them to domain errors
+	return gorm.Open(gormMysql.Open(dsn), &gorm.Config{TranslateError: true})
+}
+
+// newFileRepository creates the configured file storage: local or s3, encrypted if there are master keys and
+// content-addressed if deduplication is on. Blobs are encrypted rather than files, so deduplication still works.
+func newFileRepository(cfg *Config, db *gorm.DB) (domain.FileRepository, error) {
+	storage, err := newBlobStorage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if encryption, err := newEncryptedFileRepository(cfg, db, storage); err != nil {
+		return nil, err
+	} else if encryption != nil {
+		storage = encryption
+	}
+	if !cfg.FileDedup {
+		return storage, nil
+	}
+	return cas.NewCASFileRepository(storage, mysql.NewMysqlBlobRepository(db), os.TempDir()), nil
+}
+
+// newEncryptedFileRepository encrypts the storage with the configured master keys, nil if there are none
+func newEncryptedFileRepository(cfg *Config, db *gorm.DB, storage domain.FileRepository) (*encrypted.EncryptedFileRepository, error) {
+	keys := map[string]string{}
+	if cfg.EncryptionKeyFile != "" {
+		var err error
+		if keys, err = encrypted.ReadKeyFile(cfg.EncryptionKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	for id, key := range cfg.EncryptionKeys {
+		keys[id] = key
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	keyring, err := encrypted.NewKeyring(keys, cfg.EncryptionKeyID)
+	if err != nil {
+		return nil, err
+	}
+	return encrypted.NewEncryptedFileRepository(storage, mysql.NewMysqlDataKeyRepository(db), keyring), nil
+}
+
+// newBlobStorage creates the storage the content of files is written to
+func newBlobStorage(cfg *Config) (domain.FileRepository, error) {
+	switch cfg.FileStorage {
+	case "local":
+		dir := cfg.FileStorageDir
+		if dir == "" {
+			dir = os.TempDir()
+		}
+		return local.NewLocalFileRepository(dir), nil
+	case "s3":
+		return s3.NewS3FileRepository(s3.Config{
+			Endpoint:             cfg.S3Endpoint,
+			Region:               cfg.S3Region,
+			Bucket:               cfg.S3Bucket,
+			Prefix:               cfg.S3Prefix,
+			PathStyle:            cfg.S3PathStyle,
+			AccessKeyID:          cfg.S3AccessKeyID,
+			SecretAccessKey:      cfg.S3SecretAccessKey,
+			SessionToken:         cfg.S3SessionToken,
+			ServerSideEncryption: cfg.S3SSE,
+			KMSKeyID:             cfg.S3SSEKMSKeyID,
+			PartSize:             cfg.S3PartSize,
+		}, &http.Client{})
+	default:
+		return nil, fmt.Errorf("invalid file storage: '%s'", cfg.FileStorage)
+	}
 }
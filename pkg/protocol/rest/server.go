@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"expvar"
 	"log"
 	"net/http"
 	"os"
@@ -9,41 +10,256 @@ import (
 	"time"
 
 	service "github.com/bizio/abc-user-service/internal/application/service"
+	"github.com/bizio/abc-user-service/internal/domain"
+	"github.com/bizio/abc-user-service/internal/domain/model"
+	"github.com/bizio/abc-user-service/internal/infrastructure/auth"
 	infraHttp "github.com/bizio/abc-user-service/internal/infrastructure/http/gin"
+	"github.com/bizio/abc-user-service/internal/infrastructure/imaging"
 	"github.com/bizio/abc-user-service/internal/infrastructure/mysql"
 	"github.com/bizio/abc-user-service/internal/infrastructure/rabbitmq"
+	v1 "github.com/bizio/abc-user-service/pkg/api/v1"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"gorm.io/gorm"
 
 	"github.com/bizio/abc-user-service/internal/infrastructure/storage/local"
 )
 
+// Settings tunes the application services of the HTTP/REST gateway
+type Settings struct {
+	ExportTTL        time.Duration
+	VerificationTTL  time.Duration
+	PasswordResetTTL time.Duration
+	LoginMaxAttempts int
+	LoginLockout     time.Duration
+	UploadPolicy     *model.UploadPolicy
+	// FileScrubInterval is how often the stored files are re-hashed, 0 disables the scrubber
+	FileScrubInterval time.Duration
+	// UploadTTL is how long a resumable upload can be idle before it expires
+	UploadTTL time.Duration
+	// StorageQuota applies to the users without a quota of their own
+	StorageQuota *model.StorageQuota
+	// PresignedURLBase is prepended to the pre-signed URLs, they are relative if empty
+	PresignedURLBase string
+	// PresignedURLTTL is how long a pre-signed URL is valid unless the client asks otherwise, up to PresignedURLMaxTTL
+	PresignedURLTTL    time.Duration
+	PresignedURLMaxTTL time.Duration
+	// ScanAsync adds the files pending a malware scan instead of scanning them during the upload
+	ScanAsync bool
+	// ScanInterval is how often the files pending a scan are scanned in async mode
+	ScanInterval time.Duration
+	// VersionRetention is how many prior versions are kept per file, 0 keeps none
+	VersionRetention int
+	// ReconcilePolicy tells how the scheduled reconciliation repairs the storage
+	ReconcilePolicy *model.ReconcilePolicy
+	// ReconcileInterval is how often the storage is reconciled with the files, 0 disables it
+	ReconcileInterval time.Duration
+	// TrustedProxies are the addresses or CIDR ranges of the proxies whose X-Forwarded-For is trusted, none if empty
+	TrustedProxies []string
+}
+
+// uploadPurgeInterval is how often the expired resumable uploads are discarded
+const uploadPurgeInterval = time.Hour
+
+// exportPurgeInterval is how often the archives of the expired exports are deleted
+const exportPurgeInterval = time.Hour
+
 // RunServer runs HTTP/REST gateway
-func RunServer(ctx context.Context, httpPort string, db *gorm.DB, channel *amqp.Channel) error {
+func RunServer(
+	ctx context.Context,
+	httpPort string,
+	db *gorm.DB,
+	channel *amqp.Channel,
+	mailer domain.Mailer,
+	fileRepository domain.FileRepository,
+	scanner domain.Scanner,
+	quarantineRepository domain.FileRepository,
+	avatarRepository domain.FileRepository,
+	tokenIssuer domain.TokenIssuer,
+	urlSigner domain.URLSigner,
+	attributeSchema domain.AttributeSchema,
+	settings *Settings,
+) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	var maxFileSize int64 = 2 << 20 // 2 MB
-	localFileRepository := local.NewLocalFileRepository(os.TempDir())
+	var maxAvatarSize int64 = 5 << 20 // 5 MB
+	localArchiveRepository := local.NewLocalArchiveRepository(os.TempDir())
+	localPartialUploadRepository := local.NewLocalPartialUploadRepository(os.TempDir())
 	mysqlRepository := mysql.NewMysqlUserRepository(db)
+	mysqlExportRepository := mysql.NewMysqlExportRepository(db)
+	mysqlUploadRepository := mysql.NewMysqlUploadRepository(db)
+	mysqlVerificationTokenRepository := mysql.NewMysqlVerificationTokenRepository(db)
+	mysqlCredentialRepository := mysql.NewMysqlCredentialRepository(db)
+	mysqlPasswordResetTokenRepository := mysql.NewMysqlPasswordResetTokenRepository(db)
+	mysqlGroupRepository := mysql.NewMysqlGroupRepository(db)
+	mysqlRoleRepository := mysql.NewMysqlRoleRepository(db)
+	mysqlAttributeSchemaRepository := mysql.NewMysqlAttributeSchemaRepository(db)
+	argon2Hasher := auth.NewArgon2Hasher(auth.DefaultArgon2Params)
+	thumbnailer := imaging.NewThumbnailer(imaging.DefaultMaxPixels)
 	rabbitmqPublisher := rabbitmq.NewRabbitMQPublisher("user_events", channel)
 
+	// refuse to serve with a schema that existing users don't match
+	applyAttributeSchemaApplicationService := service.NewApplyAttributeSchemaApplicationService(
+		mysqlRepository, mysqlAttributeSchemaRepository, attributeSchema)
+	if err := applyAttributeSchemaApplicationService.Do(); err != nil {
+		return err
+	}
+
+	emailVerifier := service.NewEmailVerifier(mysqlVerificationTokenRepository, mailer, settings.VerificationTTL)
+
 	listApplicationService := service.NewListUsersApplicationService(mysqlRepository)
 	getApplicationService := service.NewGetUserApplicationService(mysqlRepository)
-	createApplicationService := service.NewCreateUserApplicationService(mysqlRepository, rabbitmqPublisher)
-	updateApplicationService := service.NewUpdateUserApplicationService(mysqlRepository, rabbitmqPublisher)
-	deleteApplicationService := service.NewDeleteUserApplicationService(mysqlRepository, localFileRepository, rabbitmqPublisher)
+	createApplicationService := service.NewCreateUserApplicationService(mysqlRepository, rabbitmqPublisher, emailVerifier, attributeSchema)
+	updateApplicationService := service.NewUpdateUserApplicationService(mysqlRepository, rabbitmqPublisher, emailVerifier, attributeSchema)
+	verifyEmailApplicationService := service.NewVerifyEmailApplicationService(mysqlRepository, mysqlVerificationTokenRepository, rabbitmqPublisher)
+	sendEmailVerificationApplicationService := service.NewSendEmailVerificationApplicationService(mysqlRepository, emailVerifier)
+	deleteApplicationService := service.NewDeleteUserApplicationService(
+		mysqlRepository, fileRepository, avatarRepository, rabbitmqPublisher)
+	eraseApplicationService := service.NewEraseUserApplicationService(
+		mysqlRepository, mysqlCredentialRepository, fileRepository, avatarRepository, quarantineRepository,
+		mysqlExportRepository, localArchiveRepository, mysqlUploadRepository, localPartialUploadRepository, rabbitmqPublisher)
+	transitionApplicationService := service.NewTransitionUserStatusApplicationService(mysqlRepository, rabbitmqPublisher)
+
+	setPasswordApplicationService := service.NewSetPasswordApplicationService(mysqlRepository, mysqlCredentialRepository, argon2Hasher)
+	changePasswordApplicationService := service.NewChangePasswordApplicationService(mysqlCredentialRepository, argon2Hasher)
+	requestPasswordResetApplicationService := service.NewRequestPasswordResetApplicationService(
+		mysqlRepository, mysqlPasswordResetTokenRepository, mailer, settings.PasswordResetTTL)
+	resetPasswordApplicationService := service.NewResetPasswordApplicationService(
+		mysqlCredentialRepository, mysqlPasswordResetTokenRepository, argon2Hasher)
+	loginApplicationService := service.NewLoginApplicationService(
+		mysqlRepository, mysqlCredentialRepository, argon2Hasher, tokenIssuer, settings.LoginMaxAttempts, settings.LoginLockout)
+
+	listRolesApplicationService := service.NewListRolesApplicationService(mysqlRoleRepository)
+	createRoleApplicationService := service.NewCreateRoleApplicationService(mysqlRoleRepository)
+	deleteRoleApplicationService := service.NewDeleteRoleApplicationService(mysqlRoleRepository)
+	listGroupsApplicationService := service.NewListGroupsApplicationService(mysqlGroupRepository)
+	getGroupApplicationService := service.NewGetGroupApplicationService(mysqlGroupRepository)
+	createGroupApplicationService := service.NewCreateGroupApplicationService(mysqlGroupRepository, mysqlRoleRepository)
+	updateGroupApplicationService := service.NewUpdateGroupApplicationService(
+		mysqlGroupRepository, mysqlRoleRepository, mysqlRepository, rabbitmqPublisher)
+	deleteGroupApplicationService := service.NewDeleteGroupApplicationService(mysqlGroupRepository, mysqlRepository, rabbitmqPublisher)
+	addGroupMemberApplicationService := service.NewAddGroupMemberApplicationService(mysqlGroupRepository, mysqlRepository, rabbitmqPublisher)
+	removeGroupMemberApplicationService := service.NewRemoveGroupMemberApplicationService(mysqlGroupRepository, mysqlRepository, rabbitmqPublisher)
+
+	listContactPointsApplicationService := service.NewListContactPointsApplicationService(mysqlRepository)
+	addContactPointApplicationService := service.NewAddContactPointApplicationService(mysqlRepository, rabbitmqPublisher, emailVerifier)
+	updateContactPointApplicationService := service.NewUpdateContactPointApplicationService(mysqlRepository, rabbitmqPublisher)
+	deleteContactPointApplicationService := service.NewDeleteContactPointApplicationService(mysqlRepository, rabbitmqPublisher)
+	sendContactPointVerificationApplicationService := service.NewSendContactPointVerificationApplicationService(mysqlRepository, emailVerifier)
+
+	listAddressesApplicationService := service.NewListAddressesApplicationService(mysqlRepository)
+	getAddressApplicationService := service.NewGetAddressApplicationService(mysqlRepository)
+	addAddressApplicationService := service.NewAddAddressApplicationService(mysqlRepository, rabbitmqPublisher)
+	updateAddressApplicationService := service.NewUpdateAddressApplicationService(mysqlRepository, rabbitmqPublisher)
+	deleteAddressApplicationService := service.NewDeleteAddressApplicationService(mysqlRepository, rabbitmqPublisher)
+
+	setAvatarApplicationService := service.NewSetAvatarApplicationService(
+		mysqlRepository, avatarRepository, thumbnailer, rabbitmqPublisher, maxAvatarSize)
+	getAvatarApplicationService := service.NewGetAvatarApplicationService(mysqlRepository, avatarRepository)
+	deleteAvatarApplicationService := service.NewDeleteAvatarApplicationService(mysqlRepository, avatarRepository, rabbitmqPublisher)
+
+	// files aren't scanned without a scanner
+	fileScanner := service.NewFileScanner(scanner, quarantineRepository, rabbitmqPublisher, settings.ScanAsync)
+	fileVersions := service.NewFileVersions(mysqlRepository, fileRepository, settings.VersionRetention)
 
 	getFilesApplicationService := service.NewGetFilesApplicationService(mysqlRepository)
-	addFileApplicationService := service.NewAddFileApplicationService(mysqlRepository, localFileRepository, int64(maxFileSize))
-	deleteFilesApplicationService := service.NewDeleteFilesApplicationService(mysqlRepository, localFileRepository)
+	addFileApplicationService := service.NewAddFileApplicationService(
+		mysqlRepository, fileRepository, settings.UploadPolicy, settings.StorageQuota, fileScanner, fileVersions)
+	deleteFilesApplicationService := service.NewDeleteFilesApplicationService(mysqlRepository, fileRepository)
+	deleteFileApplicationService := service.NewDeleteFileApplicationService(mysqlRepository, fileRepository)
+	downloadFileApplicationService := service.NewDownloadFileApplicationService(mysqlRepository, fileRepository)
+	verifyFileApplicationService := service.NewVerifyFileApplicationService(mysqlRepository, fileRepository)
+	downloadFilesApplicationService := service.NewDownloadFilesApplicationService(mysqlRepository, fileRepository)
+	updateFileApplicationService := service.NewUpdateFileApplicationService(mysqlRepository, rabbitmqPublisher)
+	listFoldersApplicationService := service.NewListFoldersApplicationService(mysqlRepository)
+	createFolderApplicationService := service.NewCreateFolderApplicationService(mysqlRepository)
+	updateFolderApplicationService := service.NewUpdateFolderApplicationService(mysqlRepository)
+	deleteFolderApplicationService := service.NewDeleteFolderApplicationService(mysqlRepository, fileRepository)
+
+	uploadLocks := service.NewUploadLocks()
+	createUploadApplicationService := service.NewCreateUploadApplicationService(
+		mysqlRepository, mysqlUploadRepository, localPartialUploadRepository, settings.UploadPolicy, settings.StorageQuota, settings.UploadTTL)
+	getUploadApplicationService := service.NewGetUploadApplicationService(mysqlUploadRepository)
+	patchUploadApplicationService := service.NewPatchUploadApplicationService(
+		mysqlRepository, mysqlUploadRepository, localPartialUploadRepository, fileRepository,
+		settings.UploadPolicy, settings.StorageQuota, fileScanner, fileVersions, uploadLocks, settings.UploadTTL)
+	deleteUploadApplicationService := service.NewDeleteUploadApplicationService(
+		mysqlUploadRepository, localPartialUploadRepository, uploadLocks)
+	purgeUploadsApplicationService := service.NewPurgeUploadsApplicationService(
+		mysqlUploadRepository, localPartialUploadRepository, uploadLocks)
+
+	getStorageUsageApplicationService := service.NewGetStorageUsageApplicationService(mysqlRepository, settings.StorageQuota)
+	setStorageQuotaApplicationService := service.NewSetStorageQuotaApplicationService(mysqlRepository, settings.StorageQuota)
+	deleteStorageQuotaApplicationService := service.NewDeleteStorageQuotaApplicationService(mysqlRepository, settings.StorageQuota)
+	// served with the other metrics at /debug/vars
+	expvar.Publish("storage", expvar.Func(func() any {
+		totals, err := mysqlRepository.GetStorageTotals()
+		if err != nil {
+			return err.Error()
+		}
+		return &v1.StorageTotals{UsedBytes: totals.Bytes, FileCount: totals.Files}
+	}))
+
+	createPresignedURLApplicationService := service.NewCreatePresignedURLApplicationService(
+		mysqlRepository, urlSigner, settings.PresignedURLBase, settings.PresignedURLTTL, settings.PresignedURLMaxTTL)
+	presignedDownloadApplicationService := service.NewPresignedDownloadApplicationService(urlSigner, downloadFileApplicationService)
+	presignedUploadApplicationService := service.NewPresignedUploadApplicationService(urlSigner, addFileApplicationService)
+
+	listFileVersionsApplicationService := service.NewListFileVersionsApplicationService(mysqlRepository)
+	downloadFileVersionApplicationService := service.NewDownloadFileVersionApplicationService(mysqlRepository, fileRepository)
+	restoreFileVersionApplicationService := service.NewRestoreFileVersionApplicationService(
+		mysqlRepository, fileVersions, settings.StorageQuota)
+
+	exportApplicationService := service.NewExportUserApplicationService(
+		mysqlRepository, mysqlExportRepository, fileRepository, localArchiveRepository, settings.ExportTTL)
+	getExportApplicationService := service.NewGetExportApplicationService(mysqlExportRepository)
+	downloadExportApplicationService := service.NewDownloadExportApplicationService(mysqlExportRepository, localArchiveRepository)
+	purgeExportsApplicationService := service.NewPurgeExportsApplicationService(mysqlExportRepository, localArchiveRepository)
 
 	httpService := infraHttp.NewGinHttpService(
 		listApplicationService, getApplicationService, createApplicationService, updateApplicationService,
-		deleteApplicationService, getFilesApplicationService, addFileApplicationService, deleteFilesApplicationService,
-		maxFileSize,
+		deleteApplicationService, eraseApplicationService, transitionApplicationService,
+		getFilesApplicationService, addFileApplicationService, deleteFilesApplicationService,
+		exportApplicationService, getExportApplicationService, downloadExportApplicationService,
+		verifyEmailApplicationService, sendEmailVerificationApplicationService,
+		setPasswordApplicationService, changePasswordApplicationService,
+		requestPasswordResetApplicationService, resetPasswordApplicationService, loginApplicationService,
+		listRolesApplicationService, createRoleApplicationService, deleteRoleApplicationService,
+		listGroupsApplicationService, getGroupApplicationService, createGroupApplicationService,
+		updateGroupApplicationService, deleteGroupApplicationService,
+		addGroupMemberApplicationService, removeGroupMemberApplicationService,
+		listContactPointsApplicationService, addContactPointApplicationService, updateContactPointApplicationService,
+		deleteContactPointApplicationService, sendContactPointVerificationApplicationService,
+		listAddressesApplicationService, getAddressApplicationService, addAddressApplicationService,
+		updateAddressApplicationService, deleteAddressApplicationService,
+		setAvatarApplicationService, getAvatarApplicationService, deleteAvatarApplicationService,
+		downloadFileApplicationService, verifyFileApplicationService, downloadFilesApplicationService,
+		updateFileApplicationService, listFoldersApplicationService, createFolderApplicationService,
+		updateFolderApplicationService, deleteFolderApplicationService,
+		createUploadApplicationService, getUploadApplicationService, patchUploadApplicationService,
+		deleteUploadApplicationService, deleteFileApplicationService,
+		getStorageUsageApplicationService, setStorageQuotaApplicationService, deleteStorageQuotaApplicationService,
+		createPresignedURLApplicationService, presignedDownloadApplicationService, presignedUploadApplicationService,
+		listFileVersionsApplicationService, downloadFileVersionApplicationService, restoreFileVersionApplicationService,
+		settings.UploadPolicy.LargestMaxSize(), settings.TrustedProxies,
 	)
 
+	if settings.FileScrubInterval > 0 {
+		scrubFilesApplicationService := service.NewScrubFilesApplicationService(mysqlRepository, fileRepository)
+		go scrubFilesApplicationService.Run(ctx, settings.FileScrubInterval)
+	}
+	if scanner != nil && settings.ScanAsync {
+		scanPendingFilesApplicationService := service.NewScanPendingFilesApplicationService(mysqlRepository, fileRepository, fileScanner)
+		go scanPendingFilesApplicationService.Run(ctx, settings.ScanInterval)
+	}
+	if settings.ReconcileInterval > 0 {
+		reconcileStorageApplicationService := service.NewReconcileStorageApplicationService(
+			mysqlRepository, fileRepository, settings.ReconcilePolicy)
+		go reconcileStorageApplicationService.Run(ctx, settings.ReconcileInterval)
+	}
+	go purgeUploadsApplicationService.Run(ctx, uploadPurgeInterval)
+	go purgeExportsApplicationService.Run(ctx, exportPurgeInterval)
+
 	srv := &http.Server{
 		Addr:    ":" + httpPort,
 		Handler: httpService.GetRouter(),